@@ -0,0 +1,239 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// artifacts.go - Workflow Run Artifacts
+// Purpose: Download run artifacts with progress, and open their archives in
+// memory so files can be previewed without extracting anything to disk.
+// The artifact browser (view_artifacts.go) drives these.
+// When to extend: Add other ways of handling artifact archives here
+
+// artifactsLoadedMsg carries the artifacts of a run
+type artifactsLoadedMsg struct {
+	runID     int64
+	artifacts []Artifact
+	err       error
+}
+
+// artifactDownloadMsg reports the progress of an artifact download, or its
+// result once finished is set
+type artifactDownloadMsg struct {
+	runID      int64
+	artifactID int64
+	done       int64 // Bytes received so far
+	finished   bool
+	path       string // Where the archive was saved
+	err        error
+	stream     <-chan artifactDownloadMsg
+}
+
+// artifactDownloadsDoneMsg reports that a batch of downloads has finished
+type artifactDownloadsDoneMsg struct {
+	runID int64
+}
+
+// artifactArchiveMsg carries an artifact's archive, read into memory
+type artifactArchiveMsg struct {
+	artifactID int64
+	archive    *zip.Reader
+	err        error
+}
+
+const (
+	artifactProgressInterval = 100 * time.Millisecond // Between progress updates
+	artifactPreviewLimit     = 100 << 20              // Larger archives can only be downloaded
+	artifactFileLimit        = 1 << 20                // Previews show the start of larger files
+)
+
+// errDownloadStopped is returned when the browser closes mid-download
+var errDownloadStopped = errors.New("download stopped")
+
+// downloadArtifacts saves the archives of artifacts into dir one after the
+// other, reporting progress as it goes. Closing stop abandons the downloads.
+func downloadArtifacts(repo string, runID int64, artifacts []Artifact, dir string, stop <-chan struct{}) tea.Cmd {
+	stream := make(chan artifactDownloadMsg, 4)
+
+	go func() {
+		defer close(stream)
+		send := func(msg artifactDownloadMsg) bool {
+			msg.runID = runID
+			msg.stream = stream
+			select {
+			case stream <- msg:
+				return true
+			case <-stop:
+				return false
+			}
+		}
+
+		repo, repoErr := resolveRepo(repo)
+		for _, artifact := range artifacts {
+			msg := artifactDownloadMsg{artifactID: artifact.ID, finished: true, err: repoErr}
+			if repoErr == nil {
+				msg.path, msg.done, msg.err = saveArtifact(repo, runID, artifact, dir, func(done int64) bool {
+					return send(artifactDownloadMsg{artifactID: artifact.ID, done: done})
+				})
+			}
+			if errors.Is(msg.err, errDownloadStopped) || !send(msg) {
+				return
+			}
+		}
+	}()
+
+	return waitForArtifactDownload(runID, stream)
+}
+
+// waitForArtifactDownload waits for the next update of a download batch
+func waitForArtifactDownload(runID int64, stream <-chan artifactDownloadMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return artifactDownloadsDoneMsg{runID: runID}
+		}
+		return msg
+	}
+}
+
+// saveArtifact downloads the archive of artifact to dir/<name>-<run ID>.zip,
+// calling progress now and then; progress returning false stops the download
+func saveArtifact(repo string, runID int64, artifact Artifact, dir string, progress func(done int64) bool) (string, int64, error) {
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(dir, "~/") {
+		dir = filepath.Join(home, dir[2:])
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, err
+	}
+	path := filepath.Join(dir, artifactFileName(artifact.Name, runID))
+
+	body, err := ghClient.DownloadArtifact(repo, artifact.ID)
+	if err != nil {
+		return "", 0, err
+	}
+	// Write next to the target so a failed download leaves nothing behind
+	f, err := os.Create(path + ".part")
+	if err != nil {
+		body.Close()
+		return "", 0, err
+	}
+
+	var done int64
+	last := time.Now()
+	buf := make([]byte, 32<<10)
+	for err == nil {
+		var n int
+		n, err = body.Read(buf)
+		if n > 0 {
+			if _, werr := f.Write(buf[:n]); werr != nil {
+				err = werr
+			}
+			done += int64(n)
+		}
+		if err == nil && time.Since(last) >= artifactProgressInterval {
+			last = time.Now()
+			if !progress(done) {
+				err = errDownloadStopped
+			}
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	if cerr := body.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(path+".part", path)
+	}
+	if err != nil {
+		os.Remove(path + ".part")
+		return "", done, err
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path, done, nil
+}
+
+// artifactFileName is the file an artifact's archive is saved as. Runs of a
+// workflow upload artifacts of the same name, so the run ID keeps one run's
+// download from replacing another's.
+func artifactFileName(name string, runID int64) string {
+	return fmt.Sprintf("%s-%d.zip", strings.NewReplacer("/", "_", `\`, "_").Replace(name), runID)
+}
+
+// fetchArtifactArchive reads an artifact's archive into memory for previews
+func fetchArtifactArchive(repo string, artifact Artifact) tea.Cmd {
+	return func() tea.Msg {
+		msg := artifactArchiveMsg{artifactID: artifact.ID}
+		if artifact.SizeInBytes > artifactPreviewLimit {
+			msg.err = fmt.Errorf("%s is too large to preview (%s); download it instead",
+				artifact.Name, formatBytes(artifact.SizeInBytes))
+			return msg
+		}
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		body, err := ghClient.DownloadArtifact(repo, artifact.ID)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		data, err := io.ReadAll(io.LimitReader(body, artifactPreviewLimit+1))
+		if cerr := body.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil && len(data) > artifactPreviewLimit {
+			err = fmt.Errorf("%s is too large to preview; download it instead", artifact.Name)
+		}
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		msg.archive, msg.err = zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		return msg
+	}
+}
+
+// readArtifactFile returns the start of a file in an artifact's archive,
+// and whether it looks like text at all
+func readArtifactFile(f *zip.File) (string, bool, error) {
+	r, err := f.Open()
+	if err != nil {
+		return "", false, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, artifactFileLimit))
+	if err != nil {
+		return "", false, err
+	}
+	// The limit may have cut a character in half
+	for i := 0; i < utf8.UTFMax-1 && len(data) > 0 && !utf8.Valid(data); i++ {
+		data = data[:len(data)-1]
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return "", false, nil
+	}
+	return string(data), true, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveArtifactKeepsOtherRuns(t *testing.T) {
+	c := newTestClient(t)
+	dir := t.TempDir()
+	artifact := c.Artifacts[8990][0]
+	keepGoing := func(int64) bool { return true }
+
+	// The same artifact name from two runs lands in two files
+	first, _, err := saveArtifact(fixtureRepo, 8990, artifact, dir, keepGoing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := saveArtifact(fixtureRepo, 8991, artifact, dir, keepGoing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Fatalf("both runs saved to %s", first)
+	}
+	if got := filepath.Base(first); got != "test-results-8990.zip" {
+		t.Errorf("saved as %s", got)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// batch.go - Batch Actions
+// Purpose: Run one action over the items marked in a list view, one item
+// after the other, reporting progress and per-item failures in the status bar.
+// When to extend: Build batchItems for new batch actions in the views
+
+// batchItem is one item of a batch action
+type batchItem struct {
+	key  string // Selection key, so failed items can stay marked
+	name string // How the item is shown, e.g. "#12" or "owner/repo"
+	run  func() error
+}
+
+// batchFailure is an item a batch action failed on
+type batchFailure struct {
+	key  string
+	name string
+	err  error
+}
+
+// batchProgressMsg reports the progress of a batch action, or its result
+// once finished is set. The view that started it gets the finished message.
+type batchProgressMsg struct {
+	view     ViewType
+	verb     string // What is being done, e.g. "Closing"
+	past     string // What was done, e.g. "Closed"
+	noun     string // What it is done to, plural, e.g. "issues"
+	done     int
+	total    int
+	failures []batchFailure
+	finished bool
+	stream   <-chan batchProgressMsg
+}
+
+// runBatch runs items in the background and streams their progress
+func runBatch(view ViewType, verb, past, noun string, items []batchItem) tea.Cmd {
+	stream := make(chan batchProgressMsg)
+
+	go func() {
+		defer close(stream)
+		msg := batchProgressMsg{view: view, verb: verb, past: past, noun: noun, total: len(items)}
+		for _, item := range items {
+			if err := item.run(); err != nil {
+				msg.failures = append(msg.failures, batchFailure{key: item.key, name: item.name, err: err})
+			}
+			msg.done++
+			msg.finished = msg.done == msg.total
+			update := msg
+			update.failures = append([]batchFailure(nil), msg.failures...)
+			update.stream = stream
+			stream <- update
+		}
+	}()
+
+	return waitForBatch(stream)
+}
+
+// waitForBatch waits for the next progress update of a batch action
+func waitForBatch(stream <-chan batchProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// batchStatus describes the progress or result of a batch action
+func batchStatus(msg batchProgressMsg) string {
+	if !msg.finished {
+		status := fmt.Sprintf("%s %s... %d/%d", msg.verb, msg.noun, msg.done, msg.total)
+		if len(msg.failures) > 0 {
+			status += fmt.Sprintf(", %d failed", len(msg.failures))
+		}
+		return status
+	}
+
+	succeeded := msg.total - len(msg.failures)
+	if len(msg.failures) == 0 {
+		return fmt.Sprintf("%s %d of %d %s", msg.past, succeeded, msg.total, msg.noun)
+	}
+	failures := make([]string, len(msg.failures))
+	for i, failure := range msg.failures {
+		failures[i] = failure.name + ": " + failure.err.Error()
+	}
+	return fmt.Sprintf("%s %d of %d %s; failed %s", msg.past, succeeded, msg.total, msg.noun, strings.Join(failures, "; "))
+}
+
+// reselectFailures leaves only the items a finished batch failed on marked,
+// ready to retry
+func reselectFailures(s *Selection, msg batchProgressMsg) {
+	s.Clear()
+	for _, failure := range msg.failures {
+		s.keys[failure.key] = true
+	}
+}
+
+// issueBatch builds a batch running action on issue or PR numbers in repo
+func issueBatch(repo string, numbers []int, action func(repo string, number int) error) []batchItem {
+	items := make([]batchItem, len(numbers))
+	for i, number := range numbers {
+		number := number
+		items[i] = batchItem{
+			key:  fmt.Sprint(number),
+			name: fmt.Sprintf("#%d", number),
+			run: func() error {
+				resolved, err := resolveRepo(repo)
+				if err != nil {
+					return err
+				}
+				return action(resolved, number)
+			},
+		}
+	}
+	return items
+}
+
+// repoBatch builds a batch running action on repositories
+func repoBatch(repos []string, action func(repo string) error) []batchItem {
+	items := make([]batchItem, len(repos))
+	for i, repo := range repos {
+		repo := repo
+		items[i] = batchItem{key: repo, name: repo, run: func() error { return action(repo) }}
+	}
+	return items
+}
+
+// addLabels adds labels to an issue or PR, for use with issueBatch
+func addLabels(labels []string) func(repo string, number int) error {
+	return func(repo string, number int) error {
+		_, err := ghClient.EditIssue(repo, number, IssueEdit{AddLabels: labels})
+		return err
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// cache.go - Persistent Data Cache
+// Purpose: On-disk cache of loaded GitHub lists under ~/.cache/gh-tui
+// Entries are keyed by kind and scope (repo or owner) and hold the first page
+// of a list as it was last fetched, with the ETag GitHub sent for it when the
+// list came from REST. Refreshes send the ETag back as If-None-Match, and a
+// 304 serves the entry (see listIfChanged in github.go). Once more than
+// Performance.CacheSize entries exist, the least recently used are evicted.
+
+// cacheEntry is a single cached list, stored as one JSON file
+type cacheEntry struct {
+	Key       string          `json:"key"`
+	ETag      string          `json:"etag,omitempty"` // "" for lists from GraphQL
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Page      PageInfo        `json:"page"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// cacheIndexEntry tracks an entry's file and last access for LRU eviction
+type cacheIndexEntry struct {
+	File     string    `json:"file"`
+	Accessed time.Time `json:"accessed"`
+}
+
+// DataCache is an LRU-bounded on-disk cache of list data
+type DataCache struct {
+	mu         sync.Mutex
+	dir        string
+	maxEntries int
+	index      map[string]cacheIndexEntry
+}
+
+// dataCache is the active cache, set up in main(); nil disables caching
+var dataCache *DataCache
+
+// NewDataCache opens (or creates) the cache in dir
+func NewDataCache(dir string, maxEntries int) *DataCache {
+	c := &DataCache{
+		dir:        dir,
+		maxEntries: maxEntries,
+		index:      make(map[string]cacheIndexEntry),
+	}
+
+	if data, err := os.ReadFile(c.indexPath()); err == nil {
+		json.Unmarshal(data, &c.index)
+	}
+
+	return c
+}
+
+// getCacheDir returns the cache directory (~/.cache/gh-tui)
+func getCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-tui")
+}
+
+// cacheKey builds the key for a kind of list in a scope
+func cacheKey(kind, scope string) string {
+	return kind + ":" + scope
+}
+
+func (c *DataCache) indexPath() string {
+	return filepath.Join(c.dir, "index.json")
+}
+
+// Load decodes the cached list for kind/scope into dest
+func (c *DataCache) Load(kind, scope string, dest interface{}) (cacheEntry, bool) {
+	if c == nil {
+		return cacheEntry{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey(kind, scope)
+	idx, ok := c.index[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, idx.File))
+	if err != nil {
+		delete(c.index, key)
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || json.Unmarshal(entry.Data, dest) != nil {
+		return cacheEntry{}, false
+	}
+
+	idx.Accessed = time.Now()
+	c.index[key] = idx
+	c.saveIndex()
+
+	return entry, true
+}
+
+// ETag returns the ETag the list for kind/scope was cached with, "" when it
+// isn't cached or came without one
+func (c *DataCache) ETag(kind, scope string) string {
+	if c == nil {
+		return ""
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	idx, ok := c.index[cacheKey(kind, scope)]
+	if !ok {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(c.dir, idx.File))
+	if err != nil {
+		return ""
+	}
+	var entry struct {
+		ETag string `json:"etag"`
+	}
+	json.Unmarshal(data, &entry)
+	return entry.ETag
+}
+
+// Store writes the list for kind/scope, fetched with etag, and evicts old
+// entries
+func (c *DataCache) Store(kind, scope string, value interface{}, page PageInfo, etag string) {
+	if c == nil || c.maxEntries <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return
+	}
+
+	key := cacheKey(kind, scope)
+	file := fmt.Sprintf("%x", sha256.Sum256([]byte(key)))[:16] + ".json"
+	path := filepath.Join(c.dir, file)
+
+	entry := cacheEntry{
+		Key:       key,
+		ETag:      etag,
+		FetchedAt: time.Now(),
+		Page:      page,
+		Data:      data,
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(path, encoded, 0644); err != nil {
+		return
+	}
+
+	c.index[key] = cacheIndexEntry{File: file, Accessed: time.Now()}
+	c.evict()
+	c.saveIndex()
+}
+
+// evict removes least recently used entries beyond maxEntries
+func (c *DataCache) evict() {
+	if len(c.index) <= c.maxEntries {
+		return
+	}
+
+	keys := make([]string, 0, len(c.index))
+	for key := range c.index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.index[keys[i]].Accessed.Before(c.index[keys[j]].Accessed)
+	})
+
+	for _, key := range keys[:len(keys)-c.maxEntries] {
+		os.Remove(filepath.Join(c.dir, c.index[key].File))
+		delete(c.index, key)
+	}
+}
+
+// saveIndex persists the LRU index
+func (c *DataCache) saveIndex() {
+	data, err := json.Marshal(c.index)
+	if err != nil {
+		return
+	}
+	os.WriteFile(c.indexPath(), data, 0644)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDataCacheRoundTrip(t *testing.T) {
+	c := NewDataCache(t.TempDir(), 10)
+	page := PageInfo{HasNextPage: true, EndCursor: "50", TotalCount: 120}
+	c.Store("prs", fixtureRepo, []PullRequest{{Number: 42, Title: "Add pluggable GitHub backend"}}, page, "")
+
+	var prs []PullRequest
+	entry, ok := c.Load("prs", fixtureRepo, &prs)
+	if !ok {
+		t.Fatal("entry not found")
+	}
+	if len(prs) != 1 || prs[0].Number != 42 || entry.Page != page || entry.FetchedAt.IsZero() {
+		t.Errorf("loaded %+v, %+v", prs, entry)
+	}
+
+	if _, ok := c.Load("issues", fixtureRepo, &prs); ok {
+		t.Error("found issues that were never stored")
+	}
+
+	// A nil cache and a cache of size 0 keep nothing
+	var disabled *DataCache
+	disabled.Store("prs", fixtureRepo, prs, page, "")
+	if _, ok := disabled.Load("prs", fixtureRepo, &prs); ok {
+		t.Error("nil cache loaded an entry")
+	}
+	empty := NewDataCache(t.TempDir(), 0)
+	empty.Store("prs", fixtureRepo, prs, page, "")
+	if _, ok := empty.Load("prs", fixtureRepo, &prs); ok {
+		t.Error("cache of size 0 loaded an entry")
+	}
+}
+
+func TestDataCacheEviction(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []string // "store <scope>" or "load <scope>", in order
+		want []string // Scopes still cached
+	}{
+		{
+			name: "under the limit",
+			ops:  []string{"store a", "store b"},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "oldest store evicted",
+			ops:  []string{"store a", "store b", "store c", "store d"},
+			want: []string{"b", "c", "d"},
+		},
+		{
+			name: "loading keeps an entry",
+			ops:  []string{"store a", "store b", "store c", "load a", "store d"},
+			want: []string{"a", "c", "d"},
+		},
+		{
+			name: "storing again keeps an entry",
+			ops:  []string{"store a", "store b", "store c", "store a", "store d", "store e"},
+			want: []string{"a", "d", "e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			c := NewDataCache(dir, 3)
+			for _, op := range tt.ops {
+				// Access times must differ for the order to be well defined
+				time.Sleep(time.Millisecond)
+				var scopes []string
+				if op[:5] == "store" {
+					c.Store("prs", op[6:], []string{op[6:]}, PageInfo{}, "")
+				} else if _, ok := c.Load("prs", op[5:], &scopes); !ok {
+					t.Fatalf("%s: not found", op)
+				}
+			}
+
+			// The index on disk agrees, and evicted files are gone
+			c = NewDataCache(dir, 3)
+			var got []string
+			for _, scope := range []string{"a", "b", "c", "d", "e"} {
+				var scopes []string
+				if _, ok := c.Load("prs", scope, &scopes); ok {
+					got = append(got, scope)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cached %q, want %q", got, tt.want)
+			}
+			files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+			if len(files) != len(tt.want)+1 {
+				t.Errorf("%d files in the cache for %d entries and the index", len(files), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestDataCacheMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	c := NewDataCache(dir, 10)
+	c.Store("repos", "octocat", []Repository{{Name: "dotfiles"}}, PageInfo{}, "")
+	os.Remove(filepath.Join(dir, c.index[cacheKey("repos", "octocat")].File))
+
+	var repos []Repository
+	if _, ok := c.Load("repos", "octocat", &repos); ok {
+		t.Error("loaded an entry whose file was removed")
+	}
+	if _, ok := c.index[cacheKey("repos", "octocat")]; ok {
+		t.Error("index still lists the removed entry")
+	}
+}
+
+func TestListIfChanged(t *testing.T) {
+	previous := dataCache
+	dataCache = NewDataCache(t.TempDir(), 10)
+	t.Cleanup(func() { dataCache = previous })
+
+	// What the server has, and the ETags it was sent
+	current := []string{"first"}
+	currentTag := `"v1"`
+	var sent []string
+	list := func(dest *[]string) func(etag string) (PageInfo, string, error) {
+		return func(etag string) (PageInfo, string, error) {
+			sent = append(sent, etag)
+			if etag == currentTag {
+				return PageInfo{}, etag, errNotModified
+			}
+			*dest = current
+			return PageInfo{TotalCount: len(current)}, currentTag, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  []string // New server content, nil for none
+		want    []string
+		wantTag string // ETag sent
+	}{
+		{name: "not cached", want: []string{"first"}, wantTag: ""},
+		{name: "unchanged", want: []string{"first"}, wantTag: `"v1"`},
+		{name: "changed", change: []string{"first", "second"}, want: []string{"first", "second"}, wantTag: `"v1"`},
+		{name: "unchanged again", want: []string{"first", "second"}, wantTag: `"v2"`},
+	}
+	for i, tt := range tests {
+		if tt.change != nil {
+			current, currentTag = tt.change, `"v2"`
+		}
+		var got []string
+		page, err := listIfChanged("runs", fixtureRepo, &got, list(&got))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || page.TotalCount != len(tt.want) {
+			t.Errorf("%s: got %q, %+v; want %q", tt.name, got, page, tt.want)
+		}
+		if sent[i] != tt.wantTag {
+			t.Errorf("%s: sent ETag %q, want %q", tt.name, sent[i], tt.wantTag)
+		}
+		if tag := dataCache.ETag("runs", fixtureRepo); tag != currentTag {
+			t.Errorf("%s: cached ETag %q, want %q", tt.name, tag, currentTag)
+		}
+	}
+
+	// Failures are returned, not served from the cache
+	sent = nil
+	failing := func(etag string) (PageInfo, string, error) {
+		sent = append(sent, etag)
+		return PageInfo{}, "", errors.New("offline")
+	}
+	if _, err := listIfChanged("gists", "@me", new([]string), failing); err == nil || len(sent) != 1 {
+		t.Errorf("err = %v after %d requests", err, len(sent))
+	}
+}
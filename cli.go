@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// cli.go - Command-Line Interface
+// Purpose: Flag parsing and non-interactive subcommands
+// When to extend: Add new flags to parseArgs, new subcommands to runCommand
+//
+// Usage:
+//   gh-tui [--repo owner/name] [--tab issues] [--no-landing] [--config path] [--theme name]
+//   gh-tui export <prs|issues|repos|runs|gists> [--repo owner/name] [--format json|tsv] [--limit n]
+
+// cliOptions holds the parsed command line
+type cliOptions struct {
+	repo       string
+	tab        string
+	noLanding  bool
+	configPath string
+	theme      string
+
+	// command is the subcommand and its arguments, e.g. ["export", "prs"]
+	command []string
+}
+
+// tabNames maps --tab values to views
+var tabNames = map[string]ViewType{
+	"prs":           ViewPullRequests,
+	"pulls":         ViewPullRequests,
+	"pull-requests": ViewPullRequests,
+	"issues":        ViewIssues,
+	"repos":         ViewRepositories,
+	"repositories":  ViewRepositories,
+	"actions":       ViewActions,
+	"runs":          ViewActions,
+	"gists":         ViewGists,
+	"search":        ViewSearch,
+	"notifications": ViewNotifications,
+	"inbox":         ViewNotifications,
+	"dashboard":     ViewDashboard,
+	"home":          ViewDashboard,
+}
+
+// parseArgs parses command-line arguments (without the program name)
+func parseArgs(args []string) (cliOptions, error) {
+	var opts cliOptions
+
+	fs := flag.NewFlagSet("gh-tui", flag.ContinueOnError)
+	fs.StringVar(&opts.repo, "repo", "", "repository to open (owner/name); defaults to the current directory's repo")
+	fs.StringVar(&opts.tab, "tab", "", "tab to start on: prs, issues, repos, actions, gists, search, notifications, dashboard, a custom tab's name (or its number, 1-8 and then the custom tabs)")
+	fs.BoolVar(&opts.noLanding, "no-landing", false, "skip the landing page")
+	fs.StringVar(&opts.configPath, "config", "", "config file (default ~/.config/gh-tui/config.yaml)")
+	fs.StringVar(&opts.theme, "theme", "", "theme: dark, light, solarized, dracula, nord, custom")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n")
+		fmt.Fprintf(fs.Output(), "  gh-tui [flags]\n")
+		fmt.Fprintf(fs.Output(), "  gh-tui export <prs|issues|repos|runs|gists> [--repo owner/name] [--format json|tsv] [--limit n]\n\n")
+		fmt.Fprintf(fs.Output(), "Flags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	opts.command = fs.Args()
+
+	return opts, nil
+}
+
+// parseTab resolves a --tab value by name or 1-based number: the built-in
+// tabs and then the custom tabs of the config, in tab bar order
+func parseTab(name string, tabs []TabConfig) (ViewType, error) {
+	if vt, ok := tabNames[strings.ToLower(name)]; ok {
+		return vt, nil
+	}
+	for i, tab := range tabs {
+		if strings.EqualFold(tab.Name, name) {
+			return ViewCustom + ViewType(i), nil
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= 1 && n <= len(builtinTabs)+len(tabs) {
+		if n <= len(builtinTabs) {
+			return builtinTabs[n-1], nil
+		}
+		return ViewCustom + ViewType(n-len(builtinTabs)-1), nil
+	}
+	return 0, fmt.Errorf("unknown tab %q (prs, issues, repos, actions, gists, search, notifications, dashboard or a custom tab)", name)
+}
+
+// applyConfig applies flag overrides to the loaded config, and checks the
+// flags that depend on it
+func (o cliOptions) applyConfig(cfg *Config) error {
+	if o.tab != "" {
+		if _, err := parseTab(o.tab, cfg.Tabs); err != nil {
+			return err
+		}
+	}
+	if o.theme == "" {
+		return nil
+	}
+	if err := applyNamedTheme(o.theme, cfg.CustomTheme); err != nil {
+		return err
+	}
+	cfg.Theme = o.theme
+	return nil
+}
+
+// applyModel applies flag overrides to the initial model, made from cfg
+func (o cliOptions) applyModel(m *model, cfg Config) {
+	if o.repo != "" {
+		m.currentRepo = o.repo
+		m.scopeViews(o.repo)
+		saveRecentRepo(o.repo)
+	}
+
+	if o.tab != "" {
+		vt, _ := parseTab(o.tab, cfg.Tabs)
+		m.switchToView(vt)
+		if m.landingPage != nil {
+			m.landingPage.SetSelectedItem(int(vt))
+		}
+	}
+
+	if o.noLanding {
+		m.showLandingPage = false
+	}
+}
+
+// runCommand runs a non-interactive subcommand and returns the exit code
+func runCommand(opts cliOptions, out io.Writer) int {
+	switch opts.command[0] {
+	case "export":
+		if err := runExport(opts.command[1:], opts.repo, out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q (available: export)\n", opts.command[0])
+		return 2
+	}
+}
+
+// runExport writes a list of GitHub items to out
+func runExport(args []string, repo string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.StringVar(&repo, "repo", repo, "repository (owner/name); repos lists its owner's; defaults to the current directory's repo")
+	format := fs.String("format", "json", "output format: json or tsv")
+	limit := fs.Int("limit", 0, "maximum number of items (0 for all)")
+
+	// Accept flags both before and after the kind
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: gh-tui export <prs|issues|repos|runs|gists> [--format json|tsv]")
+	}
+	kind := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	if *format != "json" && *format != "tsv" {
+		return fmt.Errorf("unknown format %q (json, tsv)", *format)
+	}
+
+	items, err := exportItems(kind, repo, *limit)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(items)
+	}
+	return writeTSV(out, items)
+}
+
+// exportRow is implemented by items that can be written as TSV
+type exportRow interface {
+	tsvFields() []string
+}
+
+// exportItems loads every page of a list, up to limit items
+func exportItems(kind, repo string, limit int) ([]exportRow, error) {
+	rows := []exportRow{}
+	cursor := ""
+
+	for {
+		var (
+			page PageInfo
+			err  error
+		)
+
+		switch kind {
+		case "prs", "pulls", "pull-requests":
+			var prs []PullRequest
+			if repo, err = resolveRepo(repo); err == nil {
+				prs, page, err = ghClient.ListPullRequests(repo, cursor)
+			}
+			for _, pr := range prs {
+				rows = append(rows, pr)
+			}
+		case "issues":
+			var issues []Issue
+			if repo, err = resolveRepo(repo); err == nil {
+				issues, page, err = ghClient.ListIssues(repo, cursor)
+			}
+			for _, issue := range issues {
+				rows = append(rows, issue)
+			}
+		case "repos", "repositories":
+			// The repos of --repo's owner
+			var repos []Repository
+			owner := strings.SplitN(repo, "/", 2)[0]
+			if owner == "" {
+				owner, err = ghClient.CurrentUser()
+			}
+			if err == nil {
+				repos, page, err = ghClient.ListRepositories(owner, cursor)
+			}
+			for _, r := range repos {
+				rows = append(rows, r)
+			}
+		case "runs", "actions":
+			var runs []WorkflowRun
+			if repo, err = resolveRepo(repo); err == nil {
+				runs, page, err = ghClient.ListWorkflowRuns(repo, cursor)
+			}
+			for _, run := range runs {
+				rows = append(rows, run)
+			}
+		case "gists":
+			var gists []Gist
+			gists, page, err = ghClient.ListGists(cursor)
+			for _, gist := range gists {
+				rows = append(rows, gist)
+			}
+		default:
+			return nil, fmt.Errorf("unknown list %q (prs, issues, repos, runs, gists)", kind)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if limit > 0 && len(rows) >= limit {
+			return rows[:limit], nil
+		}
+		if !page.HasNextPage {
+			return rows, nil
+		}
+		cursor = page.EndCursor
+	}
+}
+
+// writeTSV writes one tab-separated line per item
+func writeTSV(out io.Writer, rows []exportRow) error {
+	for _, row := range rows {
+		fields := row.tsvFields()
+		for i, field := range fields {
+			fields[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(field)
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pr PullRequest) tsvFields() []string {
+	return []string{strconv.Itoa(pr.Number), pr.State, pr.Author.Login, pr.Title, pr.URL}
+}
+
+func (issue Issue) tsvFields() []string {
+	return []string{strconv.Itoa(issue.Number), issue.State, issue.Author.Login, issue.Title, issue.URL}
+}
+
+func (repo Repository) tsvFields() []string {
+	return []string{repo.NameWithOwner, strconv.Itoa(repo.StargazerCount), repo.Description, repo.URL}
+}
+
+func (run WorkflowRun) tsvFields() []string {
+	return []string{strconv.FormatInt(run.DatabaseId, 10), run.Name, run.Status, run.Conclusion, run.HeadBranch, run.URL}
+}
+
+func (gist Gist) tsvFields() []string {
+	return []string{gist.ID, gist.Description, strconv.FormatBool(gist.Public), gist.URL}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseTab(t *testing.T) {
+	tabs := []TabConfig{
+		{Name: "Review requests", Kind: "prs"},
+		{Name: "Failing main", Kind: "runs"},
+	}
+
+	tests := []struct {
+		name string
+		want ViewType
+	}{
+		{"issues", ViewIssues},
+		{"2", ViewIssues},
+		{"8", ViewDashboard},
+		{"failing MAIN", ViewCustom + 1},
+		{"9", ViewCustom},
+		{"10", ViewCustom + 1},
+	}
+	for _, tt := range tests {
+		got, err := parseTab(tt.name, tabs)
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	for _, name := range []string{"0", "11", "nope"} {
+		if _, err := parseTab(name, tabs); err == nil {
+			t.Errorf("%q: no error", name)
+		}
+	}
+	if _, err := parseTab("9", nil); err == nil {
+		t.Error("9 without custom tabs: no error")
+	}
+}
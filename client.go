@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// client.go - GitHub Backend Abstraction
+// Purpose: The GitHubClient interface that every fetcher and action goes through
+// When to extend: Add a method here, then implement it in every backend (client_*.go)
+
+// GitHubClient is the backend used to talk to GitHub.
+// All tea.Cmds in github.go, helpers.go and gist_editor.go call through it,
+// so views never depend on how the data is actually retrieved.
+type GitHubClient interface {
+	// Session
+	CheckAuth() error
+	CurrentRepo() (string, error)
+	CurrentUser() (string, error)
+
+	// Listing - one page per call; pass PageInfo.EndCursor to get the next
+	ListPullRequests(repo, cursor string) ([]PullRequest, PageInfo, error)
+	ListIssues(repo, cursor string) ([]Issue, PageInfo, error)
+	ListRepositories(owner, cursor string) ([]Repository, PageInfo, error)
+	ListWorkflowRuns(repo, cursor string) ([]WorkflowRun, PageInfo, error)
+	ListGists(cursor string) ([]Gist, PageInfo, error)
+
+	// Listing what matches a saved query of a custom tab: GitHub search
+	// syntax within repo for PRs and issues, qualifiers like branch:main for runs
+	ListPullRequestsMatching(repo, query, cursor string) ([]PullRequest, PageInfo, error)
+	ListIssuesMatching(repo, query, cursor string) ([]Issue, PageInfo, error)
+	ListWorkflowRunsMatching(repo, query, cursor string) ([]WorkflowRun, PageInfo, error)
+
+	// Pull requests
+	PullRequestDetail(repo string, number int) (PullRequestDetail, error)
+	PullRequestDiff(repo string, number int) (string, error)
+	SubmitReview(repo string, number int, review ReviewSubmission) error
+	MergeInfo(repo string, number int) (MergeInfo, error)
+	MergePullRequest(repo string, number int, req MergeRequest) error
+	DeleteBranch(repo, branch string) error
+
+	// Actions
+	WorkflowRunDetail(repo string, runID int64) (WorkflowRunDetail, error)
+	WorkflowRunLogs(repo string, runID int64) ([]byte, error) // Zip archive, see logs.go
+	RerunWorkflowRun(repo string, runID int64, failedOnly, debug bool) error
+	CancelWorkflowRun(repo string, runID int64) error
+	ListWorkflows(repo string) ([]Workflow, error)
+	ListEnvironments(repo string) ([]string, error)
+	DispatchWorkflow(repo string, workflowID int64, ref string, inputs map[string]string) error
+	ListDispatchRuns(repo string, workflowID int64, branch string) ([]WorkflowRun, error) // Newest first
+	ListArtifacts(repo string, runID int64) ([]Artifact, error)
+	DownloadArtifact(repo string, artifactID int64) (io.ReadCloser, error) // Zip archive, streamed
+
+	// Browser
+	OpenInBrowser(itemType, identifier, repo string) error
+	CreateIssueInBrowser(repo string) error
+
+	// Repositories
+	IsRepoStarred(repo string) (bool, error)
+	StarRepo(repo string) error
+	UnstarRepo(repo string) error
+	CloneRepository(repo string) error
+	ForkRepository(repo string) error
+	RepoFile(repo, path, ref string) ([]byte, error)      // ref "" is the default branch
+	RepoDir(repo, path string) (map[string]string, error) // Text files in path on the default branch; empty when missing
+	DefaultBranch(repo string) (string, error)
+
+	// Issues
+	IssueDetail(repo string, number int) (IssueDetail, error)
+	CreateIssue(repo string, issue NewIssue) (Issue, error)
+	AddComment(repo string, number int, body string) error // Issues and PRs alike
+	ListLabels(repo string) ([]Label, error)
+	ListAssignableUsers(repo string) ([]string, error)
+	ListMilestones(repo string) ([]Milestone, error) // Open milestones only
+	EditIssue(repo string, number int, edit IssueEdit) (Issue, error)
+	CloseIssue(repo string, number int) error
+	ReopenIssue(repo string, number int) error
+
+	// Search - GitHub search syntax across all of GitHub; each returns up to
+	// one page of results and the total number of matches
+	SearchIssues(query string) ([]SearchIssue, int, error) // Issues and PRs
+	SearchRepositories(query string) ([]Repository, int, error)
+	SearchCode(query string) ([]CodeResult, int, error)
+
+	// Notifications - the authenticated user's inbox, newest first, read
+	// and unread; participating leaves out threads the user only watches
+	ListNotifications(participating bool, cursor string) ([]Notification, PageInfo, error)
+	MarkNotificationRead(threadID string) error
+	MarkNotificationDone(threadID string) error
+	UnsubscribeNotification(threadID string) error // Until the user is mentioned or comments again
+
+	// Gists
+	GistFileContent(gistID, filename string) ([]byte, error)
+	UpdateGistFile(gistID, path string) error
+	CreateGist(path, description string, public bool) error
+}
+
+// conditionalLister is implemented by backends that can ask for the first
+// page of a REST list only if it changed since it was fetched with etag.
+// They return the page's ETag, or errNotModified when it hasn't changed.
+type conditionalLister interface {
+	ListWorkflowRunsIfChanged(repo, etag string) ([]WorkflowRun, PageInfo, string, error)
+	ListGistsIfChanged(etag string) ([]Gist, PageInfo, string, error)
+}
+
+// errNotModified is returned by a conditionalLister for a list that hasn't
+// changed
+var errNotModified = errors.New("not modified")
+
+// ghClient is the active backend, selected from config in main()
+var ghClient GitHubClient = NewGHCLIClient()
+
+// newGitHubClient creates the backend selected by cfg.GitHub.Backend
+func newGitHubClient(cfg Config) (GitHubClient, error) {
+	switch cfg.GitHub.Backend {
+	case "", "gh":
+		return NewGHCLIClient(), nil
+	case "api":
+		return NewAPIClient(cfg.GitHub.BaseURL)
+	case "fake":
+		return NewFakeClient(cfg.GitHub.FixturesDir)
+	default:
+		return nil, fmt.Errorf("unknown GitHub backend: %s", cfg.GitHub.Backend)
+	}
+}
+
+// resolveRepo returns repo, or the current directory's repo when repo is empty
+func resolveRepo(repo string) (string, error) {
+	if repo != "" {
+		return repo, nil
+	}
+	current, err := ghClient.CurrentRepo()
+	if err != nil {
+		return "", fmt.Errorf("no repo specified and not in a git repo")
+	}
+	return current, nil
+}
+
+// staleRepo reports whether data loaded for repo was asked for before a view
+// of viewRepo switched to it. A view that never switched shows the current
+// directory's repo, which everything it loaded is for.
+func staleRepo(repo, viewRepo string) bool {
+	return viewRepo != "" && repo != viewRepo
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// client_actions.go - Shared Actions Queries
+// Purpose: Per-run queries and actions shared by the gh and api backends
+// When to extend: Add GitHub Actions REST calls here and call them from each backend
+
+// apiWorkflowStep is the REST representation of a job step
+type apiWorkflowStep struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// apiWorkflowJob is the REST representation of a run's job
+type apiWorkflowJob struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Conclusion  string            `json:"conclusion"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+	HTMLURL     string            `json:"html_url"`
+	Steps       []apiWorkflowStep `json:"steps"`
+}
+
+// toWorkflowJob converts a REST job to our WorkflowJob structure
+func (j apiWorkflowJob) toWorkflowJob() WorkflowJob {
+	job := WorkflowJob{
+		ID:          j.ID,
+		Name:        j.Name,
+		Status:      j.Status,
+		Conclusion:  j.Conclusion,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.CompletedAt,
+		URL:         j.HTMLURL,
+	}
+	for _, step := range j.Steps {
+		job.Steps = append(job.Steps, WorkflowStep(step))
+	}
+	return job
+}
+
+// queryWorkflowRunDetail fetches a run's current status and its jobs with their steps
+func queryWorkflowRunDetail(r restGetter, repo string, runID int64) (WorkflowRunDetail, error) {
+	if _, _, err := splitRepo(repo); err != nil {
+		return WorkflowRunDetail{}, err
+	}
+
+	var run apiWorkflowRun
+	if err := r.restGet(fmt.Sprintf("/repos/%s/actions/runs/%d", repo, runID), &run); err != nil {
+		return WorkflowRunDetail{}, err
+	}
+
+	// Runs with more than 100 jobs (large matrices) are cut off
+	var data struct {
+		Jobs []apiWorkflowJob `json:"jobs"`
+	}
+	if err := r.restGet(fmt.Sprintf("/repos/%s/actions/runs/%d/jobs?per_page=100", repo, runID), &data); err != nil {
+		return WorkflowRunDetail{}, err
+	}
+
+	detail := WorkflowRunDetail{Run: run.toWorkflowRun()}
+	for _, job := range data.Jobs {
+		detail.Jobs = append(detail.Jobs, job.toWorkflowJob())
+	}
+	return detail, nil
+}
+
+// rerunWorkflowRun re-runs a completed run, or only its failed jobs (and
+// the jobs they depend on)
+func rerunWorkflowRun(r restCaller, repo string, runID int64, failedOnly, debug bool) error {
+	path := fmt.Sprintf("/repos/%s/actions/runs/%d/rerun", repo, runID)
+	if failedOnly {
+		path += "-failed-jobs"
+	}
+	body := map[string]interface{}{"enable_debug_logging": debug}
+	return r.rest("POST", path, body, nil)
+}
+
+// cancelWorkflowRun asks GitHub to cancel a queued or running run
+// Cancelling is asynchronous; the run completes as cancelled shortly after.
+func cancelWorkflowRun(r restCaller, repo string, runID int64) error {
+	return r.rest("POST", fmt.Sprintf("/repos/%s/actions/runs/%d/cancel", repo, runID), nil, nil)
+}
+
+// apiWorkflow is the REST representation of a workflow
+type apiWorkflow struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// queryWorkflows lists the workflows of a repo
+func queryWorkflows(r restGetter, repo string) ([]Workflow, error) {
+	var data struct {
+		Workflows []apiWorkflow `json:"workflows"`
+	}
+	if err := r.restGet(fmt.Sprintf("/repos/%s/actions/workflows?per_page=100", repo), &data); err != nil {
+		return nil, err
+	}
+
+	workflows := make([]Workflow, len(data.Workflows))
+	for i, w := range data.Workflows {
+		workflows[i] = Workflow{ID: w.ID, Name: w.Name, Path: w.Path, State: w.State, URL: w.HTMLURL}
+	}
+	return workflows, nil
+}
+
+// queryArtifacts lists the artifacts of a run
+func queryArtifacts(r restGetter, repo string, runID int64) ([]Artifact, error) {
+	var data struct {
+		Artifacts []struct {
+			ID          int64     `json:"id"`
+			Name        string    `json:"name"`
+			SizeInBytes int64     `json:"size_in_bytes"`
+			Expired     bool      `json:"expired"`
+			CreatedAt   time.Time `json:"created_at"`
+			ExpiresAt   time.Time `json:"expires_at"`
+		} `json:"artifacts"`
+	}
+	if err := r.restGet(fmt.Sprintf("/repos/%s/actions/runs/%d/artifacts?per_page=100", repo, runID), &data); err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]Artifact, len(data.Artifacts))
+	for i, a := range data.Artifacts {
+		artifacts[i] = Artifact(a)
+	}
+	return artifacts, nil
+}
+
+// queryEnvironments lists the names of a repo's deployment environments
+func queryEnvironments(r restGetter, repo string) ([]string, error) {
+	var data struct {
+		Environments []struct {
+			Name string `json:"name"`
+		} `json:"environments"`
+	}
+	if err := r.restGet(fmt.Sprintf("/repos/%s/environments?per_page=100", repo), &data); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(data.Environments))
+	for i, env := range data.Environments {
+		names[i] = env.Name
+	}
+	return names, nil
+}
+
+// dispatchWorkflow triggers a workflow_dispatch run of a workflow at ref
+// GitHub doesn't return the new run; see queryDispatchRuns.
+func dispatchWorkflow(r restCaller, repo string, workflowID int64, ref string, inputs map[string]string) error {
+	body := map[string]interface{}{"ref": ref}
+	if len(inputs) > 0 {
+		body["inputs"] = inputs
+	}
+	return r.rest("POST", fmt.Sprintf("/repos/%s/actions/workflows/%d/dispatches", repo, workflowID), body, nil)
+}
+
+// queryDispatchRuns lists the latest manually dispatched runs of a workflow on branch
+func queryDispatchRuns(r restGetter, repo string, workflowID int64, branch string) ([]WorkflowRun, error) {
+	var data struct {
+		WorkflowRuns []apiWorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/actions/workflows/%d/runs?event=workflow_dispatch&branch=%s&per_page=10",
+		repo, workflowID, url.QueryEscape(branch))
+	if err := r.restGet(path, &data); err != nil {
+		return nil, err
+	}
+
+	runs := make([]WorkflowRun, len(data.WorkflowRuns))
+	for i, run := range data.WorkflowRuns {
+		runs[i] = run.toWorkflowRun()
+	}
+	return runs, nil
+}
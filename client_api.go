@@ -0,0 +1,782 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// client_api.go - Native GitHub API Backend
+// Purpose: GitHubClient implementation that talks to the REST and GraphQL APIs
+// over net/http, so the gh binary is not required at runtime.
+// Token lookup order, as gh does it: GH_ENTERPRISE_TOKEN and
+// GITHUB_ENTERPRISE_TOKEN for hosts other than github.com, GH_TOKEN,
+// GITHUB_TOKEN, gh's hosts.yml, then `gh auth token` for tokens gh keeps in
+// the system keyring
+
+const defaultAPIBaseURL = "https://api.github.com"
+
+// APIClient implements GitHubClient using the GitHub REST and GraphQL APIs
+type APIClient struct {
+	baseURL    string // REST root, e.g. https://api.github.com or https://ghe.example.com/api/v3
+	graphqlURL string
+	webURL     string // Browser root, e.g. https://github.com
+	token      string
+	http       *http.Client
+}
+
+// NewAPIClient creates an API backend for baseURL (api.github.com if empty)
+func NewAPIClient(baseURL string) (*APIClient, error) {
+	if baseURL == "" {
+		baseURL = defaultAPIBaseURL
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid API base URL: %s", baseURL)
+	}
+
+	c := &APIClient{
+		baseURL: baseURL,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+
+	// Derive GraphQL and web roots from the REST root
+	host := u.Host
+	switch {
+	case baseURL == defaultAPIBaseURL:
+		c.graphqlURL = baseURL + "/graphql"
+		c.webURL = "https://github.com"
+		host = "github.com"
+	case strings.HasSuffix(u.Path, "/api/v3"):
+		// GitHub Enterprise Server
+		root := strings.TrimSuffix(baseURL, "/api/v3")
+		c.graphqlURL = root + "/api/graphql"
+		c.webURL = root
+	default:
+		// Local mock servers and proxies serve everything under one root
+		c.graphqlURL = baseURL + "/graphql"
+		c.webURL = baseURL
+	}
+
+	c.token = lookupToken(host)
+	return c, nil
+}
+
+// lookupToken finds a token for host in the environment or in gh's config
+func lookupToken(host string) string {
+	envs := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != "github.com" {
+		envs = append([]string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}, envs...)
+	}
+	for _, env := range envs {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+
+	if token := hostsFileToken(host); token != "" {
+		return token
+	}
+	return ghAuthToken(host)
+}
+
+// hostsFileToken reads the token of host from gh's hosts.yml, where gh
+// keeps it when it can't use the system keyring
+func hostsFileToken(host string) string {
+	configDir := os.Getenv("GH_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config", "gh")
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+	return hosts[host].OAuthToken
+}
+
+// ghAuthToken asks gh for the token of host, which also finds tokens kept in
+// the system keyring; empty when gh isn't installed or isn't logged in
+func ghAuthToken(host string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	output, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// apiError is returned for non-2xx API responses
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("GitHub API %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("GitHub API %d", e.Status)
+}
+
+// newRequest builds an authenticated API request
+func (c *APIClient) newRequest(method, rawURL string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, rawURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// rest performs a REST call against path and decodes the response into out
+func (c *APIClient) rest(method, path string, body, out interface{}) error {
+	req, err := c.newRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// restRaw performs a REST GET with a custom media type and returns the raw body
+// e.g. application/vnd.github.diff for pull request diffs
+func (c *APIClient) restRaw(path, accept string) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// checkResponse turns a non-2xx response into an apiError
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	var payload struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(resp.Body).Decode(&payload)
+	return &apiError{Status: resp.StatusCode, Message: payload.Message}
+}
+
+// graphql runs a GraphQL query and decodes its data into out
+func (c *APIClient) graphql(query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	req, err := c.newRequest(http.MethodPost, c.graphqlURL, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{Status: resp.StatusCode}
+	}
+
+	return decodeGraphQLResponse(resp.Body, out)
+}
+
+// restGet performs a REST GET against path
+func (c *APIClient) restGet(path string, out interface{}) error {
+	return c.rest(http.MethodGet, path, nil, out)
+}
+
+// restGetIfChanged performs a REST GET against path sending etag as
+// If-None-Match, and returns the response's ETag. It returns errNotModified
+// for a 304, which GitHub doesn't count against the rate limit.
+func (c *APIClient) restGetIfChanged(path, etag string, out interface{}) (string, error) {
+	req, err := c.newRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return etag, errNotModified
+	}
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+	return resp.Header.Get("ETag"), json.NewDecoder(resp.Body).Decode(out)
+}
+
+// conditionalGetter makes the REST GETs of a query conditional on etag
+type conditionalGetter struct {
+	c    *APIClient
+	etag string // Sent as If-None-Match, then the ETag of the response
+}
+
+func (g *conditionalGetter) restGet(path string, out interface{}) error {
+	etag, err := g.c.restGetIfChanged(path, g.etag, out)
+	g.etag = etag
+	return err
+}
+
+// splitRepo splits "owner/name" into its parts
+func splitRepo(repo string) (string, string, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid repository: %q (expected owner/name)", repo)
+	}
+	return parts[0], parts[1], nil
+}
+
+// CheckAuth verifies a token is available and accepted by the API
+func (c *APIClient) CheckAuth() error {
+	if c.token == "" {
+		return fmt.Errorf("no GitHub token found. Set GH_TOKEN or run: gh auth login")
+	}
+	if _, err := c.CurrentUser(); err != nil {
+		return fmt.Errorf("GitHub token rejected: %w", err)
+	}
+	return nil
+}
+
+// CurrentRepo derives owner/name from the origin remote of the cwd
+func (c *APIClient) CurrentRepo() (string, error) {
+	output, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", err
+	}
+	return parseRemoteURL(strings.TrimSpace(string(output)))
+}
+
+// parseRemoteURL extracts owner/name from an https or ssh git remote
+func parseRemoteURL(remote string) (string, error) {
+	remote = strings.TrimSuffix(remote, ".git")
+
+	// git@github.com:owner/name
+	if i := strings.Index(remote, ":"); i >= 0 && !strings.Contains(remote, "://") {
+		remote = remote[i+1:]
+	} else if u, err := url.Parse(remote); err == nil {
+		remote = strings.TrimPrefix(u.Path, "/")
+	}
+
+	parts := strings.Split(remote, "/")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("cannot parse remote URL: %s", remote)
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1], nil
+}
+
+// CurrentUser returns the login of the authenticated user
+func (c *APIClient) CurrentUser() (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.rest(http.MethodGet, "/user", nil, &user); err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// ListPullRequests retrieves one page of open PRs via GraphQL
+func (c *APIClient) ListPullRequests(repo, cursor string) ([]PullRequest, PageInfo, error) {
+	return queryPullRequests(c, repo, cursor)
+}
+
+// ListIssues retrieves one page of open issues via GraphQL
+func (c *APIClient) ListIssues(repo, cursor string) ([]Issue, PageInfo, error) {
+	return queryIssues(c, repo, cursor)
+}
+
+// ListRepositories retrieves one page of repos owned by owner via GraphQL
+func (c *APIClient) ListRepositories(owner, cursor string) ([]Repository, PageInfo, error) {
+	return queryRepositories(c, owner, cursor)
+}
+
+// ListWorkflowRuns retrieves one page of workflow runs via REST
+func (c *APIClient) ListWorkflowRuns(repo, cursor string) ([]WorkflowRun, PageInfo, error) {
+	return queryWorkflowRuns(c, repo, cursor)
+}
+
+// ListGists retrieves one page of the authenticated user's gists via REST
+func (c *APIClient) ListGists(cursor string) ([]Gist, PageInfo, error) {
+	return queryGists(c, cursor)
+}
+
+// ListWorkflowRunsIfChanged retrieves the first page of workflow runs unless
+// it still has etag
+func (c *APIClient) ListWorkflowRunsIfChanged(repo, etag string) ([]WorkflowRun, PageInfo, string, error) {
+	g := &conditionalGetter{c: c, etag: etag}
+	runs, page, err := queryWorkflowRuns(g, repo, "")
+	return runs, page, g.etag, err
+}
+
+// ListGistsIfChanged retrieves the first page of gists unless it still has etag
+func (c *APIClient) ListGistsIfChanged(etag string) ([]Gist, PageInfo, string, error) {
+	g := &conditionalGetter{c: c, etag: etag}
+	gists, page, err := queryGists(g, "")
+	return gists, page, g.etag, err
+}
+
+// ListPullRequestsMatching retrieves one page of repo's PRs matching a search query via GraphQL
+func (c *APIClient) ListPullRequestsMatching(repo, query, cursor string) ([]PullRequest, PageInfo, error) {
+	return queryPullRequestsMatching(c, repo, query, cursor)
+}
+
+// ListIssuesMatching retrieves one page of repo's issues matching a search query via GraphQL
+func (c *APIClient) ListIssuesMatching(repo, query, cursor string) ([]Issue, PageInfo, error) {
+	return queryIssuesMatching(c, repo, query, cursor)
+}
+
+// ListWorkflowRunsMatching retrieves one page of repo's runs matching a runs query via REST
+func (c *APIClient) ListWorkflowRunsMatching(repo, query, cursor string) ([]WorkflowRun, PageInfo, error) {
+	return queryWorkflowRunsMatching(c, repo, query, cursor)
+}
+
+// openURL opens rawURL with the platform's default browser
+func openURL(rawURL string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", rawURL)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", rawURL)
+	default:
+		cmd = exec.Command("xdg-open", rawURL)
+	}
+	return cmd.Start()
+}
+
+// PullRequestDetail retrieves body, checks, reviews and files of a PR
+func (c *APIClient) PullRequestDetail(repo string, number int) (PullRequestDetail, error) {
+	return queryPullRequestDetail(c, repo, number)
+}
+
+// PullRequestDiff retrieves the unified diff of a PR
+func (c *APIClient) PullRequestDiff(repo string, number int) (string, error) {
+	data, err := c.restRaw(fmt.Sprintf("/repos/%s/pulls/%d", repo, number), "application/vnd.github.diff")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SubmitReview creates a PR review with its line comments
+func (c *APIClient) SubmitReview(repo string, number int, review ReviewSubmission) error {
+	return createPullRequestReview(c, repo, number, review)
+}
+
+// MergeInfo retrieves a PR's mergeability and the repo's allowed merge methods
+func (c *APIClient) MergeInfo(repo string, number int) (MergeInfo, error) {
+	return queryMergeInfo(c, repo, number)
+}
+
+// MergePullRequest merges a PR
+func (c *APIClient) MergePullRequest(repo string, number int, req MergeRequest) error {
+	return mergePullRequest(c, repo, number, req)
+}
+
+// DeleteBranch deletes a branch, e.g. a merged PR's head
+func (c *APIClient) DeleteBranch(repo, branch string) error {
+	return deleteBranch(c, repo, branch)
+}
+
+// WorkflowRunDetail retrieves a run's status and its jobs with their steps
+func (c *APIClient) WorkflowRunDetail(repo string, runID int64) (WorkflowRunDetail, error) {
+	return queryWorkflowRunDetail(c, repo, runID)
+}
+
+// WorkflowRunLogs downloads the log archive of a run
+// The API redirects to a signed URL; the redirect drops our token.
+func (c *APIClient) WorkflowRunLogs(repo string, runID int64) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("%s/repos/%s/actions/runs/%d/logs", c.baseURL, repo, runID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The archive of a long run takes longer than the usual request timeout
+	client := *c.http
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// RerunWorkflowRun re-runs a completed run or its failed jobs
+func (c *APIClient) RerunWorkflowRun(repo string, runID int64, failedOnly, debug bool) error {
+	return rerunWorkflowRun(c, repo, runID, failedOnly, debug)
+}
+
+// CancelWorkflowRun cancels a queued or running run
+func (c *APIClient) CancelWorkflowRun(repo string, runID int64) error {
+	return cancelWorkflowRun(c, repo, runID)
+}
+
+// ListWorkflows lists the workflows of a repo
+func (c *APIClient) ListWorkflows(repo string) ([]Workflow, error) {
+	return queryWorkflows(c, repo)
+}
+
+// ListEnvironments lists the deployment environments of a repo
+func (c *APIClient) ListEnvironments(repo string) ([]string, error) {
+	return queryEnvironments(c, repo)
+}
+
+// DispatchWorkflow starts a workflow_dispatch run
+func (c *APIClient) DispatchWorkflow(repo string, workflowID int64, ref string, inputs map[string]string) error {
+	return dispatchWorkflow(c, repo, workflowID, ref, inputs)
+}
+
+// ListDispatchRuns lists the latest dispatched runs of a workflow on branch
+func (c *APIClient) ListDispatchRuns(repo string, workflowID int64, branch string) ([]WorkflowRun, error) {
+	return queryDispatchRuns(c, repo, workflowID, branch)
+}
+
+// ListArtifacts lists the artifacts of a run
+func (c *APIClient) ListArtifacts(repo string, runID int64) ([]Artifact, error) {
+	return queryArtifacts(c, repo, runID)
+}
+
+// DownloadArtifact streams the zip archive of an artifact
+// GitHub redirects to blob storage; the token isn't sent along to it.
+func (c *APIClient) DownloadArtifact(repo string, artifactID int64) (io.ReadCloser, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("%s/repos/%s/actions/artifacts/%d/zip", c.baseURL, repo, artifactID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Large artifacts take longer than the usual request timeout
+	client := *c.http
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// OpenInBrowser builds the web URL for an item and opens it
+func (c *APIClient) OpenInBrowser(itemType, identifier, repo string) error {
+	if repo == "" && itemType != "repo" && itemType != "gist" {
+		current, err := c.CurrentRepo()
+		if err != nil {
+			return err
+		}
+		repo = current
+	}
+
+	var target string
+	switch itemType {
+	case "pr":
+		target = fmt.Sprintf("%s/%s/pull/%s", c.webURL, repo, identifier)
+	case "issue":
+		target = fmt.Sprintf("%s/%s/issues/%s", c.webURL, repo, identifier)
+	case "repo":
+		target = fmt.Sprintf("%s/%s", c.webURL, identifier)
+	case "run":
+		target = fmt.Sprintf("%s/%s/actions/runs/%s", c.webURL, repo, identifier)
+	case "workflow":
+		target = fmt.Sprintf("%s/%s/actions/workflows/%s", c.webURL, repo, identifier)
+	case "gist":
+		if c.webURL == "https://github.com" {
+			target = "https://gist.github.com/" + identifier
+		} else {
+			target = fmt.Sprintf("%s/gist/%s", c.webURL, identifier)
+		}
+	default:
+		return fmt.Errorf("unknown item type: %s", itemType)
+	}
+
+	return openURL(target)
+}
+
+// CreateIssueInBrowser opens GitHub's new issue form
+func (c *APIClient) CreateIssueInBrowser(repo string) error {
+	repo, err := c.repoOrCurrent(repo)
+	if err != nil {
+		return err
+	}
+	return openURL(fmt.Sprintf("%s/%s/issues/new", c.webURL, repo))
+}
+
+// repoOrCurrent returns repo, or the cwd repo when repo is empty
+func (c *APIClient) repoOrCurrent(repo string) (string, error) {
+	if repo != "" {
+		return repo, nil
+	}
+	return c.CurrentRepo()
+}
+
+// IsRepoStarred reports whether the authenticated user has starred repo
+func (c *APIClient) IsRepoStarred(repo string) (bool, error) {
+	err := c.rest(http.MethodGet, "/user/starred/"+repo, nil, nil)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// StarRepo stars a repository
+func (c *APIClient) StarRepo(repo string) error {
+	return c.rest(http.MethodPut, "/user/starred/"+repo, nil, nil)
+}
+
+// UnstarRepo unstars a repository
+func (c *APIClient) UnstarRepo(repo string) error {
+	return c.rest(http.MethodDelete, "/user/starred/"+repo, nil, nil)
+}
+
+// CloneRepository clones a repository into the current directory with git
+func (c *APIClient) CloneRepository(repo string) error {
+	return exec.Command("git", "clone", fmt.Sprintf("%s/%s.git", c.webURL, repo)).Run()
+}
+
+// ForkRepository forks a repository to the authenticated user's account
+func (c *APIClient) ForkRepository(repo string) error {
+	return c.rest(http.MethodPost, "/repos/"+repo+"/forks", map[string]interface{}{}, nil)
+}
+
+// RepoFile retrieves the content of a file in a repo
+func (c *APIClient) RepoFile(repo, path, ref string) ([]byte, error) {
+	return queryRepoFile(c, repo, path, ref)
+}
+
+// RepoDir retrieves the text files in a directory of a repo
+func (c *APIClient) RepoDir(repo, path string) (map[string]string, error) {
+	return queryRepoDir(c, repo, path)
+}
+
+// DefaultBranch retrieves the default branch of a repo
+func (c *APIClient) DefaultBranch(repo string) (string, error) {
+	return queryDefaultBranch(c, repo)
+}
+
+// setIssueState patches the state of an issue
+func (c *APIClient) setIssueState(repo string, number int, state string) error {
+	repo, err := c.repoOrCurrent(repo)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/repos/%s/issues/%d", repo, number)
+	return c.rest(http.MethodPatch, path, map[string]string{"state": state}, nil)
+}
+
+// IssueDetail retrieves the body and timeline of an issue
+func (c *APIClient) IssueDetail(repo string, number int) (IssueDetail, error) {
+	return queryIssueDetail(c, repo, number)
+}
+
+// CreateIssue opens a new issue
+func (c *APIClient) CreateIssue(repo string, issue NewIssue) (Issue, error) {
+	return createIssue(c, repo, issue)
+}
+
+// AddComment comments on an issue or PR
+func (c *APIClient) AddComment(repo string, number int, body string) error {
+	return addComment(c, repo, number, body)
+}
+
+// ListLabels retrieves the labels of a repo
+func (c *APIClient) ListLabels(repo string) ([]Label, error) {
+	return queryLabels(c, repo)
+}
+
+// ListAssignableUsers retrieves who issues in a repo can be assigned to
+func (c *APIClient) ListAssignableUsers(repo string) ([]string, error) {
+	return queryAssignableUsers(c, repo)
+}
+
+// ListMilestones retrieves the open milestones of a repo
+func (c *APIClient) ListMilestones(repo string) ([]Milestone, error) {
+	return queryMilestones(c, repo)
+}
+
+// EditIssue changes the labels, assignees and milestone of an issue
+func (c *APIClient) EditIssue(repo string, number int, edit IssueEdit) (Issue, error) {
+	return editIssue(c, repo, number, edit)
+}
+
+// CloseIssue closes an issue
+func (c *APIClient) CloseIssue(repo string, number int) error {
+	return c.setIssueState(repo, number, "closed")
+}
+
+// ReopenIssue reopens a closed issue
+func (c *APIClient) ReopenIssue(repo string, number int) error {
+	return c.setIssueState(repo, number, "open")
+}
+
+// SearchIssues searches issues and PRs across GitHub
+func (c *APIClient) SearchIssues(query string) ([]SearchIssue, int, error) {
+	return searchIssues(c, query)
+}
+
+// SearchRepositories searches repositories across GitHub
+func (c *APIClient) SearchRepositories(query string) ([]Repository, int, error) {
+	return searchRepositories(c, query)
+}
+
+// SearchCode searches code across GitHub, with the fragments that matched
+func (c *APIClient) SearchCode(query string) ([]CodeResult, int, error) {
+	data, err := c.restRaw(codeSearchPath(query), textMatchMediaType)
+	if err != nil {
+		return nil, 0, err
+	}
+	return decodeCodeSearch(data)
+}
+
+// ListNotifications retrieves one page of the notifications inbox
+func (c *APIClient) ListNotifications(participating bool, cursor string) ([]Notification, PageInfo, error) {
+	return queryNotifications(c, participating, cursor)
+}
+
+// MarkNotificationRead marks a notification thread read
+func (c *APIClient) MarkNotificationRead(threadID string) error {
+	return markNotificationRead(c, threadID)
+}
+
+// MarkNotificationDone marks a notification thread done
+func (c *APIClient) MarkNotificationDone(threadID string) error {
+	return markNotificationDone(c, threadID)
+}
+
+// UnsubscribeNotification mutes a notification thread
+func (c *APIClient) UnsubscribeNotification(threadID string) error {
+	return unsubscribeNotification(c, threadID)
+}
+
+// GistFileContent downloads the content of a single gist file
+func (c *APIClient) GistFileContent(gistID, filename string) ([]byte, error) {
+	var gist struct {
+		Files map[string]struct {
+			Content string `json:"content"`
+		} `json:"files"`
+	}
+	if err := c.rest(http.MethodGet, "/gists/"+gistID, nil, &gist); err != nil {
+		return nil, err
+	}
+	file, ok := gist.Files[filename]
+	if !ok {
+		return nil, fmt.Errorf("gist %s has no file %s", gistID, filename)
+	}
+	return []byte(file.Content), nil
+}
+
+// UpdateGistFile uploads path as a file of the given gist
+func (c *APIClient) UpdateGistFile(gistID, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"files": map[string]interface{}{
+			filepath.Base(path): map[string]string{"content": string(content)},
+		},
+	}
+	return c.rest(http.MethodPatch, "/gists/"+gistID, body, nil)
+}
+
+// CreateGist creates a new gist from the file at path
+func (c *APIClient) CreateGist(path, description string, public bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"description": description,
+		"public":      public,
+		"files": map[string]interface{}{
+			filepath.Base(path): map[string]string{"content": string(content)},
+		},
+	}
+	return c.rest(http.MethodPost, "/gists", body, nil)
+}
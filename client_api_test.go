@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"https://github.com/octo-org/fixtures.git", "octo-org/fixtures"},
+		{"https://github.com/octo-org/fixtures", "octo-org/fixtures"},
+		{"git@github.com:octo-org/fixtures.git", "octo-org/fixtures"},
+		{"ssh://git@github.com/octo-org/fixtures.git", "octo-org/fixtures"},
+		{"https://github.example.com/octo-org/fixtures.git", "octo-org/fixtures"},
+		{"git@ghe.example.com:octo-org/fixtures", "octo-org/fixtures"},
+	}
+	for _, tt := range tests {
+		got, err := parseRemoteURL(tt.remote)
+		if err != nil || got != tt.want {
+			t.Errorf("parseRemoteURL(%q) = %q, %v; want %q", tt.remote, got, err, tt.want)
+		}
+	}
+
+	for _, remote := range []string{"fixtures", "https://github.com/fixtures"} {
+		if got, err := parseRemoteURL(remote); err == nil {
+			t.Errorf("parseRemoteURL(%q) = %q, want an error", remote, got)
+		}
+	}
+}
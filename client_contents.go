@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// client_contents.go - Shared Repo Content Queries
+// Purpose: Read files and branch info from a repo, shared by the gh and api backends
+// When to extend: Add queries for repo files (workflows, templates, ...) here
+
+// queryRepoFile returns the content of the file at path, at ref or the
+// default branch when ref is ""
+func queryRepoFile(r restGetter, repo, path, ref string) ([]byte, error) {
+	endpoint := fmt.Sprintf("/repos/%s/contents/%s", repo, strings.TrimPrefix(path, "/"))
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+
+	var data struct {
+		Type     string `json:"type"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := r.restGet(endpoint, &data); err != nil {
+		return nil, err
+	}
+	if data.Type != "file" || data.Encoding != "base64" {
+		return nil, fmt.Errorf("%s is not a file", path)
+	}
+	// GitHub wraps the base64 at 60 columns
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(data.Content, "\n", ""))
+}
+
+const repoDirQuery = `query($owner: String!, $name: String!, $expression: String!) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $expression) {
+      ... on Tree {
+        entries { name type object { ... on Blob { text isBinary } } }
+      }
+    }
+  }
+}`
+
+// queryRepoDir returns the text files directly in the directory at path on
+// the default branch, by name; a missing directory has no files
+func queryRepoDir(r graphQLRunner, repo, path string) (map[string]string, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Repository struct {
+			Object *struct {
+				Entries []struct {
+					Name   string `json:"name"`
+					Type   string `json:"type"`
+					Object struct {
+						Text     *string `json:"text"`
+						IsBinary bool    `json:"isBinary"`
+					} `json:"object"`
+				} `json:"entries"`
+			} `json:"object"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "name": name, "expression": "HEAD:" + strings.Trim(path, "/")}
+	if err := r.graphql(repoDirQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	if data.Repository.Object == nil {
+		return files, nil
+	}
+	for _, entry := range data.Repository.Object.Entries {
+		// Text is null for blobs too large to return inline
+		if entry.Type == "blob" && !entry.Object.IsBinary && entry.Object.Text != nil {
+			files[entry.Name] = *entry.Object.Text
+		}
+	}
+	return files, nil
+}
+
+// queryDefaultBranch returns the default branch of repo
+func queryDefaultBranch(r restGetter, repo string) (string, error) {
+	var data struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := r.restGet(fmt.Sprintf("/repos/%s", repo), &data); err != nil {
+		return "", err
+	}
+	return data.DefaultBranch, nil
+}
@@ -0,0 +1,1201 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// client_fake.go - In-Memory GitHub Backend
+// Purpose: GitHubClient backed by JSON fixtures, for tests and offline demos
+// Fixtures use the same JSON shape as our structs in types.go:
+//   prs.json, issues.json, repos.json, runs.json, gists.json,
+//   gist_files.json ({"<gist id>": {"<filename>": "<content>"}}),
+//   pr_details.json ({"<number>": PullRequestDetail}),
+//   issue_details.json ({"<number>": IssueDetail}),
+//   labels.json ([Label]), assignees.json (["<login>"]), milestones.json ([Milestone]),
+//   pr_diffs.json ({"<number>": "<unified diff>"}),
+//   run_jobs.json ({"<run id>": [WorkflowJob]}),
+//   run_logs.json ({"<run id>": {"<path in the log archive>": "<content>"}}),
+//   workflows.json ([Workflow]), environments.json (["<name>"]),
+//   repo_files.json ({"<path>": "<content>"}, the same at every ref),
+//   run_artifacts.json ({"<run id>": [Artifact]}),
+//   artifact_files.json ({"<artifact id>": {"<path in the zip>": "<content>"}}),
+//   notifications.json ([Notification])
+// Missing fixture files are treated as empty lists.
+
+// FakeClient implements GitHubClient entirely in memory
+type FakeClient struct {
+	mu sync.Mutex
+
+	Repo string // Returned by CurrentRepo
+	User string // Returned by CurrentUser
+
+	PullRequests []PullRequest
+	Issues       []Issue
+	Repositories []Repository
+	WorkflowRuns []WorkflowRun
+	Gists        []Gist
+	GistFiles    map[string]map[string]string
+	Starred      map[string]bool
+	PRDetails    map[int]PullRequestDetail
+	IssueDetails map[int]IssueDetail
+	Labels       []Label
+	Assignable   []string // Returned by ListAssignableUsers
+	Milestones   []Milestone
+	PRDiffs      map[int]string
+	Reviews      map[int][]ReviewSubmission // Submitted by SubmitReview
+	MergeMethods []string                   // Allowed merge methods; nil allows all
+	RunJobs      map[int64][]WorkflowJob
+	RunLogs      map[int64]map[string]string // Log archive files per run
+	Workflows    []Workflow
+	Environments []string
+	RepoFiles    map[string]string
+	Branch       string // Returned by DefaultBranch
+	Artifacts    map[int64][]Artifact
+	ArtifactZips map[int64]map[string]string // Archive files per artifact
+
+	// Inbox of the user, read and unread
+	Notifications []Notification
+
+	// Calls records every mutating call, e.g. "CloseIssue owner/repo 12"
+	Calls []string
+
+	// Err, when set, is returned by every method
+	Err error
+}
+
+// NewFakeClient creates a fake backend loaded from the fixtures in dir
+func NewFakeClient(dir string) (*FakeClient, error) {
+	c := &FakeClient{
+		Repo:         "octo-org/fixtures",
+		User:         "octocat",
+		GistFiles:    make(map[string]map[string]string),
+		Starred:      make(map[string]bool),
+		PRDetails:    make(map[int]PullRequestDetail),
+		IssueDetails: make(map[int]IssueDetail),
+		PRDiffs:      make(map[int]string),
+		Reviews:      make(map[int][]ReviewSubmission),
+		RunJobs:      make(map[int64][]WorkflowJob),
+		RunLogs:      make(map[int64]map[string]string),
+		RepoFiles:    make(map[string]string),
+		Artifacts:    make(map[int64][]Artifact),
+		ArtifactZips: make(map[int64]map[string]string),
+		Branch:       "main",
+	}
+
+	fixtures := []struct {
+		name string
+		dest interface{}
+	}{
+		{"prs.json", &c.PullRequests},
+		{"issues.json", &c.Issues},
+		{"repos.json", &c.Repositories},
+		{"runs.json", &c.WorkflowRuns},
+		{"gists.json", &c.Gists},
+		{"gist_files.json", &c.GistFiles},
+		{"pr_details.json", &c.PRDetails},
+		{"issue_details.json", &c.IssueDetails},
+		{"labels.json", &c.Labels},
+		{"assignees.json", &c.Assignable},
+		{"milestones.json", &c.Milestones},
+		{"pr_diffs.json", &c.PRDiffs},
+		{"run_jobs.json", &c.RunJobs},
+		{"run_logs.json", &c.RunLogs},
+		{"workflows.json", &c.Workflows},
+		{"environments.json", &c.Environments},
+		{"repo_files.json", &c.RepoFiles},
+		{"run_artifacts.json", &c.Artifacts},
+		{"artifact_files.json", &c.ArtifactZips},
+		{"notifications.json", &c.Notifications},
+	}
+
+	for _, f := range fixtures {
+		if err := loadFixture(filepath.Join(dir, f.name), f.dest); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// loadFixture decodes a JSON fixture file into dest, ignoring missing files
+func loadFixture(path string, dest interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("fixture %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// fakePage returns the slice bounds and PageInfo for the page at cursor
+// Cursors are plain offsets into the fixture slice
+func fakePage(total int, cursor string) (int, int, PageInfo) {
+	start, _ := strconv.Atoi(cursor)
+	if start < 0 || start > total {
+		start = total
+	}
+	end := min(start+pageSize, total)
+
+	page := PageInfo{TotalCount: total}
+	if end < total {
+		page.HasNextPage = true
+		page.EndCursor = strconv.Itoa(end)
+	}
+	return start, end, page
+}
+
+// record logs a mutating call and returns the configured error
+func (c *FakeClient) record(format string, args ...interface{}) error {
+	c.Calls = append(c.Calls, fmt.Sprintf(format, args...))
+	return c.Err
+}
+
+// CheckAuth always succeeds unless Err is set
+func (c *FakeClient) CheckAuth() error {
+	return c.Err
+}
+
+// CurrentRepo returns the configured repo
+func (c *FakeClient) CurrentRepo() (string, error) {
+	return c.Repo, c.Err
+}
+
+// CurrentUser returns the configured user
+func (c *FakeClient) CurrentUser() (string, error) {
+	return c.User, c.Err
+}
+
+// ListPullRequests returns a page of the PR fixtures
+func (c *FakeClient) ListPullRequests(repo, cursor string) ([]PullRequest, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end, page := fakePage(len(c.PullRequests), cursor)
+	return append([]PullRequest{}, c.PullRequests[start:end]...), page, c.Err
+}
+
+// ListIssues returns a page of the issue fixtures
+func (c *FakeClient) ListIssues(repo, cursor string) ([]Issue, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end, page := fakePage(len(c.Issues), cursor)
+	return append([]Issue{}, c.Issues[start:end]...), page, c.Err
+}
+
+// ListRepositories returns a page of the repository fixtures
+func (c *FakeClient) ListRepositories(owner, cursor string) ([]Repository, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end, page := fakePage(len(c.Repositories), cursor)
+	return append([]Repository{}, c.Repositories[start:end]...), page, c.Err
+}
+
+// ListWorkflowRuns returns a page of the workflow run fixtures
+func (c *FakeClient) ListWorkflowRuns(repo, cursor string) ([]WorkflowRun, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end, page := fakePage(len(c.WorkflowRuns), cursor)
+	return append([]WorkflowRun{}, c.WorkflowRuns[start:end]...), page, c.Err
+}
+
+// ListGists returns a page of the gist fixtures
+func (c *FakeClient) ListGists(cursor string) ([]Gist, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end, page := fakePage(len(c.Gists), cursor)
+	return append([]Gist{}, c.Gists[start:end]...), page, c.Err
+}
+
+// ListPullRequestsMatching returns a page of the PR fixtures whose titles
+// contain the words of query; is:open, is:closed and is:draft are honoured
+// and other qualifiers ignored
+func (c *FakeClient) ListPullRequestsMatching(repo, query, cursor string) ([]PullRequest, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	words, is := fakeSearchTerms(query)
+	var prs []PullRequest
+	for _, pr := range c.PullRequests {
+		if fakeSearchMatch(pr.Title, words) && (!is["open"] || pr.State == "OPEN") &&
+			(!is["closed"] || pr.State != "OPEN") && (!is["draft"] || pr.IsDraft) {
+			prs = append(prs, pr)
+		}
+	}
+	start, end, page := fakePage(len(prs), cursor)
+	return append([]PullRequest{}, prs[start:end]...), page, c.Err
+}
+
+// ListIssuesMatching returns a page of the issue fixtures whose titles
+// contain the words of query; is:open, is:closed and label: are honoured
+// and other qualifiers ignored
+func (c *FakeClient) ListIssuesMatching(repo, query, cursor string) ([]Issue, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	words, is := fakeSearchTerms(query)
+	var issues []Issue
+	for _, issue := range c.Issues {
+		if !fakeSearchMatch(issue.Title, words) || (is["open"] && issue.State != "OPEN") || (is["closed"] && issue.State != "CLOSED") {
+			continue
+		}
+		if label := fakeQualifier(query, "label"); label != "" && !hasLabel(issue.Labels, label) {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	start, end, page := fakePage(len(issues), cursor)
+	return append([]Issue{}, issues[start:end]...), page, c.Err
+}
+
+// ListWorkflowRunsMatching returns a page of the run fixtures matching the
+// branch:, status: and workflow: (name) qualifiers of query
+func (c *FakeClient) ListWorkflowRunsMatching(repo, query, cursor string) ([]WorkflowRun, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	params, workflow, err := parseRunsQuery(query)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	var runs []WorkflowRun
+	for _, run := range c.WorkflowRuns {
+		status := params.Get("status")
+		if (workflow != "" && !strings.EqualFold(run.Name, workflow)) ||
+			(params.Get("branch") != "" && run.HeadBranch != params.Get("branch")) ||
+			(status != "" && run.Status != status && run.Conclusion != status) {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	start, end, page := fakePage(len(runs), cursor)
+	return append([]WorkflowRun{}, runs[start:end]...), page, c.Err
+}
+
+// PullRequestDetail returns the detail fixture of a PR
+// PRs without a fixture get an empty detail, like a PR with no body or checks
+func (c *FakeClient) PullRequestDetail(repo string, number int) (PullRequestDetail, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return PullRequestDetail{}, c.Err
+	}
+	detail, ok := c.PRDetails[number]
+	if !ok {
+		detail = PullRequestDetail{Number: number}
+	}
+	return detail, nil
+}
+
+// PullRequestDiff returns the diff fixture of a PR
+func (c *FakeClient) PullRequestDiff(repo string, number int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.PRDiffs[number], c.Err
+}
+
+// SubmitReview records the review against the PR
+func (c *FakeClient) SubmitReview(repo string, number int, review ReviewSubmission) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("SubmitReview %s %d %s %d", repo, number, review.Event, len(review.Comments)); err != nil {
+		return err
+	}
+	c.Reviews[number] = append(c.Reviews[number], review)
+	return nil
+}
+
+// MergeInfo derives a PR's mergeability from its fixture
+func (c *FakeClient) MergeInfo(repo string, number int) (MergeInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return MergeInfo{}, c.Err
+	}
+	pr, err := c.pullRequest(number)
+	if err != nil {
+		return MergeInfo{}, err
+	}
+
+	info := MergeInfo{
+		Number:           pr.Number,
+		Title:            pr.Title,
+		Body:             c.PRDetails[number].Body,
+		State:            pr.State,
+		IsDraft:          pr.IsDraft,
+		Mergeable:        pr.Mergeable,
+		MergeStateStatus: "CLEAN",
+		ReviewDecision:   pr.ReviewDecision,
+		HeadRefName:      pr.HeadRefName,
+		BaseRefName:      pr.BaseRefName,
+		HeadRefOid:       fmt.Sprintf("%040d", pr.Number),
+		CanDeleteBranch:  true,
+		AllowedMethods:   c.MergeMethods,
+	}
+	switch {
+	case pr.IsDraft:
+		info.MergeStateStatus = "DRAFT"
+	case pr.Mergeable == "CONFLICTING":
+		info.MergeStateStatus = "DIRTY"
+	}
+	if info.AllowedMethods == nil {
+		info.AllowedMethods = []string{"merge", "squash", "rebase"}
+	}
+	return info, nil
+}
+
+// MergePullRequest marks a PR fixture as merged
+func (c *FakeClient) MergePullRequest(repo string, number int, req MergeRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("MergePullRequest %s %d %s", repo, number, req.Method); err != nil {
+		return err
+	}
+	pr, err := c.pullRequest(number)
+	if err != nil {
+		return err
+	}
+	if pr.State != "OPEN" || pr.Mergeable == "CONFLICTING" {
+		return fmt.Errorf("pull request #%d is not mergeable", number)
+	}
+	pr.State = "MERGED"
+	return nil
+}
+
+// DeleteBranch records the call
+func (c *FakeClient) DeleteBranch(repo, branch string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("DeleteBranch %s %s", repo, branch)
+}
+
+// pullRequest returns the PR fixture with number
+func (c *FakeClient) pullRequest(number int) (*PullRequest, error) {
+	for i := range c.PullRequests {
+		if c.PullRequests[i].Number == number {
+			return &c.PullRequests[i], nil
+		}
+	}
+	return nil, fmt.Errorf("pull request #%d not found", number)
+}
+
+// WorkflowRunDetail returns a run fixture with its jobs fixture
+func (c *FakeClient) WorkflowRunDetail(repo string, runID int64) (WorkflowRunDetail, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return WorkflowRunDetail{}, c.Err
+	}
+	for _, run := range c.WorkflowRuns {
+		if run.DatabaseId == runID {
+			return WorkflowRunDetail{Run: run, Jobs: c.RunJobs[runID]}, nil
+		}
+	}
+	return WorkflowRunDetail{}, fmt.Errorf("workflow run %d not found", runID)
+}
+
+// WorkflowRunLogs zips the log fixture of a run like the real archive
+func (c *FakeClient) WorkflowRunLogs(repo string, runID int64) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	files, ok := c.RunLogs[runID]
+	if !ok {
+		return nil, fmt.Errorf("logs of workflow run %d not found", runID)
+	}
+	return zipFiles(files)
+}
+
+// ListArtifacts returns the artifact fixtures of a run
+func (c *FakeClient) ListArtifacts(repo string, runID int64) ([]Artifact, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Artifacts[runID], c.Err
+}
+
+// DownloadArtifact zips the file fixtures of an artifact
+func (c *FakeClient) DownloadArtifact(repo string, artifactID int64) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	files, ok := c.ArtifactZips[artifactID]
+	if !ok {
+		return nil, fmt.Errorf("artifact %d not found", artifactID)
+	}
+	data, err := zipFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// zipFiles builds a zip archive of files, keyed by path
+func zipFiles(files map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RerunWorkflowRun records the call and queues the run fixture again
+func (c *FakeClient) RerunWorkflowRun(repo string, runID int64, failedOnly, debug bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("RerunWorkflowRun %s %d failed=%t debug=%t", repo, runID, failedOnly, debug); err != nil {
+		return err
+	}
+	run, err := c.workflowRun(runID)
+	if err != nil {
+		return err
+	}
+	if run.Status != "completed" {
+		return fmt.Errorf("workflow run %d is still running", runID)
+	}
+	if failedOnly && run.Conclusion != "failure" && run.Conclusion != "cancelled" {
+		return fmt.Errorf("workflow run %d has no failed jobs", runID)
+	}
+	run.Status, run.Conclusion = "queued", ""
+	return nil
+}
+
+// CancelWorkflowRun records the call and marks the run fixture cancelled
+func (c *FakeClient) CancelWorkflowRun(repo string, runID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CancelWorkflowRun %s %d", repo, runID); err != nil {
+		return err
+	}
+	run, err := c.workflowRun(runID)
+	if err != nil {
+		return err
+	}
+	if run.Status == "completed" {
+		return fmt.Errorf("workflow run %d has already completed", runID)
+	}
+	run.Status, run.Conclusion = "completed", "cancelled"
+	return nil
+}
+
+// ListWorkflows returns the workflow fixtures
+func (c *FakeClient) ListWorkflows(repo string) ([]Workflow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Workflows, c.Err
+}
+
+// ListEnvironments returns the environment fixtures
+func (c *FakeClient) ListEnvironments(repo string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Environments, c.Err
+}
+
+// DispatchWorkflow records the call and adds a queued run of the workflow
+func (c *FakeClient) DispatchWorkflow(repo string, workflowID int64, ref string, inputs map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]string, len(names))
+	for i, name := range names {
+		args[i] = name + "=" + inputs[name]
+	}
+	if err := c.record("DispatchWorkflow %s %d %s %s", repo, workflowID, ref, strings.Join(args, ",")); err != nil {
+		return err
+	}
+
+	workflow, err := c.workflow(workflowID)
+	if err != nil {
+		return err
+	}
+	if workflow.State != "active" {
+		return fmt.Errorf("workflow %s is %s", workflow.Name, workflow.State)
+	}
+
+	run := WorkflowRun{Name: workflow.Name, Status: "queued", HeadBranch: ref, CreatedAt: time.Now()}
+	for _, existing := range c.WorkflowRuns {
+		if existing.DatabaseId > run.DatabaseId {
+			run.DatabaseId = existing.DatabaseId
+		}
+		run.RunNumber = max(run.RunNumber, existing.RunNumber)
+	}
+	run.DatabaseId++
+	run.RunNumber++
+	run.HeadSha = fmt.Sprintf("%040x", run.DatabaseId)
+	run.URL = fmt.Sprintf("https://github.com/%s/actions/runs/%d", repo, run.DatabaseId)
+	c.WorkflowRuns = append([]WorkflowRun{run}, c.WorkflowRuns...)
+	return nil
+}
+
+// ListDispatchRuns returns the run fixtures of a workflow on branch
+func (c *FakeClient) ListDispatchRuns(repo string, workflowID int64, branch string) ([]WorkflowRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	workflow, err := c.workflow(workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []WorkflowRun
+	for _, run := range c.WorkflowRuns {
+		if run.Name == workflow.Name && run.HeadBranch == branch {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// workflow returns the workflow fixture with id
+func (c *FakeClient) workflow(id int64) (*Workflow, error) {
+	for i := range c.Workflows {
+		if c.Workflows[i].ID == id {
+			return &c.Workflows[i], nil
+		}
+	}
+	return nil, fmt.Errorf("workflow %d not found", id)
+}
+
+// workflowRun returns the run fixture with runID
+func (c *FakeClient) workflowRun(runID int64) (*WorkflowRun, error) {
+	for i := range c.WorkflowRuns {
+		if c.WorkflowRuns[i].DatabaseId == runID {
+			return &c.WorkflowRuns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("workflow run %d not found", runID)
+}
+
+// OpenInBrowser records the call without opening anything
+func (c *FakeClient) OpenInBrowser(itemType, identifier, repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("OpenInBrowser %s %s %s", itemType, identifier, repo)
+}
+
+// CreateIssueInBrowser records the call without opening anything
+func (c *FakeClient) CreateIssueInBrowser(repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("CreateIssueInBrowser %s", repo)
+}
+
+// IsRepoStarred reports the in-memory star state
+func (c *FakeClient) IsRepoStarred(repo string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Starred[repo], c.Err
+}
+
+// StarRepo marks repo as starred
+func (c *FakeClient) StarRepo(repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Starred[repo] = true
+	return c.record("StarRepo %s", repo)
+}
+
+// UnstarRepo marks repo as not starred
+func (c *FakeClient) UnstarRepo(repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.Starred, repo)
+	return c.record("UnstarRepo %s", repo)
+}
+
+// CloneRepository records the call without touching the filesystem
+func (c *FakeClient) CloneRepository(repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("CloneRepository %s", repo)
+}
+
+// ForkRepository records the call
+func (c *FakeClient) ForkRepository(repo string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("ForkRepository %s", repo)
+}
+
+// RepoFile returns a repo file fixture
+func (c *FakeClient) RepoFile(repo, path, ref string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	content, ok := c.RepoFiles[path]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	return []byte(content), nil
+}
+
+// RepoDir returns the repo file fixtures directly in path
+func (c *FakeClient) RepoDir(repo, path string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	prefix := strings.Trim(path, "/") + "/"
+	files := make(map[string]string)
+	for name, content := range c.RepoFiles {
+		if rest := strings.TrimPrefix(name, prefix); rest != name && !strings.Contains(rest, "/") {
+			files[rest] = content
+		}
+	}
+	return files, nil
+}
+
+// DefaultBranch returns the configured branch
+func (c *FakeClient) DefaultBranch(repo string) (string, error) {
+	return c.Branch, c.Err
+}
+
+// setIssueState updates the state of an issue fixture
+func (c *FakeClient) setIssueState(number int, state string) error {
+	for i := range c.Issues {
+		if c.Issues[i].Number == number {
+			c.Issues[i].State = state
+			return nil
+		}
+	}
+	return fmt.Errorf("issue #%d not found", number)
+}
+
+// IssueDetail returns the detail fixture of an issue
+// Issues without a fixture get an empty detail, like an issue with no body or comments
+func (c *FakeClient) IssueDetail(repo string, number int) (IssueDetail, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return IssueDetail{}, c.Err
+	}
+	detail, ok := c.IssueDetails[number]
+	if !ok {
+		detail = IssueDetail{Number: number}
+	}
+	return detail, nil
+}
+
+// CreateIssue adds an open issue fixture, numbered after the highest one
+func (c *FakeClient) CreateIssue(repo string, issue NewIssue) (Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CreateIssue %s %q labels=%s assignees=%s milestone=%d", repo, issue.Title,
+		strings.Join(issue.Labels, ","), strings.Join(issue.Assignees, ","), issue.Milestone); err != nil {
+		return Issue{}, err
+	}
+
+	number := 1
+	for _, existing := range c.Issues {
+		number = max(number, existing.Number+1)
+	}
+	now := time.Now().UTC()
+	created := Issue{
+		Number:    number,
+		Title:     issue.Title,
+		State:     "OPEN",
+		Author:    Author{Login: c.User},
+		CreatedAt: now,
+		UpdatedAt: now,
+		URL:       fmt.Sprintf("https://github.com/%s/issues/%d", repo, number),
+	}
+	for _, name := range issue.Labels {
+		label := Label{Name: name}
+		for _, known := range c.Labels {
+			if known.Name == name {
+				label = known
+			}
+		}
+		created.Labels = append(created.Labels, label)
+	}
+	for _, login := range issue.Assignees {
+		created.Assignees = append(created.Assignees, Author{Login: login})
+	}
+	for i := range c.Milestones {
+		if c.Milestones[i].Number == issue.Milestone {
+			created.Milestone = &c.Milestones[i]
+		}
+	}
+
+	c.Issues = append([]Issue{created}, c.Issues...)
+	c.IssueDetails[number] = IssueDetail{Number: number, Body: issue.Body}
+	return created, nil
+}
+
+// AddComment appends a comment to the issue's timeline fixture
+func (c *FakeClient) AddComment(repo string, number int, body string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("AddComment %s %d %q", repo, number, body); err != nil {
+		return err
+	}
+	detail, ok := c.IssueDetails[number]
+	if !ok {
+		detail = IssueDetail{Number: number}
+	}
+	detail.Timeline = append(detail.Timeline, IssueEvent{
+		Type:      "comment",
+		Actor:     Author{Login: c.User},
+		CreatedAt: time.Now().UTC(),
+		Body:      body,
+	})
+	c.IssueDetails[number] = detail
+	return nil
+}
+
+// ListLabels returns the label fixtures
+func (c *FakeClient) ListLabels(repo string) ([]Label, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Label{}, c.Labels...), c.Err
+}
+
+// ListAssignableUsers returns the assignee fixtures
+func (c *FakeClient) ListAssignableUsers(repo string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.Assignable...), c.Err
+}
+
+// ListMilestones returns the milestone fixtures
+func (c *FakeClient) ListMilestones(repo string) ([]Milestone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Milestone{}, c.Milestones...), c.Err
+}
+
+// EditIssue changes the labels, assignees and milestone of an issue fixture
+// and adds the matching events to its timeline
+func (c *FakeClient) EditIssue(repo string, number int, edit IssueEdit) (Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	milestone := "-"
+	if edit.Milestone != nil {
+		milestone = fmt.Sprint(*edit.Milestone)
+	}
+	if err := c.record("EditIssue %s %d labels=+%s-%s assignees=+%s-%s milestone=%s", repo, number,
+		strings.Join(edit.AddLabels, ","), strings.Join(edit.RemoveLabels, ","),
+		strings.Join(edit.AddAssignees, ","), strings.Join(edit.RemoveAssignees, ","), milestone); err != nil {
+		return Issue{}, err
+	}
+
+	var issue *Issue
+	for i := range c.Issues {
+		if c.Issues[i].Number == number {
+			issue = &c.Issues[i]
+		}
+	}
+	if issue == nil {
+		// PRs share the numbering; their fixtures have no labels to change
+		for _, pr := range c.PullRequests {
+			if pr.Number == number {
+				return Issue{Number: pr.Number, Title: pr.Title, State: pr.State, URL: pr.URL}, nil
+			}
+		}
+		return Issue{}, fmt.Errorf("issue #%d not found", number)
+	}
+	detail, ok := c.IssueDetails[number]
+	if !ok {
+		detail = IssueDetail{Number: number}
+	}
+	now := time.Now().UTC()
+	event := func(e IssueEvent) {
+		e.Actor = Author{Login: c.User}
+		e.CreatedAt = now
+		detail.Timeline = append(detail.Timeline, e)
+	}
+
+	for _, name := range edit.AddLabels {
+		if hasLabel(issue.Labels, name) {
+			continue
+		}
+		label := Label{Name: name}
+		for _, known := range c.Labels {
+			if known.Name == name {
+				label = known
+			}
+		}
+		issue.Labels = append(issue.Labels, label)
+		event(IssueEvent{Type: "labeled", Label: &label})
+	}
+	for _, name := range edit.RemoveLabels {
+		for i, label := range issue.Labels {
+			if label.Name == name {
+				issue.Labels = append(issue.Labels[:i:i], issue.Labels[i+1:]...)
+				event(IssueEvent{Type: "unlabeled", Label: &label})
+				break
+			}
+		}
+	}
+	for _, login := range edit.AddAssignees {
+		issue.Assignees = append(issue.Assignees, Author{Login: login})
+		event(IssueEvent{Type: "assigned", Assignee: login})
+	}
+	for _, login := range edit.RemoveAssignees {
+		for i, assignee := range issue.Assignees {
+			if assignee.Login == login {
+				issue.Assignees = append(issue.Assignees[:i:i], issue.Assignees[i+1:]...)
+				event(IssueEvent{Type: "unassigned", Assignee: login})
+				break
+			}
+		}
+	}
+	if edit.Milestone != nil {
+		if issue.Milestone != nil {
+			event(IssueEvent{Type: "demilestoned", Milestone: issue.Milestone.Title})
+			issue.Milestone = nil
+		}
+		for i := range c.Milestones {
+			if c.Milestones[i].Number == *edit.Milestone {
+				issue.Milestone = &c.Milestones[i]
+				event(IssueEvent{Type: "milestoned", Milestone: issue.Milestone.Title})
+			}
+		}
+	}
+
+	issue.UpdatedAt = now
+	c.IssueDetails[number] = detail
+	return *issue, nil
+}
+
+// CloseIssue marks an issue fixture as closed
+func (c *FakeClient) CloseIssue(repo string, number int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CloseIssue %s %d", repo, number); err != nil {
+		return err
+	}
+	return c.setIssueState(number, "CLOSED")
+}
+
+// ReopenIssue marks an issue fixture as open
+func (c *FakeClient) ReopenIssue(repo string, number int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("ReopenIssue %s %d", repo, number); err != nil {
+		return err
+	}
+	return c.setIssueState(number, "OPEN")
+}
+
+// SearchIssues matches the words of query against the titles of the issue
+// and PR fixtures; is:issue, is:pr, is:open, is:closed, author:, assignee:
+// (issues only) and review: (PRs only) are honoured and other qualifiers
+// ignored
+func (c *FakeClient) SearchIssues(query string) ([]SearchIssue, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, 0, c.Err
+	}
+	words, is := fakeSearchTerms(query)
+	author, assignee := fakeQualifier(query, "author"), fakeQualifier(query, "assignee")
+	if author == "@me" {
+		author = c.User
+	}
+	if assignee == "@me" {
+		assignee = c.User
+	}
+	review := fakeQualifier(query, "review")
+	var results []SearchIssue
+	if !is["issue"] && assignee == "" {
+		for _, pr := range c.PullRequests {
+			pr := pr
+			if (author != "" && pr.Author.Login != author) || (review != "" && !strings.EqualFold(pr.ReviewDecision, review)) {
+				continue
+			}
+			if fakeSearchMatch(pr.Title, words) && (!is["open"] || pr.State == "OPEN") && (!is["closed"] || pr.State != "OPEN") {
+				issue := Issue{Number: pr.Number, Title: pr.Title, State: pr.State, Author: pr.Author,
+					CreatedAt: pr.CreatedAt, UpdatedAt: pr.UpdatedAt, URL: pr.URL}
+				results = append(results, SearchIssue{Issue: issue, Repo: c.Repo, PullRequest: &pr})
+			}
+		}
+	}
+	if !is["pr"] && review == "" {
+		for _, issue := range c.Issues {
+			if (author != "" && issue.Author.Login != author) || (assignee != "" && !fakeAssigned(issue, assignee)) {
+				continue
+			}
+			if fakeSearchMatch(issue.Title, words) && (!is["open"] || issue.State == "OPEN") && (!is["closed"] || issue.State == "CLOSED") {
+				results = append(results, SearchIssue{Issue: issue, Repo: c.Repo})
+			}
+		}
+	}
+	return results, len(results), nil
+}
+
+// SearchRepositories matches the words of query against the names and
+// descriptions of the repository fixtures
+func (c *FakeClient) SearchRepositories(query string) ([]Repository, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, 0, c.Err
+	}
+	words, _ := fakeSearchTerms(query)
+	var repos []Repository
+	for _, repo := range c.Repositories {
+		if fakeSearchMatch(repo.NameWithOwner+" "+repo.Description, words) {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, len(repos), nil
+}
+
+// SearchCode finds the lines of the repo file fixtures containing the words
+// of query, one fragment per line
+func (c *FakeClient) SearchCode(query string) ([]CodeResult, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, 0, c.Err
+	}
+	words, _ := fakeSearchTerms(query)
+	if len(words) == 0 {
+		return nil, 0, fmt.Errorf("code search needs at least one search term")
+	}
+
+	paths := make([]string, 0, len(c.RepoFiles))
+	for path := range c.RepoFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var results []CodeResult
+	for _, path := range paths {
+		result := CodeResult{
+			Repo: c.Repo,
+			Path: path,
+			URL:  fmt.Sprintf("https://github.com/%s/blob/%s/%s", c.Repo, c.Branch, path),
+		}
+		for _, line := range strings.Split(c.RepoFiles[path], "\n") {
+			if !fakeSearchMatch(line, words) {
+				continue
+			}
+			fragment := CodeFragment{Text: line}
+			lower := strings.ToLower(line)
+			for _, word := range words {
+				if i := strings.Index(lower, word); i >= 0 {
+					fragment.Matches = append(fragment.Matches, [2]int{i, i + len(word)})
+				}
+			}
+			result.Fragments = append(result.Fragments, fragment)
+		}
+		if len(result.Fragments) > 0 {
+			results = append(results, result)
+		}
+	}
+	return results, len(results), nil
+}
+
+// fakeParticipating are the reasons of threads the user takes part in
+var fakeParticipating = []string{"assign", "author", "comment", "manual", "mention", "review_requested", "state_change", "team_mention"}
+
+// ListNotifications returns a page of the notification fixtures
+func (c *FakeClient) ListNotifications(participating bool, cursor string) ([]Notification, PageInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var notifications []Notification
+	for _, n := range c.Notifications {
+		if !participating || containsString(fakeParticipating, n.Reason) {
+			notifications = append(notifications, n)
+		}
+	}
+	start, end, page := fakePage(len(notifications), cursor)
+	return append([]Notification{}, notifications[start:end]...), page, c.Err
+}
+
+// MarkNotificationRead marks a notification fixture read
+func (c *FakeClient) MarkNotificationRead(threadID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("MarkNotificationRead %s", threadID); err != nil {
+		return err
+	}
+	for i := range c.Notifications {
+		if c.Notifications[i].ID == threadID {
+			c.Notifications[i].Unread = false
+		}
+	}
+	return nil
+}
+
+// MarkNotificationDone removes a notification fixture
+func (c *FakeClient) MarkNotificationDone(threadID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("MarkNotificationDone %s", threadID); err != nil {
+		return err
+	}
+	for i, n := range c.Notifications {
+		if n.ID == threadID {
+			c.Notifications = append(c.Notifications[:i:i], c.Notifications[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// UnsubscribeNotification records the call
+func (c *FakeClient) UnsubscribeNotification(threadID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.record("UnsubscribeNotification %s", threadID)
+}
+
+// GistFileContent returns the fixture content of a gist file
+func (c *FakeClient) GistFileContent(gistID, filename string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	content, ok := c.GistFiles[gistID][filename]
+	if !ok {
+		return nil, fmt.Errorf("gist %s has no file %s", gistID, filename)
+	}
+	return []byte(content), nil
+}
+
+// UpdateGistFile stores the file at path as the gist's new content
+func (c *FakeClient) UpdateGistFile(gistID, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("UpdateGistFile %s %s", gistID, filepath.Base(path)); err != nil {
+		return err
+	}
+	if c.GistFiles[gistID] == nil {
+		c.GistFiles[gistID] = make(map[string]string)
+	}
+	c.GistFiles[gistID][filepath.Base(path)] = string(data)
+	return nil
+}
+
+// CreateGist adds a new gist fixture from the file at path
+func (c *FakeClient) CreateGist(path, description string, public bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CreateGist %s", filepath.Base(path)); err != nil {
+		return err
+	}
+	id := fmt.Sprintf("fake%d", len(c.Gists)+1)
+	filename := filepath.Base(path)
+	c.Gists = append([]Gist{{
+		ID:          id,
+		Description: description,
+		Public:      public,
+		Files:       []GistFile{{Filename: filename}},
+	}}, c.Gists...)
+	c.GistFiles[id] = map[string]string{filename: string(data)}
+	return nil
+}
+
+// hasLabel reports whether labels include one called name
+func hasLabel(labels []Label, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeSearchTerms splits a search query into lower case words and the
+// values of its is: qualifiers; other qualifiers are dropped
+func fakeSearchTerms(query string) ([]string, map[string]bool) {
+	var words []string
+	is := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(query)) {
+		key, value, ok := strings.Cut(token, ":")
+		switch {
+		case !ok:
+			words = append(words, strings.Trim(token, `"`))
+		case key == "is":
+			is[value] = true
+		}
+	}
+	return words, is
+}
+
+// fakeAssigned reports whether login is assigned to issue
+func fakeAssigned(issue Issue, login string) bool {
+	for _, assignee := range issue.Assignees {
+		if assignee.Login == login {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeQualifier returns the value of the key: qualifier of query, if any
+func fakeQualifier(query, key string) string {
+	for _, token := range strings.Fields(query) {
+		if k, value, ok := strings.Cut(token, ":"); ok && strings.EqualFold(k, key) {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// fakeSearchMatch reports whether text contains every word, in any case
+func fakeSearchMatch(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fixtureRepo is the repo the fixtures in testdata/fixtures belong to
+const fixtureRepo = "octo-org/fixtures"
+
+// newTestClient makes a FakeClient over testdata/fixtures the active backend
+// for the rest of the test
+func newTestClient(t *testing.T) *FakeClient {
+	t.Helper()
+	c, err := NewFakeClient("testdata/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := ghClient
+	ghClient = c
+	t.Cleanup(func() { ghClient = previous })
+	return c
+}
+
+// keyPress is the message for typing key
+func keyPress(key string) tea.KeyMsg {
+	switch key {
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// runCmd runs cmd as the program would and returns its message, nil for no cmd
+func runCmd(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+// assertCalls checks the mutating calls the fake backend has seen
+func assertCalls(t *testing.T, c *FakeClient, want ...string) {
+	t.Helper()
+	if len(want) == 0 && len(c.Calls) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(c.Calls, want) {
+		t.Errorf("calls = %q, want %q", c.Calls, want)
+	}
+}
+
+// addIssues appends n more open issues to the fixtures, numbered from 1000,
+// so that they take more than one page
+func addIssues(c *FakeClient, n int) {
+	for i := 0; i < n; i++ {
+		c.Issues = append(c.Issues, Issue{
+			Number: 1000 + i,
+			Title:  fmt.Sprintf("Generated issue %d", i),
+			State:  "OPEN",
+		})
+	}
+}
+
+func TestFakeClientPages(t *testing.T) {
+	c := newTestClient(t)
+	addIssues(c, pageSize)
+	total := len(c.Issues)
+
+	first, page, err := c.ListIssues(fixtureRepo, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != pageSize || !page.HasNextPage || page.TotalCount != total {
+		t.Fatalf("first page: %d issues, %+v", len(first), page)
+	}
+	rest, page, err := c.ListIssues(fixtureRepo, page.EndCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != total-pageSize || page.HasNextPage {
+		t.Fatalf("last page: %d issues, %+v", len(rest), page)
+	}
+}
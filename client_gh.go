@@ -0,0 +1,499 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// client_gh.go - GitHub CLI Backend
+// Purpose: GitHubClient implementation that shells out to the `gh` binary
+// Requires `gh` to be installed and authenticated
+
+// GHCLIClient implements GitHubClient using the gh CLI
+type GHCLIClient struct{}
+
+// NewGHCLIClient creates a new gh CLI backend
+func NewGHCLIClient() *GHCLIClient {
+	return &GHCLIClient{}
+}
+
+// withRepo appends --repo <repo> to args when repo is set
+func withRepo(args []string, repo string) []string {
+	if repo != "" {
+		return append(args, "--repo", repo)
+	}
+	return args
+}
+
+// CheckAuth verifies gh CLI is authenticated
+func (c *GHCLIClient) CheckAuth() error {
+	cmd := exec.Command("gh", "auth", "status")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("gh not authenticated. Run: gh auth login")
+	}
+	return nil
+}
+
+// CurrentRepo returns the repo for the current directory
+func (c *GHCLIClient) CurrentRepo() (string, error) {
+	cmd := exec.Command("gh", "repo", "view", "--json", "nameWithOwner")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	var repoData struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	}
+	if err := json.Unmarshal(output, &repoData); err != nil {
+		return "", err
+	}
+	return repoData.NameWithOwner, nil
+}
+
+// CurrentUser returns the login of the authenticated user
+func (c *GHCLIClient) CurrentUser() (string, error) {
+	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// graphql runs a GraphQL query through `gh api graphql`
+func (c *GHCLIClient) graphql(query string, variables map[string]interface{}, out interface{}) error {
+	args := []string{"api", "graphql", "-f", "query=" + query}
+	for name, value := range variables {
+		switch value.(type) {
+		case string:
+			// -f keeps strings raw, so repo names like "123" stay strings
+			args = append(args, "-f", fmt.Sprintf("%s=%v", name, value))
+		default:
+			args = append(args, "-F", fmt.Sprintf("%s=%v", name, value))
+		}
+	}
+
+	output, err := exec.Command("gh", args...).Output()
+	if err != nil && len(output) == 0 {
+		return fmt.Errorf("gh api graphql failed: %w", err)
+	}
+	return decodeGraphQLResponse(bytes.NewReader(output), out)
+}
+
+// restGet performs a REST GET through `gh api`
+func (c *GHCLIClient) restGet(path string, out interface{}) error {
+	output, err := exec.Command("gh", "api", strings.TrimPrefix(path, "/")).Output()
+	if err != nil {
+		return fmt.Errorf("gh api %s failed: %w", path, err)
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return fmt.Errorf("parse error: %w", err)
+	}
+	return nil
+}
+
+// rest performs a REST call through `gh api`, sending body as JSON on stdin
+func (c *GHCLIClient) rest(method, path string, body, out interface{}) error {
+	cmd := exec.Command("gh", "api", "-X", method, strings.TrimPrefix(path, "/"))
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		cmd.Args = append(cmd.Args, "--input", "-")
+		cmd.Stdin = bytes.NewReader(data)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		// gh prints the API's error message on stderr
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%s", strings.TrimPrefix(message, "gh: "))
+		}
+		return fmt.Errorf("gh api %s failed: %w", path, err)
+	}
+
+	if out == nil || len(bytes.TrimSpace(output)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return fmt.Errorf("parse error: %w", err)
+	}
+	return nil
+}
+
+// ListPullRequests retrieves one page of open PRs
+func (c *GHCLIClient) ListPullRequests(repo, cursor string) ([]PullRequest, PageInfo, error) {
+	return queryPullRequests(c, repo, cursor)
+}
+
+// ListIssues retrieves one page of open issues
+func (c *GHCLIClient) ListIssues(repo, cursor string) ([]Issue, PageInfo, error) {
+	return queryIssues(c, repo, cursor)
+}
+
+// ListRepositories retrieves one page of repos owned by owner
+func (c *GHCLIClient) ListRepositories(owner, cursor string) ([]Repository, PageInfo, error) {
+	return queryRepositories(c, owner, cursor)
+}
+
+// ListWorkflowRuns retrieves one page of workflow runs
+func (c *GHCLIClient) ListWorkflowRuns(repo, cursor string) ([]WorkflowRun, PageInfo, error) {
+	return queryWorkflowRuns(c, repo, cursor)
+}
+
+// ListGists retrieves one page of the authenticated user's gists
+func (c *GHCLIClient) ListGists(cursor string) ([]Gist, PageInfo, error) {
+	return queryGists(c, cursor)
+}
+
+// ListPullRequestsMatching retrieves one page of repo's PRs matching a search query
+func (c *GHCLIClient) ListPullRequestsMatching(repo, query, cursor string) ([]PullRequest, PageInfo, error) {
+	return queryPullRequestsMatching(c, repo, query, cursor)
+}
+
+// ListIssuesMatching retrieves one page of repo's issues matching a search query
+func (c *GHCLIClient) ListIssuesMatching(repo, query, cursor string) ([]Issue, PageInfo, error) {
+	return queryIssuesMatching(c, repo, query, cursor)
+}
+
+// ListWorkflowRunsMatching retrieves one page of repo's runs matching a runs query
+func (c *GHCLIClient) ListWorkflowRunsMatching(repo, query, cursor string) ([]WorkflowRun, PageInfo, error) {
+	return queryWorkflowRunsMatching(c, repo, query, cursor)
+}
+
+// PullRequestDetail retrieves body, checks, reviews and files of a PR
+func (c *GHCLIClient) PullRequestDetail(repo string, number int) (PullRequestDetail, error) {
+	return queryPullRequestDetail(c, repo, number)
+}
+
+// PullRequestDiff retrieves the unified diff of a PR
+func (c *GHCLIClient) PullRequestDiff(repo string, number int) (string, error) {
+	args := withRepo([]string{"pr", "diff", fmt.Sprintf("%d", number), "--color", "never"}, repo)
+	output, err := exec.Command("gh", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("gh pr diff failed: %w", err)
+	}
+	return string(output), nil
+}
+
+// SubmitReview creates a PR review with its line comments
+func (c *GHCLIClient) SubmitReview(repo string, number int, review ReviewSubmission) error {
+	return createPullRequestReview(c, repo, number, review)
+}
+
+// MergeInfo retrieves a PR's mergeability and the repo's allowed merge methods
+func (c *GHCLIClient) MergeInfo(repo string, number int) (MergeInfo, error) {
+	return queryMergeInfo(c, repo, number)
+}
+
+// MergePullRequest merges a PR
+func (c *GHCLIClient) MergePullRequest(repo string, number int, req MergeRequest) error {
+	return mergePullRequest(c, repo, number, req)
+}
+
+// DeleteBranch deletes a branch, e.g. a merged PR's head
+func (c *GHCLIClient) DeleteBranch(repo, branch string) error {
+	return deleteBranch(c, repo, branch)
+}
+
+// WorkflowRunDetail retrieves a run's status and its jobs with their steps
+func (c *GHCLIClient) WorkflowRunDetail(repo string, runID int64) (WorkflowRunDetail, error) {
+	return queryWorkflowRunDetail(c, repo, runID)
+}
+
+// WorkflowRunLogs downloads the log archive of a run
+func (c *GHCLIClient) WorkflowRunLogs(repo string, runID int64) ([]byte, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs/%d/logs", repo, runID)
+	var stderr bytes.Buffer
+	cmd := exec.Command("gh", "api", path)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s", strings.TrimPrefix(message, "gh: "))
+		}
+		return nil, fmt.Errorf("gh api %s failed: %w", path, err)
+	}
+	return output, nil
+}
+
+// RerunWorkflowRun re-runs a completed run or its failed jobs
+func (c *GHCLIClient) RerunWorkflowRun(repo string, runID int64, failedOnly, debug bool) error {
+	return rerunWorkflowRun(c, repo, runID, failedOnly, debug)
+}
+
+// CancelWorkflowRun cancels a queued or running run
+func (c *GHCLIClient) CancelWorkflowRun(repo string, runID int64) error {
+	return cancelWorkflowRun(c, repo, runID)
+}
+
+// ListWorkflows lists the workflows of a repo
+func (c *GHCLIClient) ListWorkflows(repo string) ([]Workflow, error) {
+	return queryWorkflows(c, repo)
+}
+
+// ListEnvironments lists the deployment environments of a repo
+func (c *GHCLIClient) ListEnvironments(repo string) ([]string, error) {
+	return queryEnvironments(c, repo)
+}
+
+// DispatchWorkflow starts a workflow_dispatch run
+func (c *GHCLIClient) DispatchWorkflow(repo string, workflowID int64, ref string, inputs map[string]string) error {
+	return dispatchWorkflow(c, repo, workflowID, ref, inputs)
+}
+
+// ListDispatchRuns lists the latest dispatched runs of a workflow on branch
+func (c *GHCLIClient) ListDispatchRuns(repo string, workflowID int64, branch string) ([]WorkflowRun, error) {
+	return queryDispatchRuns(c, repo, workflowID, branch)
+}
+
+// ListArtifacts lists the artifacts of a run
+func (c *GHCLIClient) ListArtifacts(repo string, runID int64) ([]Artifact, error) {
+	return queryArtifacts(c, repo, runID)
+}
+
+// DownloadArtifact streams the zip archive of an artifact from `gh api`
+func (c *GHCLIClient) DownloadArtifact(repo string, artifactID int64) (io.ReadCloser, error) {
+	path := fmt.Sprintf("repos/%s/actions/artifacts/%d/zip", repo, artifactID)
+	cmd := exec.Command("gh", "api", path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	r := &ghStream{ReadCloser: stdout, cmd: cmd, path: path}
+	cmd.Stderr = &r.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ghStream is the output of a running gh command; closing it reports
+// whether the command succeeded
+type ghStream struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	path   string
+	stderr bytes.Buffer
+}
+
+// Close waits for gh to exit
+func (s *ghStream) Close() error {
+	s.ReadCloser.Close()
+	if err := s.cmd.Wait(); err != nil {
+		if message := strings.TrimSpace(s.stderr.String()); message != "" {
+			return fmt.Errorf("%s", strings.TrimPrefix(message, "gh: "))
+		}
+		return fmt.Errorf("gh api %s failed: %w", s.path, err)
+	}
+	return nil
+}
+
+// OpenInBrowser opens a GitHub item in the default browser
+// itemType: "pr", "issue", "repo", "run", "workflow", "gist"
+func (c *GHCLIClient) OpenInBrowser(itemType, identifier, repo string) error {
+	var args []string
+
+	switch itemType {
+	case "pr":
+		// gh pr view <number> --web [--repo <repo>]
+		args = withRepo([]string{"pr", "view", identifier, "--web"}, repo)
+	case "issue":
+		// gh issue view <number> --web [--repo <repo>]
+		args = withRepo([]string{"issue", "view", identifier, "--web"}, repo)
+	case "repo":
+		// gh repo view <repo> --web
+		args = []string{"repo", "view", identifier, "--web"}
+	case "run":
+		// gh run view <id> --web [--repo <repo>]
+		args = withRepo([]string{"run", "view", identifier, "--web"}, repo)
+	case "workflow":
+		// gh workflow view <file> --web [--repo <repo>]
+		args = withRepo([]string{"workflow", "view", identifier, "--web"}, repo)
+	case "gist":
+		// gh gist view <id> --web
+		args = []string{"gist", "view", identifier, "--web"}
+	default:
+		return fmt.Errorf("unknown item type: %s", itemType)
+	}
+
+	return exec.Command("gh", args...).Run()
+}
+
+// CreateIssueInBrowser opens the browser with GitHub's new issue form
+func (c *GHCLIClient) CreateIssueInBrowser(repo string) error {
+	return exec.Command("gh", withRepo([]string{"issue", "create", "--web"}, repo)...).Run()
+}
+
+// IsRepoStarred reports whether the authenticated user has starred repo
+func (c *GHCLIClient) IsRepoStarred(repo string) (bool, error) {
+	cmd := exec.Command("gh", "repo", "view", repo, "--json", "viewerHasStarred", "-q", ".viewerHasStarred")
+	output, err := cmd.Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(output)) == "true", nil
+}
+
+// StarRepo stars a repository
+func (c *GHCLIClient) StarRepo(repo string) error {
+	return exec.Command("gh", "repo", "star", repo).Run()
+}
+
+// UnstarRepo unstars a repository
+func (c *GHCLIClient) UnstarRepo(repo string) error {
+	return exec.Command("gh", "repo", "unstar", repo).Run()
+}
+
+// CloneRepository clones a repository into the current directory
+func (c *GHCLIClient) CloneRepository(repo string) error {
+	return exec.Command("gh", "repo", "clone", repo).Run()
+}
+
+// ForkRepository forks a repository without adding a remote
+func (c *GHCLIClient) ForkRepository(repo string) error {
+	return exec.Command("gh", "repo", "fork", repo, "--remote=false").Run()
+}
+
+// RepoFile retrieves the content of a file in a repo
+func (c *GHCLIClient) RepoFile(repo, path, ref string) ([]byte, error) {
+	return queryRepoFile(c, repo, path, ref)
+}
+
+// RepoDir retrieves the text files in a directory of a repo
+func (c *GHCLIClient) RepoDir(repo, path string) (map[string]string, error) {
+	return queryRepoDir(c, repo, path)
+}
+
+// DefaultBranch retrieves the default branch of a repo
+func (c *GHCLIClient) DefaultBranch(repo string) (string, error) {
+	return queryDefaultBranch(c, repo)
+}
+
+// IssueDetail retrieves the body and timeline of an issue
+func (c *GHCLIClient) IssueDetail(repo string, number int) (IssueDetail, error) {
+	return queryIssueDetail(c, repo, number)
+}
+
+// CreateIssue opens a new issue
+func (c *GHCLIClient) CreateIssue(repo string, issue NewIssue) (Issue, error) {
+	return createIssue(c, repo, issue)
+}
+
+// AddComment comments on an issue or PR
+func (c *GHCLIClient) AddComment(repo string, number int, body string) error {
+	return addComment(c, repo, number, body)
+}
+
+// ListLabels retrieves the labels of a repo
+func (c *GHCLIClient) ListLabels(repo string) ([]Label, error) {
+	return queryLabels(c, repo)
+}
+
+// ListAssignableUsers retrieves who issues in a repo can be assigned to
+func (c *GHCLIClient) ListAssignableUsers(repo string) ([]string, error) {
+	return queryAssignableUsers(c, repo)
+}
+
+// ListMilestones retrieves the open milestones of a repo
+func (c *GHCLIClient) ListMilestones(repo string) ([]Milestone, error) {
+	return queryMilestones(c, repo)
+}
+
+// EditIssue changes the labels, assignees and milestone of an issue
+func (c *GHCLIClient) EditIssue(repo string, number int, edit IssueEdit) (Issue, error) {
+	return editIssue(c, repo, number, edit)
+}
+
+// CloseIssue closes an issue
+func (c *GHCLIClient) CloseIssue(repo string, number int) error {
+	return exec.Command("gh", withRepo([]string{"issue", "close", fmt.Sprintf("%d", number)}, repo)...).Run()
+}
+
+// ReopenIssue reopens a closed issue
+func (c *GHCLIClient) ReopenIssue(repo string, number int) error {
+	return exec.Command("gh", withRepo([]string{"issue", "reopen", fmt.Sprintf("%d", number)}, repo)...).Run()
+}
+
+// SearchIssues searches issues and PRs across GitHub
+func (c *GHCLIClient) SearchIssues(query string) ([]SearchIssue, int, error) {
+	return searchIssues(c, query)
+}
+
+// SearchRepositories searches repositories across GitHub
+func (c *GHCLIClient) SearchRepositories(query string) ([]Repository, int, error) {
+	return searchRepositories(c, query)
+}
+
+// SearchCode searches code across GitHub, with the fragments that matched
+func (c *GHCLIClient) SearchCode(query string) ([]CodeResult, int, error) {
+	path := codeSearchPath(query)
+	cmd := exec.Command("gh", "api", "-H", "Accept: "+textMatchMediaType, strings.TrimPrefix(path, "/"))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, 0, fmt.Errorf("%s", strings.TrimPrefix(message, "gh: "))
+		}
+		return nil, 0, fmt.Errorf("gh api search/code failed: %w", err)
+	}
+	return decodeCodeSearch(output)
+}
+
+// ListNotifications retrieves one page of the notifications inbox
+func (c *GHCLIClient) ListNotifications(participating bool, cursor string) ([]Notification, PageInfo, error) {
+	return queryNotifications(c, participating, cursor)
+}
+
+// MarkNotificationRead marks a notification thread read
+func (c *GHCLIClient) MarkNotificationRead(threadID string) error {
+	return markNotificationRead(c, threadID)
+}
+
+// MarkNotificationDone marks a notification thread done
+func (c *GHCLIClient) MarkNotificationDone(threadID string) error {
+	return markNotificationDone(c, threadID)
+}
+
+// UnsubscribeNotification mutes a notification thread
+func (c *GHCLIClient) UnsubscribeNotification(threadID string) error {
+	return unsubscribeNotification(c, threadID)
+}
+
+// GistFileContent downloads the content of a single gist file
+func (c *GHCLIClient) GistFileContent(gistID, filename string) ([]byte, error) {
+	cmd := exec.Command("gh", "gist", "view", gistID, "--filename", filename)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", string(output), err)
+	}
+	return output, nil
+}
+
+// UpdateGistFile uploads path as a file of the given gist
+func (c *GHCLIClient) UpdateGistFile(gistID, path string) error {
+	return exec.Command("gh", "gist", "edit", gistID, "-a", path).Run()
+}
+
+// CreateGist creates a new gist from the file at path
+func (c *GHCLIClient) CreateGist(path, description string, public bool) error {
+	args := []string{"gist", "create", path}
+
+	if description != "" {
+		args = append(args, "-d", description)
+	}
+
+	if public {
+		args = append(args, "-p")
+	}
+
+	return exec.Command("gh", args...).Run()
+}
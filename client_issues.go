@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client_issues.go - Shared Issue Queries
+// Purpose: Per-issue queries and mutations shared by the gh and api backends
+// When to extend: Add issue-specific GraphQL here and call it from each backend
+
+// issueTimelineLimit is how many of the latest timeline items are fetched
+const issueTimelineLimit = 100
+
+const issueDetailQuery = `query($owner: String!, $name: String!, $number: Int!, $last: Int!) {
+  repository(owner: $owner, name: $name) {
+    issue(number: $number) {
+      number body
+      timelineItems(last: $last, itemTypes: [
+        ISSUE_COMMENT, LABELED_EVENT, UNLABELED_EVENT, ASSIGNED_EVENT, UNASSIGNED_EVENT,
+        CROSS_REFERENCED_EVENT, CLOSED_EVENT, REOPENED_EVENT, RENAMED_TITLE_EVENT,
+        MILESTONED_EVENT, DEMILESTONED_EVENT
+      ]) {
+        totalCount
+        nodes {
+          __typename
+          ... on IssueComment { author { login } createdAt body }
+          ... on LabeledEvent { actor { login } createdAt label { name color } }
+          ... on UnlabeledEvent { actor { login } createdAt label { name color } }
+          ... on AssignedEvent {
+            actor { login } createdAt
+            assignee { ... on User { login } ... on Bot { login } ... on Mannequin { login } }
+          }
+          ... on UnassignedEvent {
+            actor { login } createdAt
+            assignee { ... on User { login } ... on Bot { login } ... on Mannequin { login } }
+          }
+          ... on CrossReferencedEvent {
+            actor { login } createdAt
+            source {
+              __typename
+              ... on Issue { number title issueState: state url repository { nameWithOwner } }
+              ... on PullRequest { number title prState: state url repository { nameWithOwner } }
+            }
+          }
+          ... on ClosedEvent { actor { login } createdAt }
+          ... on ReopenedEvent { actor { login } createdAt }
+          ... on RenamedTitleEvent { actor { login } createdAt previousTitle currentTitle }
+          ... on MilestonedEvent { actor { login } createdAt milestoneTitle }
+          ... on DemilestonedEvent { actor { login } createdAt milestoneTitle }
+        }
+      }
+    }
+  }
+}`
+
+// gqlTimelineItem is any of the timeline items asked for by issueDetailQuery
+type gqlTimelineItem struct {
+	Typename  string    `json:"__typename"`
+	Author    *Author   `json:"author"` // IssueComment
+	Actor     *Author   `json:"actor"`  // Everything else; nil for deleted users
+	CreatedAt time.Time `json:"createdAt"`
+	Body      string    `json:"body"`
+	Label     *Label    `json:"label"`
+	Assignee  *Author   `json:"assignee"`
+	Source    *struct {
+		Typename   string `json:"__typename"`
+		Number     int    `json:"number"`
+		Title      string `json:"title"`
+		IssueState string `json:"issueState"` // Aliased, the two state enums differ
+		PRState    string `json:"prState"`
+		URL        string `json:"url"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+	} `json:"source"`
+	PreviousTitle  string `json:"previousTitle"`
+	CurrentTitle   string `json:"currentTitle"`
+	MilestoneTitle string `json:"milestoneTitle"`
+}
+
+// timelineEventTypes maps GraphQL timeline item types to IssueEvent types
+var timelineEventTypes = map[string]string{
+	"IssueComment":         "comment",
+	"LabeledEvent":         "labeled",
+	"UnlabeledEvent":       "unlabeled",
+	"AssignedEvent":        "assigned",
+	"UnassignedEvent":      "unassigned",
+	"CrossReferencedEvent": "cross-referenced",
+	"ClosedEvent":          "closed",
+	"ReopenedEvent":        "reopened",
+	"RenamedTitleEvent":    "renamed",
+	"MilestonedEvent":      "milestoned",
+	"DemilestonedEvent":    "demilestoned",
+}
+
+// toEvent normalizes a timeline item to an IssueEvent
+func (t gqlTimelineItem) toEvent() IssueEvent {
+	event := IssueEvent{
+		Type:      timelineEventTypes[t.Typename],
+		CreatedAt: t.CreatedAt,
+		Body:      t.Body,
+		Label:     t.Label,
+		Milestone: t.MilestoneTitle,
+		From:      t.PreviousTitle,
+		To:        t.CurrentTitle,
+	}
+
+	switch {
+	case t.Author != nil:
+		event.Actor = *t.Author
+	case t.Actor != nil:
+		event.Actor = *t.Actor
+	default:
+		event.Actor = Author{Login: "ghost"}
+	}
+	if t.Assignee != nil {
+		event.Assignee = t.Assignee.Login
+	}
+	if t.Source != nil {
+		state := t.Source.IssueState
+		if state == "" {
+			state = t.Source.PRState
+		}
+		event.Source = &IssueReference{
+			Repo:          t.Source.Repository.NameWithOwner,
+			Number:        t.Source.Number,
+			Title:         t.Source.Title,
+			State:         state,
+			IsPullRequest: t.Source.Typename == "PullRequest",
+			URL:           t.Source.URL,
+		}
+	}
+	return event
+}
+
+// queryIssueDetail fetches the body and latest timeline items of an issue
+func queryIssueDetail(r graphQLRunner, repo string, number int) (IssueDetail, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return IssueDetail{}, err
+	}
+
+	var data struct {
+		Repository struct {
+			Issue struct {
+				Number        int    `json:"number"`
+				Body          string `json:"body"`
+				TimelineItems struct {
+					TotalCount int               `json:"totalCount"`
+					Nodes      []gqlTimelineItem `json:"nodes"`
+				} `json:"timelineItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "name": name, "number": number, "last": issueTimelineLimit}
+	if err := r.graphql(issueDetailQuery, vars, &data); err != nil {
+		return IssueDetail{}, err
+	}
+
+	issue := data.Repository.Issue
+	detail := IssueDetail{
+		Number:        issue.Number,
+		Body:          issue.Body,
+		EarlierEvents: max(0, issue.TimelineItems.TotalCount-len(issue.TimelineItems.Nodes)),
+	}
+	for _, node := range issue.TimelineItems.Nodes {
+		detail.Timeline = append(detail.Timeline, node.toEvent())
+	}
+	return detail, nil
+}
+
+// restIssue is an issue as returned by the REST API
+type restIssue struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	User      Author     `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Labels    []Label    `json:"labels"`
+	Assignees []Author   `json:"assignees"`
+	Milestone *Milestone `json:"milestone"`
+	HTMLURL   string     `json:"html_url"`
+}
+
+// toIssue converts to the GraphQL shape used everywhere else
+func (i restIssue) toIssue() Issue {
+	return Issue{
+		Number:    i.Number,
+		Title:     i.Title,
+		State:     strings.ToUpper(i.State),
+		Author:    i.User,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+		Labels:    i.Labels,
+		Assignees: i.Assignees,
+		Milestone: i.Milestone,
+		URL:       i.HTMLURL,
+	}
+}
+
+// createIssue opens a new issue and returns it
+func createIssue(r restCaller, repo string, issue NewIssue) (Issue, error) {
+	if _, _, err := splitRepo(repo); err != nil {
+		return Issue{}, err
+	}
+	var created restIssue
+	if err := r.rest(http.MethodPost, fmt.Sprintf("/repos/%s/issues", repo), issue, &created); err != nil {
+		return Issue{}, err
+	}
+	return created.toIssue(), nil
+}
+
+// addComment comments on an issue or PR, which share their numbering
+func addComment(r restCaller, repo string, number int, body string) error {
+	if _, _, err := splitRepo(repo); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number)
+	return r.rest(http.MethodPost, path, map[string]string{"body": body}, nil)
+}
+
+// queryLabels fetches the labels of repo, up to 100
+func queryLabels(r restGetter, repo string) ([]Label, error) {
+	var labels []Label
+	if err := r.restGet(fmt.Sprintf("/repos/%s/labels?per_page=100", repo), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// queryAssignableUsers fetches the logins issues in repo can be assigned to, up to 100
+func queryAssignableUsers(r restGetter, repo string) ([]string, error) {
+	var users []Author
+	if err := r.restGet(fmt.Sprintf("/repos/%s/assignees?per_page=100", repo), &users); err != nil {
+		return nil, err
+	}
+	logins := make([]string, len(users))
+	for i, user := range users {
+		logins[i] = user.Login
+	}
+	return logins, nil
+}
+
+// queryMilestones fetches the open milestones of repo, soonest due first
+func queryMilestones(r restGetter, repo string) ([]Milestone, error) {
+	var milestones []Milestone
+	path := fmt.Sprintf("/repos/%s/milestones?state=open&sort=due_on&per_page=100", repo)
+	if err := r.restGet(path, &milestones); err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// editIssue applies edit to an issue and returns the issue as it is now
+// GitHub has no single call for this, so labels, assignees and the milestone
+// are changed one after the other; a failure leaves the earlier changes in place.
+func editIssue(r restCaller, repo string, number int, edit IssueEdit) (Issue, error) {
+	if _, _, err := splitRepo(repo); err != nil {
+		return Issue{}, err
+	}
+	path := fmt.Sprintf("/repos/%s/issues/%d", repo, number)
+
+	if len(edit.AddLabels) > 0 {
+		if err := r.rest(http.MethodPost, path+"/labels", map[string][]string{"labels": edit.AddLabels}, nil); err != nil {
+			return Issue{}, err
+		}
+	}
+	for _, label := range edit.RemoveLabels {
+		if err := r.rest(http.MethodDelete, path+"/labels/"+url.PathEscape(label), nil, nil); err != nil {
+			return Issue{}, err
+		}
+	}
+	if len(edit.AddAssignees) > 0 {
+		if err := r.rest(http.MethodPost, path+"/assignees", map[string][]string{"assignees": edit.AddAssignees}, nil); err != nil {
+			return Issue{}, err
+		}
+	}
+	if len(edit.RemoveAssignees) > 0 {
+		if err := r.rest(http.MethodDelete, path+"/assignees", map[string][]string{"assignees": edit.RemoveAssignees}, nil); err != nil {
+			return Issue{}, err
+		}
+	}
+	if edit.Milestone != nil {
+		// null clears the milestone
+		var milestone interface{}
+		if *edit.Milestone != 0 {
+			milestone = *edit.Milestone
+		}
+		if err := r.rest(http.MethodPatch, path, map[string]interface{}{"milestone": milestone}, nil); err != nil {
+			return Issue{}, err
+		}
+	}
+
+	var issue restIssue
+	if err := r.rest(http.MethodGet, path, nil, &issue); err != nil {
+		return Issue{}, err
+	}
+	return issue.toIssue(), nil
+}
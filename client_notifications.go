@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+)
+
+// client_notifications.go - Shared Notifications Queries
+// Purpose: The notifications inbox and its thread actions, shared by the gh and api backends
+// When to extend: Add notification REST calls here and call them from each backend
+
+// apiNotification is the REST representation of a notification thread
+type apiNotification struct {
+	ID         string    `json:"id"`
+	Reason     string    `json:"reason"`
+	Unread     bool      `json:"unread"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Subject struct {
+		Title string `json:"title"`
+		URL   string `json:"url"` // API URL of the PR or issue; empty for CI activity
+		Type  string `json:"type"`
+	} `json:"subject"`
+}
+
+// toNotification converts a REST thread to our Notification structure
+func (n apiNotification) toNotification() Notification {
+	notification := Notification{
+		ID:        n.ID,
+		Repo:      n.Repository.FullName,
+		Reason:    n.Reason,
+		Unread:    n.Unread,
+		UpdatedAt: n.UpdatedAt,
+		Type:      n.Subject.Type,
+		Title:     n.Subject.Title,
+	}
+	if n.Subject.Type == "PullRequest" || n.Subject.Type == "Issue" {
+		// .../repos/owner/name/pulls/42 or .../issues/42
+		notification.Number, _ = strconv.Atoi(path.Base(n.Subject.URL))
+	}
+	return notification
+}
+
+// queryNotifications fetches one page of the inbox via REST
+// The notifications API does not report a total, so TotalCount is -1
+func queryNotifications(r restGetter, participating bool, cursor string) ([]Notification, PageInfo, error) {
+	page := restPage(cursor)
+
+	var threads []apiNotification
+	p := fmt.Sprintf("/notifications?all=true&participating=%t&per_page=%d&page=%d", participating, pageSize, page)
+	if err := r.restGet(p, &threads); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	notifications := make([]Notification, len(threads))
+	for i, thread := range threads {
+		notifications[i] = thread.toNotification()
+	}
+
+	info := PageInfo{TotalCount: -1}
+	if len(threads) == pageSize {
+		info.HasNextPage = true
+		info.EndCursor = strconv.Itoa(page + 1)
+	}
+	return notifications, info, nil
+}
+
+// markNotificationRead marks a thread read; it stays in the inbox
+func markNotificationRead(r restCaller, threadID string) error {
+	return r.rest("PATCH", "/notifications/threads/"+threadID, nil, nil)
+}
+
+// markNotificationDone marks a thread done, taking it out of the inbox
+func markNotificationDone(r restCaller, threadID string) error {
+	return r.rest("DELETE", "/notifications/threads/"+threadID, nil, nil)
+}
+
+// unsubscribeNotification mutes a thread: no more notifications until the
+// user is mentioned or comments
+func unsubscribeNotification(r restCaller, threadID string) error {
+	return r.rest("DELETE", "/notifications/threads/"+threadID+"/subscription", nil, nil)
+}
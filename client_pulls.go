@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// client_pulls.go - Shared Pull Request Queries
+// Purpose: Per-PR queries and mutations shared by the gh and api backends
+// When to extend: Add PR-specific GraphQL here and call it from each backend
+
+const pullRequestDetailQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      number body additions deletions changedFiles
+      files(first: 100) { nodes { path additions deletions } }
+      reviewRequests(first: 20) {
+        nodes {
+          requestedReviewer {
+            ... on User { login }
+            ... on Bot { login }
+            ... on Team { name }
+          }
+        }
+      }
+      latestReviews(first: 20) {
+        nodes { author { login } state submittedAt }
+      }
+      commits(last: 1) {
+        nodes {
+          commit {
+            statusCheckRollup {
+              state
+              contexts(first: 100) {
+                nodes {
+                  __typename
+                  ... on CheckRun { name status conclusion detailsUrl }
+                  ... on StatusContext { context state targetUrl }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// gqlCheckContext is a CheckRun or StatusContext in a status check rollup
+type gqlCheckContext struct {
+	Typename string `json:"__typename"`
+
+	// CheckRun
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+
+	// StatusContext
+	Context   string `json:"context"`
+	State     string `json:"state"`
+	TargetURL string `json:"targetUrl"`
+}
+
+// toCheck normalizes both context kinds to a PullRequestCheck
+func (c gqlCheckContext) toCheck() PullRequestCheck {
+	if c.Typename == "StatusContext" {
+		return PullRequestCheck{Name: c.Context, State: c.State, URL: c.TargetURL}
+	}
+
+	// Check runs only have a conclusion once completed
+	state := c.Conclusion
+	if state == "" {
+		state = c.Status
+	}
+	return PullRequestCheck{Name: c.Name, State: state, URL: c.DetailsURL}
+}
+
+// gqlPullRequestDetail is a PR node as returned by pullRequestDetailQuery
+type gqlPullRequestDetail struct {
+	Number       int    `json:"number"`
+	Body         string `json:"body"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	ChangedFiles int    `json:"changedFiles"`
+	Files        struct {
+		Nodes []PullRequestFile `json:"nodes"`
+	} `json:"files"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login string `json:"login"`
+				Name  string `json:"name"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	LatestReviews struct {
+		Nodes []struct {
+			Author      Author    `json:"author"`
+			State       string    `json:"state"`
+			SubmittedAt time.Time `json:"submittedAt"`
+		} `json:"nodes"`
+	} `json:"latestReviews"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State    string `json:"state"`
+					Contexts struct {
+						Nodes []gqlCheckContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// toDetail flattens the GraphQL connections
+func (p gqlPullRequestDetail) toDetail() PullRequestDetail {
+	detail := PullRequestDetail{
+		Number:       p.Number,
+		Body:         p.Body,
+		Additions:    p.Additions,
+		Deletions:    p.Deletions,
+		ChangedFiles: p.ChangedFiles,
+		Files:        p.Files.Nodes,
+	}
+
+	for _, node := range p.ReviewRequests.Nodes {
+		reviewer := node.RequestedReviewer.Login
+		if reviewer == "" {
+			reviewer = node.RequestedReviewer.Name
+		}
+		if reviewer != "" {
+			detail.ReviewRequests = append(detail.ReviewRequests, reviewer)
+		}
+	}
+
+	for _, node := range p.LatestReviews.Nodes {
+		detail.Reviews = append(detail.Reviews, PullRequestReview{
+			Author:      node.Author,
+			State:       node.State,
+			SubmittedAt: node.SubmittedAt,
+		})
+	}
+
+	if len(p.Commits.Nodes) > 0 {
+		if rollup := p.Commits.Nodes[0].Commit.StatusCheckRollup; rollup != nil {
+			detail.ChecksState = rollup.State
+			for _, context := range rollup.Contexts.Nodes {
+				detail.Checks = append(detail.Checks, context.toCheck())
+			}
+		}
+	}
+
+	return detail
+}
+
+// queryPullRequestDetail fetches body, checks, reviews and files of a PR
+func queryPullRequestDetail(r graphQLRunner, repo string, number int) (PullRequestDetail, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return PullRequestDetail{}, err
+	}
+
+	var data struct {
+		Repository struct {
+			PullRequest gqlPullRequestDetail `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "name": name, "number": number}
+	if err := r.graphql(pullRequestDetailQuery, vars, &data); err != nil {
+		return PullRequestDetail{}, err
+	}
+	return data.Repository.PullRequest.toDetail(), nil
+}
+
+// createPullRequestReview submits a review and its line comments in one call
+func createPullRequestReview(r restCaller, repo string, number int, review ReviewSubmission) error {
+	if _, _, err := splitRepo(repo); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo, number)
+	return r.rest(http.MethodPost, path, review, nil)
+}
+
+const mergeInfoQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    mergeCommitAllowed squashMergeAllowed rebaseMergeAllowed
+    pullRequest(number: $number) {
+      number title body state isDraft mergeable mergeStateStatus reviewDecision
+      headRefName baseRefName headRefOid isCrossRepository viewerCanDeleteHeadRef
+    }
+  }
+}`
+
+// queryMergeInfo fetches a PR's mergeability and the repo's allowed merge methods
+func queryMergeInfo(r graphQLRunner, repo string, number int) (MergeInfo, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return MergeInfo{}, err
+	}
+
+	var data struct {
+		Repository struct {
+			MergeCommitAllowed bool `json:"mergeCommitAllowed"`
+			SquashMergeAllowed bool `json:"squashMergeAllowed"`
+			RebaseMergeAllowed bool `json:"rebaseMergeAllowed"`
+			PullRequest        struct {
+				MergeInfo
+				IsCrossRepository      bool `json:"isCrossRepository"`
+				ViewerCanDeleteHeadRef bool `json:"viewerCanDeleteHeadRef"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "name": name, "number": number}
+	if err := r.graphql(mergeInfoQuery, vars, &data); err != nil {
+		return MergeInfo{}, err
+	}
+
+	pr := data.Repository.PullRequest
+	info := pr.MergeInfo
+	info.CanDeleteBranch = pr.ViewerCanDeleteHeadRef && !pr.IsCrossRepository
+	for _, method := range []struct {
+		name    string
+		allowed bool
+	}{
+		{"merge", data.Repository.MergeCommitAllowed},
+		{"squash", data.Repository.SquashMergeAllowed},
+		{"rebase", data.Repository.RebaseMergeAllowed},
+	} {
+		if method.allowed {
+			info.AllowedMethods = append(info.AllowedMethods, method.name)
+		}
+	}
+	return info, nil
+}
+
+// mergePullRequest merges a PR with the chosen method and commit message
+func mergePullRequest(r restCaller, repo string, number int, req MergeRequest) error {
+	if _, _, err := splitRepo(repo); err != nil {
+		return err
+	}
+	body := map[string]string{"merge_method": req.Method}
+	if req.Title != "" {
+		body["commit_title"] = req.Title
+	}
+	if req.Body != "" {
+		body["commit_message"] = req.Body
+	}
+	if req.SHA != "" {
+		body["sha"] = req.SHA
+	}
+	path := fmt.Sprintf("/repos/%s/pulls/%d/merge", repo, number)
+	return r.rest(http.MethodPut, path, body, nil)
+}
+
+// deleteBranch deletes a branch of repo
+func deleteBranch(r restCaller, repo, branch string) error {
+	if _, _, err := splitRepo(repo); err != nil {
+		return err
+	}
+	return r.rest(http.MethodDelete, fmt.Sprintf("/repos/%s/git/refs/heads/%s", repo, branch), nil, nil)
+}
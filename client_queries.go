@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// client_queries.go - Shared List Queries
+// Purpose: Paginated list queries shared by the gh and api backends
+// Both backends can run GraphQL and REST GETs; only the transport differs.
+
+// pageSize is the number of items requested per page
+const pageSize = 50
+
+// graphQLRunner executes a GraphQL query and decodes its data into out
+type graphQLRunner interface {
+	graphql(query string, variables map[string]interface{}, out interface{}) error
+}
+
+// restGetter performs a REST GET and decodes the response into out
+type restGetter interface {
+	restGet(path string, out interface{}) error
+}
+
+// restCaller performs a REST call, sending body as JSON and decoding the response into out
+type restCaller interface {
+	rest(method, path string, body, out interface{}) error
+}
+
+// decodeGraphQLResponse unwraps a GraphQL response envelope into out
+func decodeGraphQLResponse(r io.Reader, out interface{}) error {
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return fmt.Errorf("parse error: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("GraphQL: %s", result.Errors[0].Message)
+	}
+	return json.Unmarshal(result.Data, out)
+}
+
+// gqlPage is the pagination part of a GraphQL connection
+type gqlPage struct {
+	TotalCount int `json:"totalCount"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// toPageInfo converts a GraphQL connection page to our PageInfo
+func (p gqlPage) toPageInfo() PageInfo {
+	return PageInfo{
+		EndCursor:   p.PageInfo.EndCursor,
+		HasNextPage: p.PageInfo.HasNextPage,
+		TotalCount:  p.TotalCount,
+	}
+}
+
+// pageVariables builds the variables for a paginated GraphQL query
+func pageVariables(vars map[string]interface{}, cursor string) map[string]interface{} {
+	vars["first"] = pageSize
+	if cursor != "" {
+		vars["cursor"] = cursor
+	}
+	return vars
+}
+
+// GraphQL node shapes that differ from our flat structs
+type gqlLabels struct {
+	Nodes []Label `json:"nodes"`
+}
+
+type gqlUsers struct {
+	Nodes []Author `json:"nodes"`
+}
+
+const pullRequestsQuery = `query($owner: String!, $name: String!, $first: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $first, after: $cursor, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number title state createdAt updatedAt headRefName baseRefName
+        isDraft reviewDecision mergeable url
+        author { login }
+      }
+    }
+  }
+}`
+
+// queryPullRequests fetches one page of open PRs
+func queryPullRequests(r graphQLRunner, repo, cursor string) ([]PullRequest, PageInfo, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var data struct {
+		Repository struct {
+			PullRequests struct {
+				gqlPage
+				Nodes []PullRequest `json:"nodes"`
+			} `json:"pullRequests"`
+		} `json:"repository"`
+	}
+	vars := pageVariables(map[string]interface{}{"owner": owner, "name": name}, cursor)
+	if err := r.graphql(pullRequestsQuery, vars, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+	conn := data.Repository.PullRequests
+	return conn.Nodes, conn.toPageInfo(), nil
+}
+
+const issuesQuery = `query($owner: String!, $name: String!, $first: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    issues(first: $first, after: $cursor, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number title state createdAt updatedAt url
+        author { login }
+        labels(first: 20) { nodes { name color } }
+        assignees(first: 10) { nodes { login } }
+        milestone { number title }
+      }
+    }
+  }
+}`
+
+// gqlIssue is an issue node as returned by GraphQL
+type gqlIssue struct {
+	Issue
+	Labels    gqlLabels `json:"labels"`
+	Assignees gqlUsers  `json:"assignees"`
+}
+
+// toIssue flattens the GraphQL connections
+func (i gqlIssue) toIssue() Issue {
+	issue := i.Issue
+	issue.Labels = i.Labels.Nodes
+	issue.Assignees = i.Assignees.Nodes
+	return issue
+}
+
+// queryIssues fetches one page of open issues
+func queryIssues(r graphQLRunner, repo, cursor string) ([]Issue, PageInfo, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var data struct {
+		Repository struct {
+			Issues struct {
+				gqlPage
+				Nodes []gqlIssue `json:"nodes"`
+			} `json:"issues"`
+		} `json:"repository"`
+	}
+	vars := pageVariables(map[string]interface{}{"owner": owner, "name": name}, cursor)
+	if err := r.graphql(issuesQuery, vars, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	conn := data.Repository.Issues
+	issues := make([]Issue, len(conn.Nodes))
+	for i, node := range conn.Nodes {
+		issues[i] = node.toIssue()
+	}
+	return issues, conn.toPageInfo(), nil
+}
+
+const repositoriesQuery = `query($owner: String!, $first: Int!, $cursor: String) {
+  repositoryOwner(login: $owner) {
+    repositories(first: $first, after: $cursor, ownerAffiliations: OWNER, orderBy: {field: PUSHED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        name nameWithOwner description stargazerCount forkCount visibility url
+        primaryLanguage { name }
+        issues(states: OPEN) { totalCount }
+      }
+    }
+  }
+}`
+
+// gqlRepository is a repository node as returned by GraphQL
+type gqlRepository struct {
+	Repository
+	Issues struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+}
+
+// toRepository flattens the GraphQL connections
+func (r gqlRepository) toRepository() Repository {
+	repo := r.Repository
+	repo.OpenIssuesCount = r.Issues.TotalCount
+	return repo
+}
+
+// queryRepositories fetches one page of repositories owned by owner
+func queryRepositories(r graphQLRunner, owner, cursor string) ([]Repository, PageInfo, error) {
+	var data struct {
+		RepositoryOwner *struct {
+			Repositories struct {
+				gqlPage
+				Nodes []gqlRepository `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"repositoryOwner"`
+	}
+	vars := pageVariables(map[string]interface{}{"owner": owner}, cursor)
+	if err := r.graphql(repositoriesQuery, vars, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.RepositoryOwner == nil {
+		return nil, PageInfo{}, fmt.Errorf("owner not found: %s", owner)
+	}
+
+	conn := data.RepositoryOwner.Repositories
+	repos := make([]Repository, len(conn.Nodes))
+	for i, node := range conn.Nodes {
+		repos[i] = node.toRepository()
+	}
+	return repos, conn.toPageInfo(), nil
+}
+
+// restPage parses a REST page cursor ("" is the first page)
+func restPage(cursor string) int {
+	page, err := strconv.Atoi(cursor)
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+// apiWorkflowRun is the REST representation of a workflow run
+type apiWorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSha    string    `json:"head_sha"`
+	RunNumber  int       `json:"run_number"`
+	CreatedAt  time.Time `json:"created_at"`
+	HTMLURL    string    `json:"html_url"`
+}
+
+// toWorkflowRun converts a REST run to our WorkflowRun structure
+func (r apiWorkflowRun) toWorkflowRun() WorkflowRun {
+	return WorkflowRun{
+		DatabaseId: r.ID,
+		Name:       r.Name,
+		Status:     r.Status,
+		Conclusion: r.Conclusion,
+		HeadBranch: r.HeadBranch,
+		HeadSha:    r.HeadSha,
+		RunNumber:  r.RunNumber,
+		CreatedAt:  r.CreatedAt,
+		URL:        r.HTMLURL,
+	}
+}
+
+// queryWorkflowRuns fetches one page of workflow runs via REST
+func queryWorkflowRuns(r restGetter, repo, cursor string) ([]WorkflowRun, PageInfo, error) {
+	page := restPage(cursor)
+
+	var data struct {
+		TotalCount   int              `json:"total_count"`
+		WorkflowRuns []apiWorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/actions/runs?per_page=%d&page=%d", repo, pageSize, page)
+	if err := r.restGet(path, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	runs := make([]WorkflowRun, len(data.WorkflowRuns))
+	for i, run := range data.WorkflowRuns {
+		runs[i] = run.toWorkflowRun()
+	}
+
+	info := PageInfo{TotalCount: data.TotalCount}
+	if page*pageSize < data.TotalCount {
+		info.HasNextPage = true
+		info.EndCursor = strconv.Itoa(page + 1)
+	}
+	return runs, info, nil
+}
+
+// apiGist is the REST representation of a gist
+type apiGist struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	Files       map[string]struct {
+		Filename string `json:"filename"`
+		Type     string `json:"type"`
+		Language string `json:"language"`
+		RawURL   string `json:"raw_url"`
+		Size     int    `json:"size"`
+	} `json:"files"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	HTMLURL   string `json:"html_url"`
+}
+
+// toGist transforms a REST gist to our Gist structure
+func (g apiGist) toGist() Gist {
+	// Convert files map to array
+	files := make([]GistFile, 0, len(g.Files))
+	for filename := range g.Files {
+		files = append(files, GistFile{Filename: filename})
+	}
+
+	// Parse timestamps
+	createdAt, _ := time.Parse(time.RFC3339, g.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, g.UpdatedAt)
+
+	return Gist{
+		ID:          g.ID,
+		Description: g.Description,
+		Public:      g.Public,
+		Files:       files,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		URL:         g.HTMLURL,
+	}
+}
+
+// queryGists fetches one page of the authenticated user's gists via REST
+// The gists API does not report a total, so TotalCount is -1
+func queryGists(r restGetter, cursor string) ([]Gist, PageInfo, error) {
+	page := restPage(cursor)
+
+	var apiGists []apiGist
+	path := fmt.Sprintf("/gists?per_page=%d&page=%d", pageSize, page)
+	if err := r.restGet(path, &apiGists); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	gists := make([]Gist, len(apiGists))
+	for i, g := range apiGists {
+		gists[i] = g.toGist()
+	}
+
+	info := PageInfo{TotalCount: -1}
+	if len(apiGists) == pageSize {
+		info.HasNextPage = true
+		info.EndCursor = strconv.Itoa(page + 1)
+	}
+	return gists, info, nil
+}
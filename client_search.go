@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// client_search.go - Shared Search Queries
+// Purpose: GitHub-wide issue, PR, repository and code search, and the saved
+// queries of custom tabs, shared by the gh and api backends. Issues, PRs and
+// repositories are searched through GraphQL; code search and run filters
+// only exist in the REST API.
+// When to extend: Add search types here and a searchKind for them in view_search.go
+
+// searchLimit is the number of results requested per kind of search
+const searchLimit = 30
+
+// textMatchMediaType asks the REST search API for the fragments that matched
+const textMatchMediaType = "application/vnd.github.text-match+json"
+
+const searchIssuesQuery = `query($query: String!, $first: Int!, $cursor: String) {
+  search(query: $query, type: ISSUE, first: $first, after: $cursor) {
+    issueCount
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      __typename
+      ... on Issue {
+        number title state createdAt updatedAt url
+        author { login }
+        labels(first: 20) { nodes { name color } }
+        assignees(first: 10) { nodes { login } }
+        milestone { number title }
+        repository { nameWithOwner }
+      }
+      ... on PullRequest {
+        number title state createdAt updatedAt url
+        author { login }
+        labels(first: 20) { nodes { name color } }
+        assignees(first: 10) { nodes { login } }
+        milestone { number title }
+        repository { nameWithOwner }
+        headRefName baseRefName isDraft reviewDecision mergeable
+      }
+    }
+  }
+}`
+
+// gqlSearchIssue is an issue or PR node of a search
+type gqlSearchIssue struct {
+	gqlIssue
+	Typename   string `json:"__typename"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+
+	// Pull requests only
+	HeadRefName    string `json:"headRefName"`
+	BaseRefName    string `json:"baseRefName"`
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision"`
+	Mergeable      string `json:"mergeable"`
+}
+
+// toSearchIssue converts the node, keeping the PR fields for pull requests
+func (n gqlSearchIssue) toSearchIssue() SearchIssue {
+	result := SearchIssue{Issue: n.toIssue(), Repo: n.Repository.NameWithOwner}
+	if n.Typename == "PullRequest" {
+		result.PullRequest = &PullRequest{
+			Number:         n.Number,
+			Title:          n.Title,
+			State:          n.State,
+			Author:         n.Author,
+			CreatedAt:      n.CreatedAt,
+			UpdatedAt:      n.UpdatedAt,
+			HeadRefName:    n.HeadRefName,
+			BaseRefName:    n.BaseRefName,
+			IsDraft:        n.IsDraft,
+			ReviewDecision: n.ReviewDecision,
+			Mergeable:      n.Mergeable,
+			URL:            n.URL,
+		}
+	}
+	return result
+}
+
+// searchIssues searches issues and PRs
+func searchIssues(r graphQLRunner, query string) ([]SearchIssue, int, error) {
+	results, page, err := searchIssuesPage(r, query, searchLimit, "")
+	return results, page.TotalCount, err
+}
+
+// searchIssuesPage fetches the first results of an issue and PR search
+// after cursor
+func searchIssuesPage(r graphQLRunner, query string, first int, cursor string) ([]SearchIssue, PageInfo, error) {
+	var data struct {
+		Search struct {
+			IssueCount int `json:"issueCount"`
+			PageInfo   struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []gqlSearchIssue `json:"nodes"`
+		} `json:"search"`
+	}
+	vars := map[string]interface{}{"query": query, "first": first}
+	if cursor != "" {
+		vars["cursor"] = cursor
+	}
+	if err := r.graphql(searchIssuesQuery, vars, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	results := make([]SearchIssue, 0, len(data.Search.Nodes))
+	for _, node := range data.Search.Nodes {
+		if node.Typename != "Issue" && node.Typename != "PullRequest" {
+			continue
+		}
+		results = append(results, node.toSearchIssue())
+	}
+	page := PageInfo{
+		EndCursor:   data.Search.PageInfo.EndCursor,
+		HasNextPage: data.Search.PageInfo.HasNextPage,
+		TotalCount:  data.Search.IssueCount,
+	}
+	return results, page, nil
+}
+
+// queryPullRequestsMatching fetches one page of the PRs of repo matching a
+// search query
+func queryPullRequestsMatching(r graphQLRunner, repo, query, cursor string) ([]PullRequest, PageInfo, error) {
+	results, page, err := searchIssuesPage(r, fmt.Sprintf("repo:%s is:pr %s", repo, query), pageSize, cursor)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	prs := make([]PullRequest, 0, len(results))
+	for _, result := range results {
+		if result.PullRequest != nil {
+			prs = append(prs, *result.PullRequest)
+		}
+	}
+	return prs, page, nil
+}
+
+// queryIssuesMatching fetches one page of the issues of repo matching a
+// search query
+func queryIssuesMatching(r graphQLRunner, repo, query, cursor string) ([]Issue, PageInfo, error) {
+	results, page, err := searchIssuesPage(r, fmt.Sprintf("repo:%s is:issue %s", repo, query), pageSize, cursor)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	issues := make([]Issue, 0, len(results))
+	for _, result := range results {
+		if result.PullRequest == nil {
+			issues = append(issues, result.Issue)
+		}
+	}
+	return issues, page, nil
+}
+
+// runQualifiers maps the qualifiers of a runs query to the parameters of
+// the REST run list; workflow: picks the workflow's own list instead
+var runQualifiers = map[string]string{
+	"branch":  "branch",
+	"actor":   "actor",
+	"event":   "event",
+	"status":  "status",
+	"created": "created",
+	"sha":     "head_sha",
+}
+
+// parseRunsQuery splits a runs query, e.g. "workflow:ci.yml branch:main
+// status:failure", into REST parameters and the workflow it is limited to
+func parseRunsQuery(query string) (url.Values, string, error) {
+	params := url.Values{}
+	workflow := ""
+	for _, token := range strings.Fields(query) {
+		key, value, ok := strings.Cut(token, ":")
+		switch {
+		case !ok || value == "":
+			return nil, "", fmt.Errorf("runs queries take qualifiers like branch:main, not %q", token)
+		case key == "workflow":
+			workflow = value
+		case runQualifiers[key] != "":
+			params.Set(runQualifiers[key], value)
+		default:
+			return nil, "", fmt.Errorf("unknown runs qualifier %s: (workflow, branch, actor, event, status, created, sha)", key)
+		}
+	}
+	return params, workflow, nil
+}
+
+// queryWorkflowRunsMatching fetches one page of the runs of repo matching
+// a runs query; actor:@me is the authenticated user
+func queryWorkflowRunsMatching(r restGetter, repo, query, cursor string) ([]WorkflowRun, PageInfo, error) {
+	params, workflow, err := parseRunsQuery(query)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if params.Get("actor") == "@me" {
+		var user struct {
+			Login string `json:"login"`
+		}
+		if err := r.restGet("/user", &user); err != nil {
+			return nil, PageInfo{}, err
+		}
+		params.Set("actor", user.Login)
+	}
+	page := restPage(cursor)
+	params.Set("per_page", strconv.Itoa(pageSize))
+	params.Set("page", strconv.Itoa(page))
+
+	path := fmt.Sprintf("/repos/%s/actions/runs?%s", repo, params.Encode())
+	if workflow != "" {
+		path = fmt.Sprintf("/repos/%s/actions/workflows/%s/runs?%s", repo, url.PathEscape(workflow), params.Encode())
+	}
+	var data struct {
+		TotalCount   int              `json:"total_count"`
+		WorkflowRuns []apiWorkflowRun `json:"workflow_runs"`
+	}
+	if err := r.restGet(path, &data); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	runs := make([]WorkflowRun, len(data.WorkflowRuns))
+	for i, run := range data.WorkflowRuns {
+		runs[i] = run.toWorkflowRun()
+	}
+	info := PageInfo{TotalCount: data.TotalCount}
+	if page*pageSize < data.TotalCount {
+		info.HasNextPage = true
+		info.EndCursor = strconv.Itoa(page + 1)
+	}
+	return runs, info, nil
+}
+
+const searchRepositoriesQuery = `query($query: String!, $first: Int!) {
+  search(query: $query, type: REPOSITORY, first: $first) {
+    repositoryCount
+    nodes {
+      ... on Repository {
+        name nameWithOwner description stargazerCount forkCount visibility url
+        primaryLanguage { name }
+        issues(states: OPEN) { totalCount }
+      }
+    }
+  }
+}`
+
+// searchRepositories searches repositories
+func searchRepositories(r graphQLRunner, query string) ([]Repository, int, error) {
+	var data struct {
+		Search struct {
+			RepositoryCount int             `json:"repositoryCount"`
+			Nodes           []gqlRepository `json:"nodes"`
+		} `json:"search"`
+	}
+	vars := map[string]interface{}{"query": query, "first": searchLimit}
+	if err := r.graphql(searchRepositoriesQuery, vars, &data); err != nil {
+		return nil, 0, err
+	}
+
+	repos := make([]Repository, len(data.Search.Nodes))
+	for i, node := range data.Search.Nodes {
+		repos[i] = node.toRepository()
+	}
+	return repos, data.Search.RepositoryCount, nil
+}
+
+// codeSearchPath is the REST path of a code search; ask for it with
+// textMatchMediaType and decode the response with decodeCodeSearch
+func codeSearchPath(query string) string {
+	return fmt.Sprintf("/search/code?per_page=%d&q=%s", searchLimit, url.QueryEscape(query))
+}
+
+// decodeCodeSearch decodes a code search response with text matches
+func decodeCodeSearch(data []byte) ([]CodeResult, int, error) {
+	var response struct {
+		TotalCount int `json:"total_count"`
+		Items      []struct {
+			Path       string `json:"path"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+			TextMatches []struct {
+				Fragment string `json:"fragment"`
+				Matches  []struct {
+					Text    string `json:"text"`
+					Indices []int  `json:"indices"`
+				} `json:"matches"`
+			} `json:"text_matches"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, 0, fmt.Errorf("parse error: %w", err)
+	}
+
+	results := make([]CodeResult, len(response.Items))
+	for i, item := range response.Items {
+		result := CodeResult{Repo: item.Repository.FullName, Path: item.Path, URL: item.HTMLURL}
+		for _, textMatch := range item.TextMatches {
+			fragment := CodeFragment{Text: textMatch.Fragment}
+			for _, match := range textMatch.Matches {
+				if span, ok := fragmentSpan(textMatch.Fragment, match.Text, match.Indices); ok {
+					fragment.Matches = append(fragment.Matches, span)
+				}
+			}
+			result.Fragments = append(result.Fragments, fragment)
+		}
+		results[i] = result
+	}
+	return results, response.TotalCount, nil
+}
+
+// fragmentSpan finds a match in a fragment. The API's indices count
+// characters rather than bytes, so they are checked against the matched
+// text and, failing that, the text is looked up instead.
+func fragmentSpan(fragment, text string, indices []int) ([2]int, bool) {
+	if len(indices) == 2 {
+		runes := []rune(fragment)
+		if indices[0] >= 0 && indices[0] <= indices[1] && indices[1] <= len(runes) &&
+			string(runes[indices[0]:indices[1]]) == text {
+			start := len(string(runes[:indices[0]]))
+			return [2]int{start, start + len(text)}, true
+		}
+	}
+	if i := strings.Index(fragment, text); i >= 0 && text != "" {
+		return [2]int{i, i + len(text)}, true
+	}
+	return [2]int{}, false
+}
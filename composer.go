@@ -0,0 +1,200 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// composer.go - Issue and Comment Composer Support
+// Purpose: Read issue templates and hand the body being composed to the
+// user's editor. The composer dialog itself lives in view_composer.go.
+// When to extend: Add support for new issue form elements in issueFormBody
+
+// issueTemplateDir is where GitHub looks for issue templates
+const issueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// composerEditedMsg carries the body back from the user's editor
+type composerEditedMsg struct {
+	body string
+	err  error
+}
+
+// editComposerBody suspends the TUI and opens body in $VISUAL or $EDITOR
+func editComposerBody(body string) tea.Cmd {
+	f, err := os.CreateTemp("", "gh-tui-*.md")
+	if err == nil {
+		_, err = f.WriteString(body)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		return func() tea.Msg { return composerEditedMsg{err: err} }
+	}
+
+	path := f.Name()
+	return tea.Sequence(
+		tea.ClearScreen,
+		tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+			defer os.Remove(path)
+			if err != nil {
+				return composerEditedMsg{err: err}
+			}
+			data, err := os.ReadFile(path)
+			return composerEditedMsg{body: strings.TrimRight(string(data), "\n"), err: err}
+		}),
+	)
+}
+
+// parseIssueTemplates turns the files of the template directory into
+// templates, sorted by name. Markdown templates and issue forms are both
+// supported; forms are flattened to a markdown body with one section per
+// field. Files that are neither, like config.yml, are skipped.
+func parseIssueTemplates(files map[string]string) []IssueTemplate {
+	var templates []IssueTemplate
+	for name, content := range files {
+		ext := strings.ToLower(filepath.Ext(name))
+		base := strings.TrimSuffix(name, filepath.Ext(name))
+
+		var template IssueTemplate
+		var ok bool
+		switch {
+		case ext == ".md":
+			template, ok = parseMarkdownTemplate(content)
+		case (ext == ".yml" || ext == ".yaml") && base != "config":
+			template, ok = parseIssueForm(content)
+		}
+		if !ok {
+			continue
+		}
+		if template.Name == "" {
+			template.Name = base
+		}
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+	return templates
+}
+
+// parseMarkdownTemplate reads a markdown template and its front matter
+func parseMarkdownTemplate(content string) (IssueTemplate, bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return IssueTemplate{Body: strings.TrimSpace(content)}, true
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return IssueTemplate{}, false
+	}
+	front, body := content[4:4+end], content[4+end+4:]
+	// The closing marker may be followed by more dashes or spaces
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(front), &doc); err != nil || len(doc.Content) == 0 {
+		return IssueTemplate{}, false
+	}
+	meta := doc.Content[0]
+	return IssueTemplate{
+		Name:      scalarValue(mappingValue(meta, "name")),
+		About:     scalarValue(mappingValue(meta, "about")),
+		Title:     scalarValue(mappingValue(meta, "title")),
+		Body:      strings.TrimSpace(body),
+		Labels:    yamlStrings(mappingValue(meta, "labels")),
+		Assignees: yamlStrings(mappingValue(meta, "assignees")),
+	}, true
+}
+
+// parseIssueForm reads a YAML issue form
+func parseIssueForm(content string) (IssueTemplate, bool) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil || len(doc.Content) == 0 {
+		return IssueTemplate{}, false
+	}
+	form := doc.Content[0]
+	body := mappingValue(form, "body")
+	if body == nil || body.Kind != yaml.SequenceNode {
+		return IssueTemplate{}, false
+	}
+	return IssueTemplate{
+		Name:      scalarValue(mappingValue(form, "name")),
+		About:     scalarValue(mappingValue(form, "description")),
+		Title:     scalarValue(mappingValue(form, "title")),
+		Body:      issueFormBody(body),
+		Labels:    yamlStrings(mappingValue(form, "labels")),
+		Assignees: yamlStrings(mappingValue(form, "assignees")),
+	}, true
+}
+
+// issueFormBody renders the elements of an issue form as markdown to fill in
+func issueFormBody(elements *yaml.Node) string {
+	var sections []string
+	for _, element := range elements.Content {
+		attributes := mappingValue(element, "attributes")
+		label := scalarValue(mappingValue(attributes, "label"))
+		value := strings.TrimSpace(scalarValue(mappingValue(attributes, "value")))
+
+		switch scalarValue(mappingValue(element, "type")) {
+		case "markdown":
+			if value != "" {
+				sections = append(sections, value)
+			}
+		case "textarea", "input":
+			sections = append(sections, strings.TrimSpace("### "+label+"\n\n"+value))
+		case "dropdown":
+			options := yamlStrings(mappingValue(attributes, "options"))
+			sections = append(sections, "### "+label+"\n\n<!-- One of: "+strings.Join(options, ", ")+" -->")
+		case "checkboxes":
+			section := "### " + label + "\n"
+			if options := mappingValue(attributes, "options"); options != nil {
+				for _, option := range options.Content {
+					section += "\n- [ ] " + scalarValue(mappingValue(option, "label"))
+				}
+			}
+			sections = append(sections, section)
+		}
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// scalarValue returns the value of a scalar node, "" for anything else
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// yamlStrings reads a list given either as a YAML sequence or as a
+// comma-separated string, the two forms templates use for labels
+func yamlStrings(node *yaml.Node) []string {
+	var values []string
+	switch {
+	case node == nil:
+	case node.Kind == yaml.SequenceNode:
+		for _, item := range node.Content {
+			if value := strings.TrimSpace(item.Value); value != "" {
+				values = append(values, value)
+			}
+		}
+	case node.Kind == yaml.ScalarNode:
+		for _, item := range strings.Split(node.Value, ",") {
+			if value := strings.TrimSpace(item); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
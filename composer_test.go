@@ -0,0 +1,79 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIssueTemplates(t *testing.T) {
+	files := map[string]string{
+		"config.yml": "blank_issues_enabled: false\n",
+		"bug_report.md": "---\r\n" +
+			"name: Bug report\r\n" +
+			"about: Something is broken\r\n" +
+			"title: '[bug] '\r\n" +
+			"labels: bug, triage\r\n" +
+			"assignees:\r\n" +
+			"  - octocat\r\n" +
+			"---\r\n" +
+			"\r\n" +
+			"**Describe the bug**\r\n",
+		"plain.md":  "Just a body\n",
+		"broken.md": "---\nname: Never closed\n",
+		"feature.yml": `name: Feature request
+description: Suggest an idea
+labels: [enhancement]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for the idea!
+  - type: textarea
+    attributes:
+      label: What should it do?
+  - type: input
+    attributes:
+      label: Version
+      value: "1.0"
+  - type: dropdown
+    attributes:
+      label: Area
+      options: [UI, Backend]
+  - type: checkboxes
+    attributes:
+      label: Checks
+      options:
+        - label: I searched existing issues
+`,
+		"not_a_form.yaml": "name: Missing body\n",
+	}
+
+	want := []IssueTemplate{
+		{
+			Name:      "Bug report",
+			About:     "Something is broken",
+			Title:     "[bug] ",
+			Body:      "**Describe the bug**",
+			Labels:    []string{"bug", "triage"},
+			Assignees: []string{"octocat"},
+		},
+		{
+			Name:  "Feature request",
+			About: "Suggest an idea",
+			Body: "Thanks for the idea!\n\n" +
+				"### What should it do?\n\n" +
+				"### Version\n\n1.0\n\n" +
+				"### Area\n\n<!-- One of: UI, Backend -->\n\n" +
+				"### Checks\n\n- [ ] I searched existing issues",
+			Labels: []string{"enhancement"},
+		},
+		{
+			Name: "plain",
+			Body: "Just a body",
+		},
+	}
+
+	got := parseIssueTemplates(files)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %#v\nwant %#v", got, want)
+	}
+}
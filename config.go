@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -11,27 +12,35 @@ import (
 // Purpose: Load and manage application configuration
 // When to extend: Add new configuration options or loaders
 
-// loadConfig loads configuration from file or returns defaults
-func loadConfig() Config {
+// loadConfig loads configuration from path, or from the default location
+// when path is empty. A missing or invalid default config falls back to
+// defaults; an explicitly given path must load.
+func loadConfig(path string) (Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = getConfigPath()
+	}
+
 	// Try to load from config file
-	cfg, err := loadConfigFile()
+	cfg, err := loadConfigFile(path)
 	if err != nil {
+		if explicit {
+			return Config{}, fmt.Errorf("failed to load config %s: %w", path, err)
+		}
 		// Return default config if load fails
-		return getDefaultConfig()
+		cfg = getDefaultConfig()
 	}
 
-	// Apply custom theme if specified
-	if cfg.Theme == "custom" && cfg.CustomTheme.Primary != "" {
-		applyTheme(cfg.CustomTheme)
+	// Apply the configured theme
+	if err := applyNamedTheme(cfg.Theme, cfg.CustomTheme); err != nil && explicit {
+		return Config{}, err
 	}
 
-	return cfg
+	return cfg, nil
 }
 
 // loadConfigFile loads configuration from ~/.config/gh-tui/config.yaml
-func loadConfigFile() (Config, error) {
-	configPath := getConfigPath()
-
+func loadConfigFile(configPath string) (Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return Config{}, err
@@ -45,6 +54,9 @@ func loadConfigFile() (Config, error) {
 
 	// Validate and apply defaults for missing fields
 	cfg = applyDefaults(cfg)
+	if err := validateTabs(cfg.Tabs); err != nil {
+		return Config{}, err
+	}
 
 	return cfg, nil
 }
@@ -90,6 +102,14 @@ func getDefaultConfig() Config {
 			Level:   "info",
 			File:    getDefaultLogPath(),
 		},
+		GitHub: GitHubConfig{
+			Backend:     "gh",
+			BaseURL:     defaultAPIBaseURL,
+			FixturesDir: filepath.Join("testdata", "fixtures"),
+		},
+		Git: GitConfig{
+			WorktreeDir: "..",
+		},
 	}
 }
 
@@ -129,6 +149,22 @@ func applyDefaults(cfg Config) Config {
 		cfg.Logging.Level = defaults.Logging.Level
 	}
 
+	// GitHub backend defaults
+	if cfg.GitHub.Backend == "" {
+		cfg.GitHub.Backend = defaults.GitHub.Backend
+	}
+	if cfg.GitHub.BaseURL == "" {
+		cfg.GitHub.BaseURL = defaults.GitHub.BaseURL
+	}
+	if cfg.GitHub.FixturesDir == "" {
+		cfg.GitHub.FixturesDir = defaults.GitHub.FixturesDir
+	}
+
+	// Git defaults
+	if cfg.Git.WorktreeDir == "" {
+		cfg.Git.WorktreeDir = defaults.Git.WorktreeDir
+	}
+
 	return cfg
 }
 
@@ -216,8 +252,8 @@ ui:
 
 # Performance
 performance:
-  lazy_loading: true
-  cache_size: 100
+  lazyloading: true      # only fetch the active tab at startup
+  cachesize: 100         # cached lists kept on disk (-1 disables the cache)
   async_operations: true
 
 # Logging
@@ -225,6 +261,16 @@ logging:
   enabled: false
   level: "info"  # debug, info, warn, error
   file: "~/.local/share/gh-tui/debug.log"
+
+# GitHub backend
+github:
+  backend: "gh"  # gh (GitHub CLI), api (REST/GraphQL, no gh needed), fake (JSON fixtures, no network)
+  baseurl: "https://api.github.com"  # api backend only; GitHub Enterprise: https://HOST/api/v3
+  fixturesdir: "testdata/fixtures"
+
+# Local git
+git:
+  worktreedir: ".."  # PR worktrees (c in the PR tab) go here as <repo>-pr-<number>; relative to the clone
 `
 
 	// Create directory if it doesn't exist
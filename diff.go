@@ -0,0 +1,283 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// diff.go - Unified Diff Parsing
+// Purpose: Parse `git diff` style output into files, hunks and numbered lines
+// Used by the diff viewer (view_diff.go) and review comments on diff lines
+
+// DiffLineKind identifies a line in a hunk
+type DiffLineKind int
+
+const (
+	DiffContext DiffLineKind = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// DiffLine is a single line of a hunk with its old and new line numbers
+// OldLine is 0 for added lines, NewLine is 0 for removed lines
+type DiffLine struct {
+	Kind    DiffLineKind
+	Content string
+	OldLine int
+	NewLine int
+}
+
+// DiffHunk is a contiguous block of changes
+type DiffHunk struct {
+	Header   string // Section heading after the @@ range, e.g. a function name
+	OldStart int
+	NewStart int
+	Lines    []DiffLine
+}
+
+// DiffFile is the diff of a single file
+type DiffFile struct {
+	OldPath   string
+	NewPath   string
+	Status    string // added, deleted, renamed, modified
+	Binary    bool
+	Hunks     []DiffHunk
+	Additions int
+	Deletions int
+}
+
+// Path returns the file's current path (the old path for deletions)
+func (f DiffFile) Path() string {
+	if f.NewPath == "" {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// parseUnifiedDiff parses the output of `git diff` / `gh pr diff`
+func parseUnifiedDiff(text string) []DiffFile {
+	var files []DiffFile
+	var file *DiffFile
+	var hunk *DiffHunk
+	oldLine, newLine := 0, 0
+	oldLeft, newLeft := 0, 0 // Lines still expected in the current hunk
+
+	flushHunk := func() {
+		if file != nil && hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if file != nil {
+			files = append(files, *file)
+		}
+		file = nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushFile()
+			file = &DiffFile{Status: "modified"}
+			if a, b, ok := splitDiffGitPaths(strings.TrimPrefix(line, "diff --git ")); ok {
+				file.OldPath, file.NewPath = a, b
+			}
+			continue
+		case file == nil:
+			continue
+		}
+
+		// Hunk bodies are delimited by the line counts in their header, so
+		// removed lines such as "--- x" aren't mistaken for file headers.
+		// A line that can't be part of a body ends the hunk regardless.
+		if hunk == nil || oldLeft <= 0 && newLeft <= 0 || !isHunkBodyLine(line) {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				flushHunk()
+				m := hunkHeaderRe.FindStringSubmatch(line)
+				if m == nil {
+					continue
+				}
+				oldLine, _ = strconv.Atoi(m[1])
+				newLine, _ = strconv.Atoi(m[3])
+				oldLeft, newLeft = hunkCount(m[2]), hunkCount(m[4])
+				hunk = &DiffHunk{OldStart: oldLine, NewStart: newLine, Header: m[5]}
+			case strings.HasPrefix(line, "--- "):
+				if path := strings.TrimPrefix(line, "--- "); path == "/dev/null" {
+					file.Status = "added"
+					file.OldPath = ""
+				} else {
+					file.OldPath = strings.TrimPrefix(path, "a/")
+				}
+			case strings.HasPrefix(line, "+++ "):
+				if path := strings.TrimPrefix(line, "+++ "); path == "/dev/null" {
+					file.Status = "deleted"
+					file.NewPath = ""
+				} else {
+					file.NewPath = strings.TrimPrefix(path, "b/")
+				}
+			case strings.HasPrefix(line, "new file mode"):
+				file.Status = "added"
+			case strings.HasPrefix(line, "deleted file mode"):
+				file.Status = "deleted"
+			case strings.HasPrefix(line, "rename from "):
+				file.Status = "renamed"
+				file.OldPath = strings.TrimPrefix(line, "rename from ")
+			case strings.HasPrefix(line, "rename to "):
+				file.NewPath = strings.TrimPrefix(line, "rename to ")
+			case strings.HasPrefix(line, "Binary files "):
+				file.Binary = true
+			}
+			continue
+		}
+
+		kind := byte(' ')
+		if line != "" {
+			// Some tools strip the space of empty context lines
+			kind, line = line[0], line[1:]
+		}
+
+		switch kind {
+		case '+':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffAdded, Content: line, NewLine: newLine})
+			file.Additions++
+			newLine++
+			newLeft--
+		case '-':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffRemoved, Content: line, OldLine: oldLine})
+			file.Deletions++
+			oldLine++
+			oldLeft--
+		case ' ':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffContext, Content: line, OldLine: oldLine, NewLine: newLine})
+			oldLine++
+			newLine++
+			oldLeft--
+			newLeft--
+		}
+		// "\ No newline at end of file" is ignored
+	}
+	flushFile()
+
+	return files
+}
+
+// hunkCount parses the optional line count of a hunk range (default 1)
+func hunkCount(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// isHunkBodyLine reports whether line can be part of a hunk body
+func isHunkBodyLine(line string) bool {
+	return line == "" || strings.ContainsRune(" +-\\", rune(line[0]))
+}
+
+// splitDiffGitPaths splits "a/old b/new" from a diff --git header
+func splitDiffGitPaths(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, "a/") {
+		return "", "", false
+	}
+	// Paths are the same length unless renamed; try the symmetric split first
+	if half := (len(s) - 1) / 2; len(s)%2 == 1 && s[half] == ' ' && s[2:half] == s[half+3:] {
+		return s[2:half], s[half+3:], true
+	}
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return s[2:i], s[i+3:], true
+	}
+	return "", "", false
+}
+
+// withoutWhitespaceChanges drops changes that only touch whitespace
+// Removed/added pairs that match ignoring whitespace become context lines,
+// and added or removed blank lines are hidden.
+func (f DiffFile) withoutWhitespaceChanges() DiffFile {
+	out := f
+	out.Hunks = nil
+
+	for _, hunk := range f.Hunks {
+		filtered := hunk
+		filtered.Lines = nil
+
+		for i := 0; i < len(hunk.Lines); {
+			if hunk.Lines[i].Kind == DiffContext {
+				filtered.Lines = append(filtered.Lines, hunk.Lines[i])
+				i++
+				continue
+			}
+
+			// Collect a block of removals followed by additions
+			start := i
+			for i < len(hunk.Lines) && hunk.Lines[i].Kind == DiffRemoved {
+				i++
+			}
+			mid := i
+			for i < len(hunk.Lines) && hunk.Lines[i].Kind == DiffAdded {
+				i++
+			}
+			filtered.Lines = append(filtered.Lines, collapseWhitespaceBlock(hunk.Lines[start:mid], hunk.Lines[mid:i])...)
+		}
+
+		// Keep the hunk only if something still changes
+		for _, line := range filtered.Lines {
+			if line.Kind != DiffContext {
+				out.Hunks = append(out.Hunks, filtered)
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// collapseWhitespaceBlock pairs removed and added lines of one change block
+func collapseWhitespaceBlock(removed, added []DiffLine) []DiffLine {
+	var lines []DiffLine
+
+	matched := make([]bool, len(added))
+	oldLines := make([]int, len(added))
+	for _, r := range removed {
+		if strings.TrimSpace(r.Content) == "" {
+			continue
+		}
+		found := false
+		for j, a := range added {
+			if !matched[j] && normalizeWhitespace(a.Content) == normalizeWhitespace(r.Content) {
+				matched[j] = true
+				oldLines[j] = r.OldLine
+				found = true
+				break
+			}
+		}
+		if !found {
+			lines = append(lines, r)
+		}
+	}
+
+	for j, a := range added {
+		switch {
+		case matched[j]:
+			// Only whitespace changed: show the new line as context
+			lines = append(lines, DiffLine{Kind: DiffContext, Content: a.Content, OldLine: oldLines[j], NewLine: a.NewLine})
+		case strings.TrimSpace(a.Content) != "":
+			lines = append(lines, a)
+		}
+	}
+
+	return lines
+}
+
+// normalizeWhitespace collapses all runs of whitespace to single spaces
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
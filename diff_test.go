@@ -0,0 +1,208 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []DiffFile
+	}{
+		{
+			name: "modified",
+			diff: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,3 +10,3 @@ func main() {
+ 	a := 1
+-	b := 2
++	b := 3
+ 	c := 4
+`,
+			want: []DiffFile{{
+				OldPath: "main.go", NewPath: "main.go", Status: "modified",
+				Additions: 1, Deletions: 1,
+				Hunks: []DiffHunk{{Header: "func main() {", OldStart: 10, NewStart: 10, Lines: []DiffLine{
+					{Kind: DiffContext, Content: "\ta := 1", OldLine: 10, NewLine: 10},
+					{Kind: DiffRemoved, Content: "\tb := 2", OldLine: 11},
+					{Kind: DiffAdded, Content: "\tb := 3", NewLine: 11},
+					{Kind: DiffContext, Content: "\tc := 4", OldLine: 12, NewLine: 12},
+				}}},
+			}},
+		},
+		{
+			name: "added and deleted",
+			diff: `diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+\ No newline at end of file
+`,
+			want: []DiffFile{
+				{
+					NewPath: "new.txt", Status: "added", Additions: 1,
+					Hunks: []DiffHunk{{OldStart: 0, NewStart: 1, Lines: []DiffLine{
+						{Kind: DiffAdded, Content: "hello", NewLine: 1},
+					}}},
+				},
+				{
+					OldPath: "old.txt", Status: "deleted", Deletions: 1,
+					Hunks: []DiffHunk{{OldStart: 1, NewStart: 0, Lines: []DiffLine{
+						{Kind: DiffRemoved, Content: "bye", OldLine: 1},
+					}}},
+				},
+			},
+		},
+		{
+			name: "renamed and binary",
+			diff: `diff --git a/old name.png b/new name.png
+similarity index 100%
+rename from old name.png
+rename to new name.png
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+`,
+			want: []DiffFile{
+				{OldPath: "old name.png", NewPath: "new name.png", Status: "renamed"},
+				{OldPath: "logo.png", NewPath: "logo.png", Status: "modified", Binary: true},
+			},
+		},
+		{
+			// The removed line looks like a file header but is counted
+			// as part of the hunk
+			name: "removed header lookalike",
+			diff: `diff --git a/notes.md b/notes.md
+--- a/notes.md
++++ b/notes.md
+@@ -1,2 +1,1 @@
+--- a/list
+ end
+`,
+			want: []DiffFile{{
+				OldPath: "notes.md", NewPath: "notes.md", Status: "modified", Deletions: 1,
+				Hunks: []DiffHunk{{OldStart: 1, NewStart: 1, Lines: []DiffLine{
+					{Kind: DiffRemoved, Content: "-- a/list", OldLine: 1},
+					{Kind: DiffContext, Content: "end", OldLine: 2, NewLine: 1},
+				}}},
+			}},
+		},
+		{
+			name: "no diff",
+			diff: "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseUnifiedDiff(tt.diff)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutWhitespaceChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []string // "@@" starting each hunk kept, then its lines as "<marker><content>"
+	}{
+		{
+			name: "reindented",
+			diff: `@@ -1,3 +1,3 @@
+ if x {
+-  y()
++	y()
+ }
+`,
+			want: nil,
+		},
+		{
+			name: "reindented next to a change",
+			diff: `@@ -1,4 +1,4 @@
+ if x {
+-  y()
+-  z()
++	y()
++	w()
+ }
+`,
+			want: []string{"@@", " if x {", "-  z()", " \ty()", "+\tw()", " }"},
+		},
+		{
+			name: "blank lines",
+			diff: `@@ -1,2 +1,4 @@
+ a
++
+ b
++c
+`,
+			want: []string{"@@", " a", " b", "+c"},
+		},
+		{
+			name: "only the hunks that still change",
+			diff: `@@ -1,1 +1,1 @@
+-a  b
++a b
+@@ -9,1 +9,1 @@
+-x
++y
+`,
+			want: []string{"@@", "-x", "+y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := parseUnifiedDiff("diff --git a/f b/f\n--- a/f\n+++ b/f\n" + tt.diff)
+			if len(files) != 1 {
+				t.Fatalf("parsed %d files", len(files))
+			}
+			var got []string
+			for _, hunk := range files[0].withoutWhitespaceChanges().Hunks {
+				got = append(got, "@@")
+				for _, line := range hunk.Lines {
+					got = append(got, string(" +-"[line.Kind])+line.Content)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutWhitespaceChangesKeepsLineNumbers(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/f b/f", "--- a/f", "+++ b/f",
+		"@@ -5,2 +5,3 @@",
+		"-  a",
+		"+\ta",
+		"+b",
+		" c",
+	}, "\n")
+	lines := parseUnifiedDiff(diff)[0].withoutWhitespaceChanges().Hunks[0].Lines
+	want := []DiffLine{
+		{Kind: DiffContext, Content: "\ta", OldLine: 5, NewLine: 5},
+		{Kind: DiffAdded, Content: "b", NewLine: 6},
+		{Kind: DiffContext, Content: "c", OldLine: 6, NewLine: 7},
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got  %+v\nwant %+v", lines, want)
+	}
+}
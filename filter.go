@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filter.go - List Filter Query Language
+// Purpose: The / prompt of the list views. A query narrows the loaded items
+// as it is typed, e.g. `author:alice label:bug is:draft -state:closed "free text"`:
+//   - key:value matches an item whose key field equals value (any case);
+//     key:a,b matches either value
+//   - bare words and "quoted phrases" must appear in the item's text
+//   - a leading - negates a term
+//
+// Each view says which keys it knows and what an item offers for them.
+// When to extend: Add keys to a view's filterItem function and its key list
+
+// filterItem is what a query is matched against
+type filterItem struct {
+	fields map[string][]string // Qualifier values by key, e.g. "label": {"bug", "ui"}
+	text   []string            // Searched by free text terms
+}
+
+// filterTerm is one term of a query
+type filterTerm struct {
+	key    string   // "" for free text
+	values []string // Lower case; any of them may match
+	negate bool
+}
+
+// filterableView is a view with a filter prompt; while the prompt is open
+// the model sends it every key, see handleKeyPress
+type filterableView interface {
+	View
+	Filtering() bool
+}
+
+// ListFilter holds the filter of a list view
+type ListFilter struct {
+	input   textinput.Model
+	editing bool
+	before  string // Query when the prompt opened, restored by Esc
+	keys    []string
+	terms   []filterTerm
+	err     error // Unknown qualifier; the term is ignored
+}
+
+// NewListFilter creates a filter over items offering keys
+func NewListFilter(keys ...string) *ListFilter {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = strings.Join(keys, ": ") + ":"
+	input.CharLimit = 200
+	return &ListFilter{input: input, keys: keys}
+}
+
+// Editing reports whether the prompt is open
+func (f *ListFilter) Editing() bool {
+	return f.editing
+}
+
+// Active reports whether the filter narrows the list
+func (f *ListFilter) Active() bool {
+	return len(f.terms) > 0
+}
+
+// Start opens the prompt with the current query
+func (f *ListFilter) Start() tea.Cmd {
+	f.editing = true
+	f.before = f.input.Value()
+	f.input.CursorEnd()
+	return f.input.Focus()
+}
+
+// HandleKey edits the query; Enter keeps it and Esc goes back to the query
+// from before the prompt opened. The view re-filters after every key.
+func (f *ListFilter) HandleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		f.editing = false
+		f.input.Blur()
+		return nil
+	case "esc":
+		f.editing = false
+		f.input.Blur()
+		f.set(f.before)
+		return nil
+	}
+	input, cmd := f.input.Update(msg)
+	f.input = input
+	f.parse()
+	return cmd
+}
+
+// Clear drops the query
+func (f *ListFilter) Clear() {
+	f.editing = false
+	f.input.Blur()
+	f.set("")
+}
+
+// Set replaces the query, as when a view is opened already filtered
+func (f *ListFilter) Set(query string) {
+	f.editing = false
+	f.input.Blur()
+	f.set(query)
+}
+
+// set replaces the query
+func (f *ListFilter) set(query string) {
+	f.input.SetValue(query)
+	f.parse()
+}
+
+// parse splits the query into terms
+func (f *ListFilter) parse() {
+	f.terms = nil
+	f.err = nil
+	for _, token := range splitQuery(f.input.Value()) {
+		var term filterTerm
+		if len(token) > 1 && token[0] == '-' {
+			term.negate = true
+			token = token[1:]
+		}
+		if key, value, ok := strings.Cut(token, ":"); ok && key != "" && !strings.HasPrefix(key, `"`) {
+			term.key = strings.ToLower(key)
+			token = value
+			if !f.knows(term.key) {
+				f.err = fmt.Errorf("unknown filter %s:", term.key)
+				continue
+			}
+		}
+		for _, value := range strings.Split(strings.ToLower(strings.ReplaceAll(token, `"`, "")), ",") {
+			if value != "" {
+				term.values = append(term.values, value)
+			}
+		}
+		if len(term.values) > 0 {
+			f.terms = append(f.terms, term)
+		}
+	}
+}
+
+// knows reports whether key is one of the filter's keys
+func (f *ListFilter) knows(key string) bool {
+	for _, known := range f.keys {
+		if known == key {
+			return true
+		}
+	}
+	return false
+}
+
+// splitQuery splits a query at spaces outside double quotes
+func splitQuery(query string) []string {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// Match reports whether item matches every term of the query
+func (f *ListFilter) Match(item filterItem) bool {
+	for _, term := range f.terms {
+		if term.matches(item) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether item has any of the term's values
+func (t filterTerm) matches(item filterItem) bool {
+	for _, value := range t.values {
+		if t.key == "" {
+			for _, text := range item.text {
+				if strings.Contains(strings.ToLower(text), value) {
+					return true
+				}
+			}
+			continue
+		}
+		for _, field := range item.fields[t.key] {
+			if strings.ToLower(field) == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Highlight picks out the free text the query looks for in text, rendering
+// the rest in style; text without matches is returned as is
+func (f *ListFilter) Highlight(text string, style lipgloss.Style) string {
+	var values []string
+	for _, term := range f.terms {
+		if !term.negate && term.key == "" {
+			values = append(values, term.values...)
+		}
+	}
+	return highlightSpans(text, findSpans(text, values), style)
+}
+
+// findSpans returns the byte ranges of every occurrence of the lower case
+// terms in text, in any case
+func findSpans(text string, terms []string) [][2]int {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lower-casing changed the byte offsets; match as is
+		lower = text
+	}
+	var spans [][2]int
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		for offset := 0; ; {
+			i := strings.Index(lower[offset:], term)
+			if i < 0 {
+				break
+			}
+			spans = append(spans, [2]int{offset + i, offset + i + len(term)})
+			offset += i + len(term)
+		}
+	}
+	return spans
+}
+
+// highlightSpans picks out the byte ranges spans of text, rendering the rest
+// in style; spans may overlap and come in any order. Text without spans is
+// returned as is.
+func highlightSpans(text string, spans [][2]int, style lipgloss.Style) string {
+	if len(spans) == 0 {
+		return text
+	}
+
+	spans = append([][2]int(nil), spans...)
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	// Each piece is rendered on its own, so padding would be repeated
+	plain := lipgloss.NewStyle().Inherit(style)
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		end := min(span[1], len(text))
+		if end <= last {
+			continue
+		}
+		start := max(span[0], last)
+		b.WriteString(plain.Render(text[last:start]))
+		b.WriteString(filterMatchStyle.Inherit(plain).Render(text[start:end]))
+		last = end
+	}
+	b.WriteString(plain.Render(text[last:]))
+	return b.String()
+}
+
+// Title describes the active filter for a list title, e.g. " • /label:bug"
+func (f *ListFilter) Title() string {
+	if !f.Active() && !f.editing {
+		return ""
+	}
+	return " • /" + strings.TrimSpace(f.input.Value())
+}
+
+// Line renders the line under a list title: the prompt while it is open, or
+// a reminder of how to clear an active filter
+func (f *ListFilter) Line(width int) string {
+	switch {
+	case f.editing:
+		problem := ""
+		if f.err != nil {
+			problem = "  " + f.err.Error()
+		}
+		f.input.Width = max(10, width-6-len(problem))
+		return f.input.View() + statusFailureStyle.Render(problem)
+	case f.err != nil:
+		return statusFailureStyle.Render(" " + truncateString(f.err.Error()+" (ignored)", width-2))
+	case f.Active():
+		return helpStyle.Render(" /: Edit filter • Esc: Clear filter")
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package main
+
+import "testing"
+
+func TestListFilterMatch(t *testing.T) {
+	item := filterItem{
+		fields: map[string][]string{
+			"author": {"Monalisa"},
+			"label":  {"bug", "ui"},
+			"is":     {"OPEN", "issue"},
+		},
+		text: []string{"Status bar truncates long messages", "#57"},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"author:monalisa", true},
+		{"AUTHOR:MONALISA", true},
+		{"author:mona", false},
+		{"label:ui", true},
+		{"label:docs", false},
+		{"label:docs,bug", true},
+		{"-label:bug", false},
+		{"-label:docs", true},
+		{"is:open label:bug", true},
+		{"is:open label:docs", false},
+		{"status", true},
+		{"STATUS BAR", true},
+		{`"bar truncates"`, true},
+		{`"truncates bar"`, false},
+		{"-status", false},
+		{"#57", true},
+		{"nomatch", false},
+		{"milestone:v1", false},
+		{"-milestone:v1", true},
+		{"unknown:value", true},
+		{"unknown:value nomatch", false},
+		{"author:", true},
+		{"-", false},
+	}
+	for _, tt := range tests {
+		f := NewListFilter("author", "label", "is", "milestone")
+		f.Set(tt.query)
+		if got := f.Match(item); got != tt.want {
+			t.Errorf("%q: match = %t, want %t", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestListFilterUnknownKey(t *testing.T) {
+	f := NewListFilter("author")
+	f.Set("label:bug author:octocat")
+	if f.err == nil {
+		t.Error("no error for label:")
+	}
+	if len(f.terms) != 1 || f.terms[0].key != "author" {
+		t.Errorf("terms = %+v", f.terms)
+	}
+
+	f.Set("author:octocat")
+	if f.err != nil {
+		t.Errorf("error %v kept after fixing the query", f.err)
+	}
+}
+
+func TestSplitQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"  a   b ", []string{"a", "b"}},
+		{`label:bug "two words" -x`, []string{"label:bug", `"two words"`, "-x"}},
+		{`title:"a b" c`, []string{`title:"a b"`, "c"}},
+		{`"unclosed quote`, []string{`"unclosed quote`}},
+	}
+	for _, tt := range tests {
+		got := splitQuery(tt.query)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %q, want %q", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
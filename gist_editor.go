@@ -24,11 +24,10 @@ func downloadGistToTemp(gistID string, filename string) (string, error) {
 	// Create temp file with gist ID in the name for easy identification
 	tempFile := filepath.Join(os.TempDir(), fmt.Sprintf("gh-tui-gist-%s-%s", gistID, filename))
 
-	// Use gh CLI to download gist content
-	cmd := exec.Command("gh", "gist", "view", gistID, "--filename", filename)
-	output, err := cmd.CombinedOutput()
+	// Download gist content through the active backend
+	output, err := ghClient.GistFileContent(gistID, filename)
 	if err != nil {
-		return "", fmt.Errorf("failed to download gist %s (file: %s): %s - %w", gistID, filename, string(output), err)
+		return "", fmt.Errorf("failed to download gist %s (file: %s): %w", gistID, filename, err)
 	}
 
 	// Write to temp file
@@ -153,9 +152,7 @@ func createNewGistInMicro() tea.Cmd {
 // uploadGistChanges uploads modified gist content back to GitHub
 func uploadGistChanges(gistID string, tempFilePath string) tea.Cmd {
 	return func() tea.Msg {
-		// Use gh CLI to edit the gist
-		cmd := exec.Command("gh", "gist", "edit", gistID, "-a", tempFilePath)
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.UpdateGistFile(gistID, tempFilePath); err != nil {
 			return errMsg{err: fmt.Errorf("failed to upload gist changes: %w", err)}
 		}
 
@@ -170,19 +167,7 @@ func uploadGistChanges(gistID string, tempFilePath string) tea.Cmd {
 // createGistFromFile creates a new gist from a temp file
 func createGistFromFile(tempFilePath string, description string, public bool) tea.Cmd {
 	return func() tea.Msg {
-		// Prepare gh CLI command
-		args := []string{"gist", "create", tempFilePath}
-
-		if description != "" {
-			args = append(args, "-d", description)
-		}
-
-		if public {
-			args = append(args, "-p")
-		}
-
-		cmd := exec.Command("gh", args...)
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.CreateGist(tempFilePath, description, public); err != nil {
 			os.Remove(tempFilePath)
 			return errMsg{err: fmt.Errorf("failed to create gist: %w", err)}
 		}
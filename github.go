@@ -1,226 +1,667 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
+	"sort"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// github.go - GitHub CLI Integration
-// Purpose: All GitHub CLI commands and data fetching
-// Uses `gh` CLI as backend (must be authenticated)
+// github.go - GitHub Data Fetching
+// Purpose: tea.Cmds that load GitHub data through the active GitHubClient
+// The backend itself lives in client_*.go (gh CLI by default)
+// Each fetchX loads the first page; fetchXPage loads the page after cursor.
+// First pages are written to the on-disk cache; cachedX serves them back.
 
-// checkGitHubAuth verifies gh CLI is authenticated
+// checkGitHubAuth verifies the active backend is authenticated
 func checkGitHubAuth() error {
-	cmd := exec.Command("gh", "auth", "status")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("gh not authenticated. Run: gh auth login")
-	}
-	return nil
+	return ghClient.CheckAuth()
 }
 
-// fetchPullRequests retrieves PRs using gh CLI
+// fetchPullRequests retrieves the first page of PRs for repo (current repo if empty)
 func fetchPullRequests(repo string) tea.Cmd {
+	return fetchPullRequestsPage(repo, "")
+}
+
+// fetchPullRequestsPage retrieves the page of PRs after cursor
+func fetchPullRequestsPage(repo, cursor string) tea.Cmd {
 	return func() tea.Msg {
-		if repo == "" {
-			// Try to get current repo
-			cmd := exec.Command("gh", "repo", "view", "--json", "nameWithOwner")
-			output, err := cmd.Output()
-			if err != nil {
-				return prLoadedMsg{err: fmt.Errorf("no repo specified and not in a git repo")}
-			}
-			var repoData struct {
-				NameWithOwner string `json:"nameWithOwner"`
-			}
-			if err := json.Unmarshal(output, &repoData); err != nil {
-				return prLoadedMsg{err: err}
-			}
-			repo = repoData.NameWithOwner
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return prLoadedMsg{repo: repo, appended: appended, err: err}
+		}
+
+		prs, page, err := ghClient.ListPullRequests(repo, cursor)
+		if err != nil {
+			return prLoadedMsg{repo: repo, appended: appended, err: err}
+		}
+
+		if !appended {
+			dataCache.Store("prs", repo, prs, page, "")
 		}
 
-		cmd := exec.Command("gh", "pr", "list",
-			"--repo", repo,
-			"--json", "number,title,state,author,createdAt,updatedAt,headRefName,baseRefName,isDraft,reviewDecision,mergeable,url",
-			"--limit", "100")
+		return prLoadedMsg{repo: repo, prs: prs, page: page, appended: appended}
+	}
+}
 
-		output, err := cmd.Output()
+// fetchPRDetail retrieves body, checks, reviews and files of PR number
+func fetchPRDetail(repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
 		if err != nil {
-			return prLoadedMsg{err: fmt.Errorf("gh pr list failed: %w", err)}
+			return prDetailLoadedMsg{repo: repo, number: number, err: err}
 		}
 
-		var prs []PullRequest
-		if err := json.Unmarshal(output, &prs); err != nil {
-			return prLoadedMsg{err: fmt.Errorf("parse error: %w", err)}
+		detail, err := ghClient.PullRequestDetail(repo, number)
+		return prDetailLoadedMsg{repo: repo, number: number, detail: detail, err: err}
+	}
+}
+
+// fetchIssueDetail retrieves the body and timeline of issue number
+func fetchIssueDetail(repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return issueDetailLoadedMsg{repo: repo, number: number, err: err}
 		}
 
-		return prLoadedMsg{prs: prs}
+		detail, err := ghClient.IssueDetail(repo, number)
+		return issueDetailLoadedMsg{repo: repo, number: number, detail: detail, err: err}
 	}
 }
 
-// fetchIssues retrieves issues using gh CLI
-func fetchIssues(repo string) tea.Cmd {
+// fetchPRDiff retrieves the unified diff of PR number
+func fetchPRDiff(repo string, number int) tea.Cmd {
 	return func() tea.Msg {
-		if repo == "" {
-			cmd := exec.Command("gh", "repo", "view", "--json", "nameWithOwner")
-			output, err := cmd.Output()
-			if err != nil {
-				return issuesLoadedMsg{err: fmt.Errorf("no repo specified and not in a git repo")}
-			}
-			var repoData struct {
-				NameWithOwner string `json:"nameWithOwner"`
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return prDiffLoadedMsg{repo: repo, number: number, err: err}
+		}
+
+		diff, err := ghClient.PullRequestDiff(repo, number)
+		return prDiffLoadedMsg{repo: repo, number: number, diff: diff, err: err}
+	}
+}
+
+// fetchMergeInfo retrieves what the merge dialog needs to know about PR number
+func fetchMergeInfo(repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return mergeInfoLoadedMsg{repo: repo, number: number, err: err}
+		}
+
+		info, err := ghClient.MergeInfo(repo, number)
+		return mergeInfoLoadedMsg{repo: repo, number: number, info: info, err: err}
+	}
+}
+
+// fetchRunDetail retrieves the jobs and current status of a workflow run
+// for the view of tab view
+func fetchRunDetail(view ViewType, repo string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return runDetailLoadedMsg{view: view, runID: runID, err: err}
+		}
+
+		detail, err := ghClient.WorkflowRunDetail(repo, runID)
+		return runDetailLoadedMsg{view: view, runID: runID, detail: detail, err: err}
+	}
+}
+
+// fetchWorkflowList retrieves the workflows of repo
+func fetchWorkflowList(repo string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return workflowListLoadedMsg{err: err}
+		}
+
+		workflows, err := ghClient.ListWorkflows(repo)
+		return workflowListLoadedMsg{workflows: workflows, err: err}
+	}
+}
+
+// fetchArtifacts retrieves the artifacts of a workflow run
+func fetchArtifacts(repo string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return artifactsLoadedMsg{runID: runID, err: err}
+		}
+
+		artifacts, err := ghClient.ListArtifacts(repo, runID)
+		return artifactsLoadedMsg{runID: runID, artifacts: artifacts, err: err}
+	}
+}
+
+// fetchDispatchForm reads the workflow_dispatch inputs of workflow at ref
+// (the default branch when ref is ""), with the environments to choose from
+func fetchDispatchForm(repo string, workflow Workflow, ref string) tea.Cmd {
+	return func() tea.Msg {
+		msg := dispatchFormLoadedMsg{workflowID: workflow.ID, ref: ref}
+		repo, err := resolveRepo(repo)
+		if err == nil && ref == "" {
+			msg.ref, err = ghClient.DefaultBranch(repo)
+		}
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		data, err := ghClient.RepoFile(repo, workflow.Path, msg.ref)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.inputs, msg.dispatchable, msg.err = parseDispatchInputs(data)
+
+		for _, input := range msg.inputs {
+			if input.Type == "environment" {
+				// Without the list, environment inputs fall back to free text
+				msg.environments, _ = ghClient.ListEnvironments(repo)
+				break
 			}
-			if err := json.Unmarshal(output, &repoData); err != nil {
-				return issuesLoadedMsg{err: err}
+		}
+		return msg
+	}
+}
+
+// fetchComposerOptions retrieves the labels, assignees, milestones and issue
+// templates of repo in parallel; whatever fails is left out
+func fetchComposerOptions(repo string) tea.Cmd {
+	return func() tea.Msg {
+		msg := composerOptionsLoadedMsg{repo: repo}
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+		)
+		load := func(f func() error) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := f(); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}()
+		}
+		load(func() (err error) { msg.labels, err = ghClient.ListLabels(repo); return })
+		load(func() (err error) { msg.assignees, err = ghClient.ListAssignableUsers(repo); return })
+		load(func() (err error) { msg.milestones, err = ghClient.ListMilestones(repo); return })
+		load(func() error {
+			files, err := ghClient.RepoDir(repo, issueTemplateDir)
+			msg.templates = parseIssueTemplates(files)
+			return err
+		})
+		wg.Wait()
+
+		if len(errs) > 0 {
+			msg.err = errs[0]
+		}
+		return msg
+	}
+}
+
+// fetchIssueOptions retrieves the choices of one issue field picker
+func fetchIssueOptions(repo string, field issueField) tea.Cmd {
+	return func() tea.Msg {
+		msg := issueOptionsLoadedMsg{repo: repo, field: field}
+		resolved, err := resolveRepo(repo)
+		if err == nil {
+			switch field {
+			case issueFieldLabels:
+				msg.labels, err = ghClient.ListLabels(resolved)
+			case issueFieldAssignees:
+				msg.assignees, err = ghClient.ListAssignableUsers(resolved)
+			case issueFieldMilestone:
+				msg.milestones, err = ghClient.ListMilestones(resolved)
 			}
-			repo = repoData.NameWithOwner
 		}
+		msg.err = err
+		return msg
+	}
+}
 
-		cmd := exec.Command("gh", "issue", "list",
-			"--repo", repo,
-			"--json", "number,title,state,author,createdAt,updatedAt,labels,assignees,milestone,url",
-			"--limit", "100")
+// pollRun asks the view of tab view for another look at a run after delay
+func pollRun(view ViewType, runID int64, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return runPollMsg{view: view, runID: runID}
+	})
+}
+
+// fetchIssues retrieves the first page of issues for repo (current repo if empty)
+func fetchIssues(repo string) tea.Cmd {
+	return fetchIssuesPage(repo, "")
+}
 
-		output, err := cmd.Output()
+// fetchIssuesPage retrieves the page of issues after cursor
+func fetchIssuesPage(repo, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
 		if err != nil {
-			return issuesLoadedMsg{err: fmt.Errorf("gh issue list failed: %w", err)}
+			return issuesLoadedMsg{repo: repo, appended: appended, err: err}
 		}
 
-		var issues []Issue
-		if err := json.Unmarshal(output, &issues); err != nil {
-			return issuesLoadedMsg{err: fmt.Errorf("parse error: %w", err)}
+		issues, page, err := ghClient.ListIssues(repo, cursor)
+		if err != nil {
+			return issuesLoadedMsg{repo: repo, appended: appended, err: err}
+		}
+
+		if !appended {
+			dataCache.Store("issues", repo, issues, page, "")
 		}
 
-		return issuesLoadedMsg{issues: issues}
+		return issuesLoadedMsg{repo: repo, issues: issues, page: page, appended: appended}
 	}
 }
 
-// fetchRepositories retrieves repos using gh CLI
+// fetchRepositories retrieves the first page of repos for owner (current user if empty)
 func fetchRepositories(owner string) tea.Cmd {
+	return fetchRepositoriesPage(owner, "")
+}
+
+// fetchRepositoriesPage retrieves the page of repos after cursor
+func fetchRepositoriesPage(owner, cursor string) tea.Cmd {
 	return func() tea.Msg {
+		appended := cursor != ""
 		if owner == "" {
-			// Get current user
-			cmd := exec.Command("gh", "api", "user", "--jq", ".login")
-			output, err := cmd.Output()
+			login, err := ghClient.CurrentUser()
 			if err != nil {
-				return reposLoadedMsg{err: fmt.Errorf("failed to get current user: %w", err)}
+				return reposLoadedMsg{appended: appended, err: fmt.Errorf("failed to get current user: %w", err)}
 			}
-			owner = string(output[:len(output)-1]) // Remove newline
+			owner = login
 		}
 
-		cmd := exec.Command("gh", "repo", "list", owner,
-			"--json", "name,nameWithOwner,description,stargazerCount,forkCount,primaryLanguage,visibility,url",
-			"--limit", "100")
-
-		output, err := cmd.Output()
+		repos, page, err := ghClient.ListRepositories(owner, cursor)
 		if err != nil {
-			return reposLoadedMsg{err: fmt.Errorf("gh repo list failed: %w", err)}
+			return reposLoadedMsg{appended: appended, err: err}
 		}
 
-		var repos []Repository
-		if err := json.Unmarshal(output, &repos); err != nil {
-			return reposLoadedMsg{err: fmt.Errorf("parse error: %w", err)}
+		if !appended {
+			dataCache.Store("repos", owner, repos, page, "")
 		}
 
-		return reposLoadedMsg{repos: repos}
+		return reposLoadedMsg{repos: repos, page: page, appended: appended}
 	}
 }
 
-// fetchWorkflowRuns retrieves workflow runs using gh CLI
+// fetchWorkflowRuns retrieves the first page of workflow runs for repo (current repo if empty)
 func fetchWorkflowRuns(repo string) tea.Cmd {
+	return fetchWorkflowRunsPage(repo, "")
+}
+
+// fetchWorkflowRunsPage retrieves the page of workflow runs after cursor
+func fetchWorkflowRunsPage(repo, cursor string) tea.Cmd {
 	return func() tea.Msg {
-		if repo == "" {
-			cmd := exec.Command("gh", "repo", "view", "--json", "nameWithOwner")
-			output, err := cmd.Output()
-			if err != nil {
-				return workflowsLoadedMsg{err: fmt.Errorf("no repo specified and not in a git repo")}
-			}
-			var repoData struct {
-				NameWithOwner string `json:"nameWithOwner"`
-			}
-			if err := json.Unmarshal(output, &repoData); err != nil {
-				return workflowsLoadedMsg{err: err}
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return workflowsLoadedMsg{repo: repo, appended: appended, err: err}
+		}
+
+		var runs []WorkflowRun
+		var page PageInfo
+		if lister, ok := ghClient.(conditionalLister); ok && !appended {
+			page, err = listIfChanged("runs", repo, &runs, func(etag string) (PageInfo, string, error) {
+				var page PageInfo
+				var err error
+				runs, page, etag, err = lister.ListWorkflowRunsIfChanged(repo, etag)
+				return page, etag, err
+			})
+		} else {
+			runs, page, err = ghClient.ListWorkflowRuns(repo, cursor)
+			if err == nil && !appended {
+				dataCache.Store("runs", repo, runs, page, "")
 			}
-			repo = repoData.NameWithOwner
+		}
+		if err != nil {
+			return workflowsLoadedMsg{repo: repo, appended: appended, err: err}
 		}
 
-		cmd := exec.Command("gh", "run", "list",
-			"--repo", repo,
-			"--json", "databaseId,name,status,conclusion,headBranch,headSha,number,createdAt,url",
-			"--limit", "50")
+		return workflowsLoadedMsg{repo: repo, runs: runs, page: page, appended: appended}
+	}
+}
 
-		output, err := cmd.Output()
+// fetchPullRequestsMatching retrieves the page after cursor of repo's PRs
+// matching query, for custom tab
+func fetchPullRequestsMatching(tab ViewType, repo, query, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
 		if err != nil {
-			return workflowsLoadedMsg{err: fmt.Errorf("gh run list failed: %w", err)}
+			return prLoadedMsg{repo: repo, tab: tab, appended: appended, err: err}
 		}
 
-		var runs []WorkflowRun
-		if err := json.Unmarshal(output, &runs); err != nil {
-			return workflowsLoadedMsg{err: fmt.Errorf("parse error: %w", err)}
+		prs, page, err := ghClient.ListPullRequestsMatching(repo, query, cursor)
+		return prLoadedMsg{repo: repo, tab: tab, prs: prs, page: page, appended: appended, err: err}
+	}
+}
+
+// fetchIssuesMatching retrieves the page after cursor of repo's issues
+// matching query, for custom tab
+func fetchIssuesMatching(tab ViewType, repo, query, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return issuesLoadedMsg{repo: repo, tab: tab, appended: appended, err: err}
 		}
 
-		return workflowsLoadedMsg{runs: runs}
+		issues, page, err := ghClient.ListIssuesMatching(repo, query, cursor)
+		return issuesLoadedMsg{repo: repo, tab: tab, issues: issues, page: page, appended: appended, err: err}
 	}
 }
 
-// fetchGists retrieves gists using gh CLI API
+// fetchWorkflowRunsMatching retrieves the page after cursor of repo's runs
+// matching query, for custom tab
+func fetchWorkflowRunsMatching(tab ViewType, repo, query, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		appended := cursor != ""
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return workflowsLoadedMsg{repo: repo, tab: tab, appended: appended, err: err}
+		}
+
+		runs, page, err := ghClient.ListWorkflowRunsMatching(repo, query, cursor)
+		return workflowsLoadedMsg{repo: repo, tab: tab, runs: runs, page: page, appended: appended, err: err}
+	}
+}
+
+// fetchGists retrieves the first page of the authenticated user's gists
 func fetchGists() tea.Cmd {
+	return fetchGistsPage("")
+}
+
+// fetchGistsPage retrieves the page of gists after cursor
+func fetchGistsPage(cursor string) tea.Cmd {
 	return func() tea.Msg {
-		// gh gist list doesn't support --json, so we use the API directly
-		cmd := exec.Command("gh", "api", "/gists", "--paginate", "-q", ".[0:50]")
+		appended := cursor != ""
+		var gists []Gist
+		var page PageInfo
+		var err error
+		if lister, ok := ghClient.(conditionalLister); ok && !appended {
+			page, err = listIfChanged("gists", "@me", &gists, func(etag string) (PageInfo, string, error) {
+				var page PageInfo
+				var err error
+				gists, page, etag, err = lister.ListGistsIfChanged(etag)
+				return page, etag, err
+			})
+		} else {
+			gists, page, err = ghClient.ListGists(cursor)
+			if err == nil && !appended {
+				dataCache.Store("gists", "@me", gists, page, "")
+			}
+		}
+		if err != nil {
+			return gistsLoadedMsg{appended: appended, err: err}
+		}
 
-		output, err := cmd.Output()
+		return gistsLoadedMsg{gists: gists, page: page, appended: appended}
+	}
+}
+
+// listIfChanged fetches the first page of the list of kind in scope with
+// list, sending the ETag it was cached with, and caches what it gets. When
+// the list hasn't changed the cached page is decoded into dest instead, and
+// counts as fetched afresh.
+func listIfChanged(kind, scope string, dest interface{}, list func(etag string) (PageInfo, string, error)) (PageInfo, error) {
+	page, etag, err := list(dataCache.ETag(kind, scope))
+	if errors.Is(err, errNotModified) {
+		if entry, ok := dataCache.Load(kind, scope, dest); ok {
+			dataCache.Store(kind, scope, dest, entry.Page, entry.ETag)
+			return entry.Page, nil
+		}
+		// Evicted since its ETag was read
+		page, etag, err = list("")
+	}
+	if err != nil {
+		return PageInfo{}, err
+	}
+	dataCache.Store(kind, scope, dest, page, etag)
+	return page, nil
+}
+
+// fetchNotifications retrieves the page of the notifications inbox after
+// cursor; the inbox changes too often to be cached
+func fetchNotifications(participating bool, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		appended := cursor != ""
+		notifications, page, err := ghClient.ListNotifications(participating, cursor)
+		return notificationsLoadedMsg{participating: participating, notifications: notifications, page: page, appended: appended, err: err}
+	}
+}
+
+// cachedPullRequests serves the cached first page of PRs for repo, if any
+func cachedPullRequests(repo string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
 		if err != nil {
-			return gistsLoadedMsg{err: fmt.Errorf("gh api /gists failed: %w", err)}
+			return nil
+		}
+
+		var prs []PullRequest
+		entry, ok := dataCache.Load("prs", repo, &prs)
+		if !ok {
+			return nil
 		}
+		return prLoadedMsg{repo: repo, prs: prs, page: entry.Page, cachedAt: entry.FetchedAt}
+	}
+}
 
-		// Parse the API response which has different field names
-		var apiGists []struct {
-			ID          string `json:"id"`
-			Description string `json:"description"`
-			Public      bool   `json:"public"`
-			Files       map[string]struct {
-				Filename string `json:"filename"`
-				Type     string `json:"type"`
-				Language string `json:"language"`
-				RawURL   string `json:"raw_url"`
-				Size     int    `json:"size"`
-			} `json:"files"`
-			CreatedAt string `json:"created_at"`
-			UpdatedAt string `json:"updated_at"`
-			HTMLURL   string `json:"html_url"`
+// cachedIssues serves the cached first page of issues for repo, if any
+func cachedIssues(repo string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return nil
 		}
 
-		if err := json.Unmarshal(output, &apiGists); err != nil {
-			return gistsLoadedMsg{err: fmt.Errorf("parse error: %w", err)}
+		var issues []Issue
+		entry, ok := dataCache.Load("issues", repo, &issues)
+		if !ok {
+			return nil
 		}
+		return issuesLoadedMsg{repo: repo, issues: issues, page: entry.Page, cachedAt: entry.FetchedAt}
+	}
+}
 
-		// Transform to our Gist structure
-		gists := make([]Gist, len(apiGists))
-		for i, apiGist := range apiGists {
-			// Convert files map to array
-			files := make([]GistFile, 0, len(apiGist.Files))
-			for filename := range apiGist.Files {
-				files = append(files, GistFile{Filename: filename})
+// cachedRepositories serves the cached first page of repos for owner, if any
+func cachedRepositories(owner string) tea.Cmd {
+	return func() tea.Msg {
+		if owner == "" {
+			login, err := ghClient.CurrentUser()
+			if err != nil {
+				return nil
 			}
+			owner = login
+		}
+
+		var repos []Repository
+		entry, ok := dataCache.Load("repos", owner, &repos)
+		if !ok {
+			return nil
+		}
+		return reposLoadedMsg{repos: repos, page: entry.Page, cachedAt: entry.FetchedAt}
+	}
+}
+
+// cachedWorkflowRuns serves the cached first page of workflow runs for repo, if any
+func cachedWorkflowRuns(repo string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return nil
+		}
+
+		var runs []WorkflowRun
+		entry, ok := dataCache.Load("runs", repo, &runs)
+		if !ok {
+			return nil
+		}
+		return workflowsLoadedMsg{repo: repo, runs: runs, page: entry.Page, cachedAt: entry.FetchedAt}
+	}
+}
+
+// cachedGists serves the cached first page of gists, if any
+func cachedGists() tea.Cmd {
+	return func() tea.Msg {
+		var gists []Gist
+		entry, ok := dataCache.Load("gists", "@me", &gists)
+		if !ok {
+			return nil
+		}
+		return gistsLoadedMsg{gists: gists, page: entry.Page, cachedAt: entry.FetchedAt}
+	}
+}
+
+// searchGitHub runs one kind of search for query
+func searchGitHub(query string, kind searchKind) tea.Cmd {
+	return func() tea.Msg {
+		msg := searchResultsMsg{query: query, kind: kind}
+		switch kind {
+		case searchKindIssues:
+			msg.issues, msg.total, msg.err = ghClient.SearchIssues(query)
+		case searchKindRepos:
+			msg.repos, msg.total, msg.err = ghClient.SearchRepositories(query)
+		case searchKindCode:
+			msg.code, msg.total, msg.err = ghClient.SearchCode(query)
+		}
+		return msg
+	}
+}
 
-			// Parse timestamps
-			createdAt, _ := time.Parse(time.RFC3339, apiGist.CreatedAt)
-			updatedAt, _ := time.Parse(time.RFC3339, apiGist.UpdatedAt)
-
-			gists[i] = Gist{
-				ID:          apiGist.ID,
-				Description: apiGist.Description,
-				Public:      apiGist.Public,
-				Files:       files,
-				CreatedAt:   createdAt,
-				UpdatedAt:   updatedAt,
-				URL:         apiGist.HTMLURL,
+// fetchDashboardTile loads one tile of the dashboard. Each tile is its own
+// command so the tiles load side by side and one failing leaves the rest.
+func fetchDashboardTile(tile dashboardTile) tea.Cmd {
+	return func() tea.Msg {
+		if tile == tileFailedRuns {
+			return fetchFailedRuns()
+		}
+
+		// The searches run side by side, each into its own slot
+		searches := dashboardQueries[tile].searches
+		results := make([][]SearchIssue, len(searches))
+		totals := make([]int, len(searches))
+		errs := make([]error, len(searches))
+		var wg sync.WaitGroup
+		for i, query := range searches {
+			i, query := i, query
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], totals[i], errs[i] = ghClient.SearchIssues(query)
+			}()
+		}
+		wg.Wait()
+
+		// A PR matching more than one search is listed once
+		msg := dashboardTileMsg{tile: tile}
+		seen := make(map[string]bool)
+		for i, issues := range results {
+			if errs[i] != nil {
+				return dashboardTileMsg{tile: tile, err: errs[i]}
+			}
+			msg.total += totals[i]
+			for _, issue := range issues {
+				key := fmt.Sprintf("%s#%d", issue.Repo, issue.Number)
+				if seen[key] {
+					msg.total--
+					continue
+				}
+				seen[key] = true
+				msg.issues = append(msg.issues, issue)
 			}
 		}
+		sort.SliceStable(msg.issues, func(i, j int) bool {
+			return msg.issues[i].UpdatedAt.After(msg.issues[j].UpdatedAt)
+		})
+		return msg
+	}
+}
 
-		return gistsLoadedMsg{gists: gists}
+// fetchFailedRuns looks for runs that failed over the last dashboardRunDays
+// in the repos the user pushed to most recently, a repo at a time side by side.
+// Repos that can't be read are noted; the tile fails only if none can be.
+func fetchFailedRuns() dashboardTileMsg {
+	msg := dashboardTileMsg{tile: tileFailedRuns}
+	login, err := ghClient.CurrentUser()
+	if err != nil {
+		msg.err = fmt.Errorf("failed to get current user: %w", err)
+		return msg
+	}
+	repos, _, err := ghClient.ListRepositories(login, "")
+	if err != nil {
+		msg.err = err
+		return msg
+	}
+	if len(repos) > dashboardRunRepos {
+		repos = repos[:dashboardRunRepos]
+	}
+
+	query := "status:failure created:>=" + time.Now().AddDate(0, 0, -dashboardRunDays).Format("2006-01-02")
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed int
+		last   error
+	)
+	for _, repo := range repos {
+		repo := repo.NameWithOwner
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runs, page, err := ghClient.ListWorkflowRunsMatching(repo, query, "")
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				last = err
+				return
+			}
+			for _, run := range runs {
+				msg.runs = append(msg.runs, dashboardRun{repo: repo, run: run})
+			}
+			if page.TotalCount >= 0 {
+				msg.total += page.TotalCount
+			} else {
+				msg.total += len(runs)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failed > 0 && failed == len(repos) {
+		msg.err = last
+		return msg
+	}
+	if failed > 0 {
+		msg.note = fmt.Sprintf("%d of %d repos couldn't be read", failed, len(repos))
+	}
+	sort.Slice(msg.runs, func(i, j int) bool {
+		return msg.runs[i].run.CreatedAt.After(msg.runs[j].run.CreatedAt)
+	})
+	return msg
+}
+
+// fetchCodeFile retrieves a file found by a code search, on the default branch
+func fetchCodeFile(repo, path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := ghClient.RepoFile(repo, path, "")
+		return codeFileLoadedMsg{repo: repo, path: path, content: string(data), err: err}
 	}
 }
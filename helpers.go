@@ -2,12 +2,11 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // helpers.go - Utility Functions
@@ -85,6 +84,14 @@ func formatLabels(labels []Label) string {
 	return strings.Join(names, ", ")
 }
 
+// formatLabel renders a label name in the label's own color, when known
+func formatLabel(label Label) string {
+	if label.Color == "" {
+		return highlightStyle.Render(label.Name)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#" + label.Color)).Render(label.Name)
+}
+
 // formatLanguage formats a language name, handling nil cases
 func formatLanguage(lang *Language) string {
 	if lang == nil {
@@ -121,9 +128,103 @@ func formatStatus(status, conclusion string) string {
 			return conclusion
 		}
 	}
+	switch status {
+	case "in_progress":
+		return "● In progress"
+	case "cancelling":
+		// Not a GitHub status: a cancel was requested and the run hasn't stopped yet
+		return "⊘ Cancelling"
+	case "queued", "waiting", "pending", "requested":
+		return "○ " + strings.ToUpper(status[:1]) + status[1:]
+	}
 	return status
 }
 
+// formatStatusIcon returns the icon of a run, job or step status
+func formatStatusIcon(status, conclusion string) string {
+	switch status {
+	case "completed":
+		switch conclusion {
+		case "success":
+			return "✓"
+		case "failure", "timed_out", "startup_failure":
+			return "✗"
+		case "cancelled":
+			return "⊘"
+		case "skipped", "neutral":
+			return "⊙"
+		default:
+			return "?"
+		}
+	case "in_progress":
+		return "●"
+	case "cancelling":
+		return "⊘"
+	default:
+		// queued, waiting, pending, requested
+		return "○"
+	}
+}
+
+// pluralize formats a count with its noun, e.g. "1 error", "3 errors"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// formatDuration formats a duration compactly: 45s, 3m12s, 1h05m
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// formatElapsed formats the time between start and end, or until now
+// when end is zero; "" when start is zero (not started yet)
+func formatElapsed(start, end time.Time) string {
+	if start.IsZero() {
+		return ""
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return formatDuration(end.Sub(start))
+}
+
+// formatBytes formats a size in bytes compactly: 512 B, 1.8 KB, 15.0 MB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// renderProgressBar draws a bar width cells wide, filled done/total of the way
+func renderProgressBar(done, total int64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := width
+	if total > 0 && done < total {
+		filled = int(done * int64(width) / total)
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
 // formatPRState formats PR state with an icon
 func formatPRState(state string, isDraft bool) string {
 	if isDraft {
@@ -153,6 +254,39 @@ func formatIssueState(state string) string {
 	}
 }
 
+// formatCheckState formats a PR check or rollup state with a colored icon
+func formatCheckState(state string) string {
+	switch state {
+	case "SUCCESS", "NEUTRAL":
+		return statusSuccessStyle.Render("✓")
+	case "FAILURE", "ERROR", "TIMED_OUT", "STARTUP_FAILURE", "ACTION_REQUIRED":
+		return statusFailureStyle.Render("✗")
+	case "CANCELLED", "SKIPPED", "STALE":
+		return dimmedStyle.Render("⊘")
+	default:
+		// PENDING, EXPECTED, QUEUED, IN_PROGRESS, ...
+		return statusPendingStyle.Render("●")
+	}
+}
+
+// formatReviewState formats a review state with an icon
+func formatReviewState(state string) string {
+	switch state {
+	case "APPROVED":
+		return statusSuccessStyle.Render("✓ Approved")
+	case "CHANGES_REQUESTED":
+		return statusFailureStyle.Render("✗ Changes requested")
+	case "COMMENTED":
+		return "💬 Commented"
+	case "DISMISSED":
+		return dimmedStyle.Render("Dismissed")
+	case "PENDING":
+		return statusPendingStyle.Render("● Pending")
+	default:
+		return state
+	}
+}
+
 // formatNumber formats numbers with k/M suffixes for large values
 func formatNumber(n int) string {
 	if n < 1000 {
@@ -164,6 +298,27 @@ func formatNumber(n int) string {
 	return fmt.Sprintf("%.1fM", float64(n)/1000000)
 }
 
+// loadMoreThreshold is how close to the end of a list the cursor must get
+// before the next page is requested
+const loadMoreThreshold = 5
+
+// formatListCount formats a list size for titles, e.g. "42" or "loaded 50 of 230"
+func formatListCount(loaded int, page PageInfo) string {
+	if !page.HasNextPage {
+		return fmt.Sprintf("%d", loaded)
+	}
+	if page.TotalCount < 0 {
+		return fmt.Sprintf("loaded %d of %d+", loaded, loaded)
+	}
+	return fmt.Sprintf("loaded %d of %d", loaded, page.TotalCount)
+}
+
+// needsNextPage reports whether the cursor is near enough to the end of
+// a list that the next page should be fetched
+func needsNextPage(cursor, loaded int, page PageInfo, loadingMore bool) bool {
+	return page.HasNextPage && !loadingMore && cursor >= loaded-loadMoreThreshold
+}
+
 // max returns the maximum of two integers
 func max(a, b int) int {
 	if a > b {
@@ -180,6 +335,14 @@ func min(a, b int) int {
 	return b
 }
 
+// max64 returns the larger of two int64 values
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // makeHyperlink creates a clickable terminal hyperlink using OSC 8
 // The displayed text can be truncated while the actual URL remains full
 func makeHyperlink(url string, displayText string) string {
@@ -220,52 +383,13 @@ func wrapText(text string, width int) []string {
 	return lines
 }
 
-// openInBrowser opens a GitHub item in the default browser using gh CLI
-// itemType: "pr", "issue", "repo", "run", "gist"
-// identifier: PR number, issue number, repo name, run ID, or gist ID
+// openInBrowser opens a GitHub item in the default browser
+// itemType: "pr", "issue", "repo", "run", "workflow", "gist"
+// identifier: PR number, issue number, repo name, run ID, workflow file name, or gist ID
 // repo: repository path (e.g., "owner/repo") - optional for some types
 func openInBrowser(itemType, identifier, repo string) tea.Cmd {
 	return func() tea.Msg {
-		var cmd *exec.Cmd
-
-		switch itemType {
-		case "pr":
-			// gh pr view <number> --web [--repo <repo>]
-			if repo != "" {
-				cmd = exec.Command("gh", "pr", "view", identifier, "--web", "--repo", repo)
-			} else {
-				cmd = exec.Command("gh", "pr", "view", identifier, "--web")
-			}
-
-		case "issue":
-			// gh issue view <number> --web [--repo <repo>]
-			if repo != "" {
-				cmd = exec.Command("gh", "issue", "view", identifier, "--web", "--repo", repo)
-			} else {
-				cmd = exec.Command("gh", "issue", "view", identifier, "--web")
-			}
-
-		case "repo":
-			// gh repo view <repo> --web
-			cmd = exec.Command("gh", "repo", "view", identifier, "--web")
-
-		case "run":
-			// gh run view <id> --web [--repo <repo>]
-			if repo != "" {
-				cmd = exec.Command("gh", "run", "view", identifier, "--web", "--repo", repo)
-			} else {
-				cmd = exec.Command("gh", "run", "view", identifier, "--web")
-			}
-
-		case "gist":
-			// gh gist view <id> --web
-			cmd = exec.Command("gh", "gist", "view", identifier, "--web")
-
-		default:
-			return errMsg{err: fmt.Errorf("unknown item type: %s", itemType)}
-		}
-
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.OpenInBrowser(itemType, identifier, repo); err != nil {
 			return errMsg{err: fmt.Errorf("failed to open in browser: %w", err)}
 		}
 
@@ -273,13 +397,10 @@ func openInBrowser(itemType, identifier, repo string) tea.Cmd {
 	}
 }
 
-// createNewIssue opens the browser to create a new issue
-func createNewIssue() tea.Cmd {
+// createNewIssue opens the browser to create a new issue in repo
+func createNewIssue(repo string) tea.Cmd {
 	return func() tea.Msg {
-		// gh issue create --web opens browser with new issue form
-		cmd := exec.Command("gh", "issue", "create", "--web")
-
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.CreateIssueInBrowser(repo); err != nil {
 			return errMsg{err: fmt.Errorf("failed to create issue: %w", err)}
 		}
 
@@ -291,24 +412,18 @@ func createNewIssue() tea.Cmd {
 func toggleRepoStar(repoNameWithOwner string) tea.Cmd {
 	return func() tea.Msg {
 		// First check if already starred
-		checkCmd := exec.Command("gh", "repo", "view", repoNameWithOwner, "--json", "viewerHasStarred", "-q", ".viewerHasStarred")
-		output, err := checkCmd.Output()
+		isStarred, err := ghClient.IsRepoStarred(repoNameWithOwner)
 		if err != nil {
 			return errMsg{err: fmt.Errorf("failed to check star status: %w", err)}
 		}
 
-		isStarred := strings.TrimSpace(string(output)) == "true"
-
-		var cmd *exec.Cmd
 		if isStarred {
-			// Unstar
-			cmd = exec.Command("gh", "repo", "unstar", repoNameWithOwner)
+			err = ghClient.UnstarRepo(repoNameWithOwner)
 		} else {
-			// Star
-			cmd = exec.Command("gh", "repo", "star", repoNameWithOwner)
+			err = ghClient.StarRepo(repoNameWithOwner)
 		}
 
-		if err := cmd.Run(); err != nil {
+		if err != nil {
 			return errMsg{err: fmt.Errorf("failed to toggle star: %w", err)}
 		}
 
@@ -323,10 +438,7 @@ func toggleRepoStar(repoNameWithOwner string) tea.Cmd {
 // cloneRepository clones a repository to the current directory
 func cloneRepository(repoNameWithOwner string) tea.Cmd {
 	return func() tea.Msg {
-		// gh repo clone opens in current directory
-		cmd := exec.Command("gh", "repo", "clone", repoNameWithOwner)
-
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.CloneRepository(repoNameWithOwner); err != nil {
 			return errMsg{err: fmt.Errorf("failed to clone repository: %w", err)}
 		}
 
@@ -334,30 +446,103 @@ func cloneRepository(repoNameWithOwner string) tea.Cmd {
 	}
 }
 
-// viewPRDiff shows the diff for a pull request in the pager
-func viewPRDiff(prNumber string) tea.Cmd {
+// submitPRReview submits a review of PR number with its line comments
+func submitPRReview(repo string, number int, review ReviewSubmission) tea.Cmd {
 	return func() tea.Msg {
-		// gh pr diff shows the diff in the default pager (less, more, etc.)
-		cmd := exec.Command("gh", "pr", "diff", prNumber)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-
-		if err := cmd.Run(); err != nil {
-			return errMsg{err: fmt.Errorf("failed to view diff: %w", err)}
+		repo, err := resolveRepo(repo)
+		if err == nil {
+			err = ghClient.SubmitReview(repo, number, review)
 		}
+		return reviewSubmittedMsg{repo: repo, number: number, event: review.Event, err: err}
+	}
+}
 
-		return nil
+// mergePR merges PR number, then deletes branch unless it is empty
+func mergePR(repo string, number int, req MergeRequest, branch string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err == nil {
+			err = ghClient.MergePullRequest(repo, number, req)
+		}
+		if err != nil {
+			return prMergedMsg{repo: repo, number: number, method: req.Method, err: err}
+		}
+
+		msg := prMergedMsg{repo: repo, number: number, method: req.Method}
+		if branch != "" {
+			msg.branch = branch
+			msg.branchErr = ghClient.DeleteBranch(repo, branch)
+		}
+		return msg
+	}
+}
+
+// runWorkflowAction re-runs or cancels a workflow run
+func runWorkflowAction(repo string, run WorkflowRun, action RunAction) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err == nil {
+			switch action.Kind {
+			case "cancel":
+				err = ghClient.CancelWorkflowRun(repo, run.DatabaseId)
+			default:
+				err = ghClient.RerunWorkflowRun(repo, run.DatabaseId, action.Kind == "rerun-failed", action.Debug)
+			}
+		}
+		return runActionDoneMsg{runID: run.DatabaseId, number: run.RunNumber, action: action, err: err}
+	}
+}
+
+// notificationAction marks a notification thread read or done, or
+// unsubscribes from it
+func notificationAction(thread Notification, action string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		switch action {
+		case "read":
+			err = ghClient.MarkNotificationRead(thread.ID)
+		case "done":
+			err = ghClient.MarkNotificationDone(thread.ID)
+		case "unsubscribe":
+			err = ghClient.UnsubscribeNotification(thread.ID)
+		}
+		return notificationDoneMsg{thread: thread, action: action, err: err}
+	}
+}
+
+// dispatchWorkflowRun triggers a workflow_dispatch run of workflow at ref,
+// then waits a little for the new run to show up, since GitHub doesn't
+// return it
+func dispatchWorkflowRun(repo string, workflow Workflow, ref string, inputs map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		msg := workflowDispatchedMsg{workflow: workflow, ref: ref}
+		repo, err := resolveRepo(repo)
+		if err == nil {
+			err = ghClient.DispatchWorkflow(repo, workflow.ID, ref, inputs)
+		}
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		// Allow for clock skew between here and GitHub
+		since := time.Now().Add(-30 * time.Second)
+		for attempt := 0; attempt < dispatchPollAttempts; attempt++ {
+			runs, err := ghClient.ListDispatchRuns(repo, workflow.ID, ref)
+			if err == nil && len(runs) > 0 && runs[0].CreatedAt.After(since) {
+				msg.run, msg.found = runs[0], true
+				return msg
+			}
+			time.Sleep(dispatchPollInterval)
+		}
+		return msg
 	}
 }
 
 // forkRepository forks a repository to the authenticated user's account
 func forkRepository(repoNameWithOwner string) tea.Cmd {
 	return func() tea.Msg {
-		// gh repo fork creates a fork in the authenticated user's account
-		cmd := exec.Command("gh", "repo", "fork", repoNameWithOwner, "--remote=false")
-
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.ForkRepository(repoNameWithOwner); err != nil {
 			return errMsg{err: fmt.Errorf("failed to fork repository: %w", err)}
 		}
 
@@ -365,13 +550,10 @@ func forkRepository(repoNameWithOwner string) tea.Cmd {
 	}
 }
 
-// closeIssue closes an issue
-func closeIssue(issueNumber int) tea.Cmd {
+// closeIssue closes an issue in repo
+func closeIssue(repo string, issueNumber int) tea.Cmd {
 	return func() tea.Msg {
-		// gh issue close <number> closes the issue
-		cmd := exec.Command("gh", "issue", "close", fmt.Sprintf("%d", issueNumber))
-
-		if err := cmd.Run(); err != nil {
+		if err := ghClient.CloseIssue(repo, issueNumber); err != nil {
 			return errMsg{err: fmt.Errorf("failed to close issue: %w", err)}
 		}
 
@@ -379,34 +561,78 @@ func closeIssue(issueNumber int) tea.Cmd {
 	}
 }
 
-// reopenIssue reopens a closed issue
-func reopenIssue(issueNumber int) tea.Cmd {
+// submitIssue creates issue in repo
+func submitIssue(repo string, issue NewIssue) tea.Cmd {
 	return func() tea.Msg {
-		// gh issue reopen <number> reopens the issue
-		cmd := exec.Command("gh", "issue", "reopen", fmt.Sprintf("%d", issueNumber))
+		msg := issueCreatedMsg{repo: repo}
+		resolved, err := resolveRepo(repo)
+		if err == nil {
+			msg.issue, err = ghClient.CreateIssue(resolved, issue)
+		}
+		msg.err = err
+		return msg
+	}
+}
 
-		if err := cmd.Run(); err != nil {
-			return errMsg{err: fmt.Errorf("failed to reopen issue: %w", err)}
+// postComment comments on issue or PR number in repo
+func postComment(repo string, number int, body string) tea.Cmd {
+	return func() tea.Msg {
+		msg := commentAddedMsg{repo: repo, number: number}
+		resolved, err := resolveRepo(repo)
+		if err == nil {
+			err = ghClient.AddComment(resolved, number, body)
+		}
+		msg.err = err
+		return msg
+	}
+}
+
+// editIssueFields applies edit to issue number in repo
+func editIssueFields(repo string, number int, edit IssueEdit) tea.Cmd {
+	return func() tea.Msg {
+		msg := issueEditedMsg{repo: repo, issue: Issue{Number: number}, edit: edit}
+		resolved, err := resolveRepo(repo)
+		if err == nil {
+			msg.issue, err = ghClient.EditIssue(resolved, number, edit)
 		}
+		msg.err = err
+		return msg
+	}
+}
 
-		return statusMsg{message: fmt.Sprintf("Issue #%d reopened", issueNumber)}
+// describeIssueEdit summarizes edit for the status bar, like "+bug −ui @alice"
+func describeIssueEdit(edit IssueEdit, milestone string) string {
+	var parts []string
+	for _, label := range edit.AddLabels {
+		parts = append(parts, "+"+label)
+	}
+	for _, label := range edit.RemoveLabels {
+		parts = append(parts, "−"+label)
 	}
+	for _, login := range edit.AddAssignees {
+		parts = append(parts, "+@"+login)
+	}
+	for _, login := range edit.RemoveAssignees {
+		parts = append(parts, "−@"+login)
+	}
+	if edit.Milestone != nil {
+		if milestone == "" {
+			parts = append(parts, "milestone cleared")
+		} else {
+			parts = append(parts, "milestone "+milestone)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
-// viewWorkflowLogs shows the logs for a workflow run in the pager
-func viewWorkflowLogs(runId string) tea.Cmd {
+// reopenIssue reopens a closed issue in repo
+func reopenIssue(repo string, issueNumber int) tea.Cmd {
 	return func() tea.Msg {
-		// gh run view shows the logs in the default pager (less, more, etc.)
-		cmd := exec.Command("gh", "run", "view", runId, "--log")
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-
-		if err := cmd.Run(); err != nil {
-			return errMsg{err: fmt.Errorf("failed to view logs: %w", err)}
+		if err := ghClient.ReopenIssue(repo, issueNumber); err != nil {
+			return errMsg{err: fmt.Errorf("failed to reopen issue: %w", err)}
 		}
 
-		return nil
+		return statusMsg{message: fmt.Sprintf("Issue #%d reopened", issueNumber)}
 	}
 }
 
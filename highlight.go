@@ -0,0 +1,235 @@
+package main
+
+import (
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlight.go - Syntax Highlighting
+// Purpose: Colour keywords, strings, comments and numbers of source lines,
+// for the diff viewer (view_diff.go)
+// Lines are highlighted one at a time, as diff hunks don't carry what came
+// before them: a block comment or string spanning lines is only coloured on
+// the line where it starts.
+// When to extend: Add a language to syntaxLanguages and its extensions to
+// syntaxExtensions
+
+// syntaxLanguage is what the highlighter needs to know about a language
+type syntaxLanguage struct {
+	keywords     map[string]bool
+	lineComment  string    // e.g. //, #
+	blockComment [2]string // Opening and closing, e.g. /* */
+	quotes       string    // Characters that open a string
+}
+
+// keywordSet builds a set of keywords
+func keywordSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(list) {
+		set[word] = true
+	}
+	return set
+}
+
+// syntaxLanguages are the languages the diff viewer highlights
+var syntaxLanguages = map[string]*syntaxLanguage{
+	"go": {
+		keywords: keywordSet(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var
+			true false nil iota`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	},
+	"c": {
+		keywords: keywordSet(`auto break case char class const continue default delete do double else enum
+			extern final float for goto if implements import int interface long namespace new
+			package private protected public return short signed sizeof static struct super
+			switch template this throw throws try catch typedef union unsigned using virtual
+			void volatile while bool boolean byte var val fun let func override true false
+			null nullptr NULL`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'",
+	},
+	"js": {
+		keywords: keywordSet(`async await break case catch class const continue debugger default delete do
+			else export extends finally for from function if import in instanceof interface let
+			new of return static super switch this throw try type typeof var void while yield
+			true false null undefined`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	},
+	"rust": {
+		keywords: keywordSet(`as async await break const continue crate else enum extern fn for if impl in
+			let loop match mod move mut pub ref return self Self static struct super trait type
+			unsafe use where while true false`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"",
+	},
+	"python": {
+		keywords: keywordSet(`and as assert async await break class continue def del elif else except
+			finally for from global if import in is lambda nonlocal not or pass raise return try
+			while with yield True False None self`),
+		lineComment: "#",
+		quotes:      "\"'",
+	},
+	"ruby": {
+		keywords: keywordSet(`alias and begin break case class def defined? do else elsif end ensure for
+			if in module next nil not or redo rescue retry return self super then true false
+			undef unless until when while yield require`),
+		lineComment: "#",
+		quotes:      "\"'",
+	},
+	"shell": {
+		keywords: keywordSet(`case do done elif else esac export fi for function if in local readonly
+			return set then until while`),
+		lineComment: "#",
+		quotes:      "\"'",
+	},
+	"yaml": {
+		keywords:    keywordSet(`true false null yes no on off`),
+		lineComment: "#",
+		quotes:      "\"'",
+	},
+	"json": {
+		keywords: keywordSet(`true false null`),
+		quotes:   "\"",
+	},
+}
+
+// syntaxExtensions maps file extensions to syntaxLanguages
+var syntaxExtensions = map[string]string{
+	".go":    "go",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "c",
+	".cpp":   "c",
+	".hpp":   "c",
+	".java":  "c",
+	".cs":    "c",
+	".kt":    "c",
+	".kts":   "c",
+	".swift": "c",
+	".scala": "c",
+	".js":    "js",
+	".jsx":   "js",
+	".mjs":   "js",
+	".ts":    "js",
+	".tsx":   "js",
+	".rs":    "rust",
+	".py":    "python",
+	".rb":    "ruby",
+	".sh":    "shell",
+	".bash":  "shell",
+	".zsh":   "shell",
+	".yml":   "yaml",
+	".yaml":  "yaml",
+	".json":  "json",
+}
+
+// syntaxFor returns the language of the file at path, or nil when it isn't
+// one the highlighter knows
+func syntaxFor(file string) *syntaxLanguage {
+	if path.Base(file) == "Dockerfile" || path.Base(file) == "Makefile" {
+		return syntaxLanguages["shell"]
+	}
+	return syntaxLanguages[syntaxExtensions[strings.ToLower(path.Ext(file))]]
+}
+
+// highlightCode renders a line of source in lang, with the text that isn't a
+// keyword, string, comment or number in plain
+func highlightCode(lang *syntaxLanguage, text string, plain lipgloss.Style) string {
+	if lang == nil {
+		return plain.Render(text)
+	}
+
+	var b strings.Builder
+	start := 0 // Start of the plain text not yet written
+	token := func(from, to int, style lipgloss.Style) {
+		if from > start {
+			b.WriteString(plain.Render(text[start:from]))
+		}
+		b.WriteString(style.Render(text[from:to]))
+		start = to
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case lang.lineComment != "" && strings.HasPrefix(text[i:], lang.lineComment) &&
+			(lang.lineComment != "#" || i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			token(i, len(text), syntaxCommentStyle)
+			i = len(text)
+		case lang.blockComment[0] != "" && strings.HasPrefix(text[i:], lang.blockComment[0]):
+			end := len(text)
+			open := len(lang.blockComment[0])
+			if n := strings.Index(text[i+open:], lang.blockComment[1]); n >= 0 {
+				end = i + open + n + len(lang.blockComment[1])
+			}
+			token(i, end, syntaxCommentStyle)
+			i = end
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			end := stringEnd(text, i)
+			token(i, end, syntaxStringStyle)
+			i = end
+		case isDigit(c) && (i == 0 || !isIdentByte(text[i-1])):
+			end := i + 1
+			for end < len(text) && (isIdentByte(text[end]) || text[end] == '.') {
+				end++
+			}
+			token(i, end, syntaxNumberStyle)
+			i = end
+		case isIdentByte(c):
+			end := i + 1
+			for end < len(text) && isIdentByte(text[end]) {
+				end++
+			}
+			// Ruby's defined? and the like
+			if end < len(text) && text[end] == '?' && lang.keywords[text[i:end+1]] {
+				end++
+			}
+			if lang.keywords[text[i:end]] {
+				token(i, end, syntaxKeywordStyle)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	if start < len(text) {
+		b.WriteString(plain.Render(text[start:]))
+	}
+	return b.String()
+}
+
+// stringEnd returns where the string opened at text[i] ends, just after its
+// closing quote, or the end of the line when it isn't closed on it
+func stringEnd(text string, i int) int {
+	quote := text[i]
+	for j := i + 1; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			return j + 1
+		}
+	}
+	return len(text)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentByte reports whether c can be part of an identifier; bytes of
+// non-ASCII characters count, so they never split one
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 0x80 || isDigit(c) || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
@@ -416,7 +416,9 @@ func NewLandingPage(width, height int) *LandingPage {
 			"Repositories",
 			"Actions",
 			"Gists",
-			"Plugins",
+			"Search",
+			"Notifications",
+			"Dashboard",
 		},
 		selectedItem: 0,
 	}
@@ -452,6 +454,13 @@ func (lp *LandingPage) SelectPrev() {
 	}
 }
 
+// SetSelectedItem selects the menu item at index, if it exists
+func (lp *LandingPage) SetSelectedItem(index int) {
+	if index >= 0 && index < len(lp.menuItems) {
+		lp.selectedItem = index
+	}
+}
+
 // GetSelectedItem returns the currently selected menu item index
 func (lp *LandingPage) GetSelectedItem() int {
 	return lp.selectedItem
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logs.go - Workflow Run Logs
+// Purpose: Split a run's log archive into job/step sections of classified
+// lines, and stream them to the log viewer (view_logs.go) in chunks.
+// The archive has one "<n>_<job>.txt" per job and, for most runs, a
+// "<job>/<n>_<step>.txt" per step; jobs without step files get one section.
+
+// LogLineKind classifies a log line by its ##[...] marker
+type LogLineKind int
+
+const (
+	LogPlain LogLineKind = iota
+	LogGroup             // ##[group]: starts a foldable group
+	LogError
+	LogWarning
+	LogNotice
+	LogCommand
+	LogDebug
+)
+
+// LogLine is a line of a step's log, without its timestamp and marker
+type LogLine struct {
+	Kind  LogLineKind
+	Text  string
+	Group int // Index of the LogGroup line containing this one, -1 outside groups
+}
+
+// LogSection is the log of one step (or a whole job without step files)
+type LogSection struct {
+	Job   string
+	Step  string // "" for a whole job
+	Lines []LogLine
+}
+
+// logChunkSize is the number of lines sent per logChunkMsg
+const logChunkSize = 2000
+
+// logChunkMsg carries the next lines of a run's logs
+// A section may arrive in several chunks; later ones have more set.
+type logChunkMsg struct {
+	runID   int64
+	section LogSection
+	more    bool // Continues the previous chunk's section
+	err     error
+	stream  <-chan logChunkMsg
+}
+
+// logStreamDoneMsg reports that all of a run's logs have arrived
+type logStreamDoneMsg struct {
+	runID int64
+}
+
+// logSavedMsg reports the result of saving logs to a file
+type logSavedMsg struct {
+	path string
+	err  error
+}
+
+var (
+	logTimestampRe = regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?Z ?`)
+	logStepFileRe  = regexp.MustCompile(`^(\d+)_(.*)\.txt$`)
+	logANSIRe      = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+)
+
+// logMarkers maps the ##[...] markers to line kinds
+var logMarkers = []struct {
+	marker string
+	kind   LogLineKind
+}{
+	{"##[group]", LogGroup},
+	{"##[error]", LogError},
+	{"##[warning]", LogWarning},
+	{"##[notice]", LogNotice},
+	{"##[command]", LogCommand},
+	{"##[debug]", LogDebug},
+}
+
+// parseLogLines classifies the lines of a log file
+// ##[endgroup] lines only close their group and are dropped, and colour
+// codes are stripped since lines are restyled by kind.
+func parseLogLines(text string) []LogLine {
+	var lines []LogLine
+	group := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(strings.TrimPrefix(text, "\ufeff")))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		raw := logTimestampRe.ReplaceAllString(strings.TrimSuffix(scanner.Text(), "\r"), "")
+		raw = logANSIRe.ReplaceAllString(raw, "")
+
+		if strings.HasPrefix(raw, "##[endgroup]") {
+			group = -1
+			continue
+		}
+
+		line := LogLine{Kind: LogPlain, Text: raw, Group: group}
+		for _, m := range logMarkers {
+			if strings.HasPrefix(raw, m.marker) {
+				line.Kind = m.kind
+				line.Text = strings.TrimPrefix(raw, m.marker)
+				break
+			}
+		}
+		if line.Kind == LogGroup {
+			line.Group = -1
+			group = len(lines)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// logArchiveFile is a job or step file of the log archive
+type logArchiveFile struct {
+	job    string
+	step   string
+	number int
+	file   *zip.File
+}
+
+// splitLogArchive orders the files of a log archive by job and step,
+// using step files where a job has them and the job file otherwise
+func splitLogArchive(data []byte) ([]logArchiveFile, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("reading log archive: %w", err)
+	}
+
+	var jobs []logArchiveFile
+	steps := make(map[string][]logArchiveFile)
+	for _, f := range archive.File {
+		dir, name := path.Split(f.Name)
+		m := logStepFileRe.FindStringSubmatch(name)
+		if f.FileInfo().IsDir() || m == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(m[1])
+		if dir == "" {
+			jobs = append(jobs, logArchiveFile{job: m[2], number: number, file: f})
+		} else {
+			job := strings.TrimSuffix(dir, "/")
+			steps[job] = append(steps[job], logArchiveFile{job: job, step: m[2], number: number, file: f})
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].number < jobs[j].number })
+
+	var files []logArchiveFile
+	for _, job := range jobs {
+		jobSteps := steps[job.job]
+		if len(jobSteps) == 0 {
+			files = append(files, job)
+			continue
+		}
+		sort.SliceStable(jobSteps, func(i, j int) bool { return jobSteps[i].number < jobSteps[j].number })
+		files = append(files, jobSteps...)
+		delete(steps, job.job)
+	}
+
+	// Step directories without a job file (names can differ when truncated)
+	var orphans []string
+	for job := range steps {
+		orphans = append(orphans, job)
+	}
+	sort.Strings(orphans)
+	for _, job := range orphans {
+		jobSteps := steps[job]
+		sort.SliceStable(jobSteps, func(i, j int) bool { return jobSteps[i].number < jobSteps[j].number })
+		files = append(files, jobSteps...)
+	}
+
+	return files, nil
+}
+
+// streamRunLogs downloads a run's logs and sends them section by section
+// Closing stop abandons the stream.
+func streamRunLogs(repo string, runID int64, stop <-chan struct{}) tea.Cmd {
+	stream := make(chan logChunkMsg, 4)
+
+	go func() {
+		defer close(stream)
+		send := func(msg logChunkMsg) bool {
+			msg.runID = runID
+			msg.stream = stream
+			select {
+			case stream <- msg:
+				return true
+			case <-stop:
+				return false
+			}
+		}
+
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			send(logChunkMsg{err: err})
+			return
+		}
+		data, err := ghClient.WorkflowRunLogs(repo, runID)
+		if err != nil {
+			send(logChunkMsg{err: err})
+			return
+		}
+		files, err := splitLogArchive(data)
+		if err != nil {
+			send(logChunkMsg{err: err})
+			return
+		}
+
+		for _, f := range files {
+			text, err := readZipFile(f.file)
+			if err != nil {
+				send(logChunkMsg{err: err})
+				return
+			}
+
+			// Group indices are relative to the whole section, so they stay
+			// valid when the viewer appends chunks
+			lines := parseLogLines(text)
+			for start := 0; start == 0 || start < len(lines); start += logChunkSize {
+				end := min(start+logChunkSize, len(lines))
+				section := LogSection{Job: f.job, Step: f.step, Lines: lines[start:end]}
+				if !send(logChunkMsg{section: section, more: start > 0}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return waitForLogChunk(runID, stream)
+}
+
+// waitForLogChunk waits for the next chunk of a log stream
+func waitForLogChunk(runID int64, stream <-chan logChunkMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return logStreamDoneMsg{runID: runID}
+		}
+		return msg
+	}
+}
+
+// readZipFile returns the content of a file in a zip archive
+func readZipFile(f *zip.File) (string, error) {
+	r, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
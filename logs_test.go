@@ -0,0 +1,133 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLogLines(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		want []LogLine
+	}{
+		{
+			name: "markers",
+			log: "##[command]go test ./...\n" +
+				"##[error]boom\n" +
+				"##[warning]careful\n" +
+				"##[notice]fyi\n" +
+				"##[debug]details\n" +
+				"ok",
+			want: []LogLine{
+				{Kind: LogCommand, Text: "go test ./...", Group: -1},
+				{Kind: LogError, Text: "boom", Group: -1},
+				{Kind: LogWarning, Text: "careful", Group: -1},
+				{Kind: LogNotice, Text: "fyi", Group: -1},
+				{Kind: LogDebug, Text: "details", Group: -1},
+				{Kind: LogPlain, Text: "ok", Group: -1},
+			},
+		},
+		{
+			name: "groups",
+			log: "before\n" +
+				"##[group]Run actions/checkout@v4\n" +
+				"with:\n" +
+				"##[endgroup]\n" +
+				"after\n" +
+				"##[group]Second\n" +
+				"inside",
+			want: []LogLine{
+				{Kind: LogPlain, Text: "before", Group: -1},
+				{Kind: LogGroup, Text: "Run actions/checkout@v4", Group: -1},
+				{Kind: LogPlain, Text: "with:", Group: 1},
+				{Kind: LogPlain, Text: "after", Group: -1},
+				{Kind: LogGroup, Text: "Second", Group: -1},
+				{Kind: LogPlain, Text: "inside", Group: 4},
+			},
+		},
+		{
+			name: "timestamps, colours and line endings",
+			log: "\ufeff2024-01-01T00:00:00.1234567Z ##[error]\x1b[31mfailed\x1b[0m\r\n" +
+				"2024-01-01T00:00:01Z plain\r\n",
+			want: []LogLine{
+				{Kind: LogError, Text: "failed", Group: -1},
+				{Kind: LogPlain, Text: "plain", Group: -1},
+			},
+		},
+		{
+			name: "empty",
+			log:  "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLogLines(tt.log)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLogArchive(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string // "<job>/<step>" of each file in order; step is "" for job files
+	}{
+		{
+			name: "job files only",
+			files: map[string]string{
+				"2_test.txt":  "",
+				"1_build.txt": "",
+			},
+			want: []string{"build/", "test/"},
+		},
+		{
+			name: "step files replace their job file",
+			files: map[string]string{
+				"1_build.txt":                   "",
+				"build/10_Post checkout.txt":    "",
+				"build/2_Run tests.txt":         "",
+				"build/1_Set up job.txt":        "",
+				"2_lint.txt":                    "",
+				"build/system.txt":              "",
+				"README.md":                     "",
+				"zz truncated/1_Set up job.txt": "",
+				"aa truncated/1_Set up job.txt": "",
+			},
+			want: []string{
+				"build/Set up job", "build/Run tests", "build/Post checkout",
+				"lint/",
+				"aa truncated/Set up job", "zz truncated/Set up job",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := zipFiles(tt.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := splitLogArchive(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range files {
+				got = append(got, f.job+"/"+f.step)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := splitLogArchive([]byte("not a zip")); err == nil {
+		t.Error("no error for a file that isn't a zip")
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -12,29 +14,71 @@ import (
 // Rule: Never add business logic to this file. Keep it minimal.
 
 func main() {
-	// Check GitHub CLI authentication
+	// Parse flags (see cli.go)
+	opts, err := parseArgs(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := loadConfig(opts.configPath)
+	if err == nil {
+		err = opts.applyConfig(&cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Select the GitHub backend
+	client, err := newGitHubClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ghClient = client
+
+	// Open the on-disk list cache (~/.cache/gh-tui); a negative size turns it off
+	if cfg.Performance.CacheSize > 0 {
+		dataCache = NewDataCache(getCacheDir(), cfg.Performance.CacheSize)
+	}
+
+	// PR worktrees (see worktree.go)
+	worktreeDir = cfg.Git.WorktreeDir
+
+	// Check GitHub authentication
 	if err := checkGitHubAuth(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		fmt.Fprintf(os.Stderr, "\nPlease authenticate with GitHub CLI:\n")
 		fmt.Fprintf(os.Stderr, "  gh auth login\n")
+		fmt.Fprintf(os.Stderr, "\nor, with the api backend, set GH_TOKEN or GITHUB_TOKEN\n")
 		os.Exit(1)
 	}
 
-	// Load configuration
-	cfg := loadConfig()
+	// Non-interactive subcommands (e.g. gh-tui export prs --format json)
+	if len(opts.command) > 0 {
+		os.Exit(runCommand(opts, os.Stdout))
+	}
 
 	// Create program with options based on config
-	opts := []tea.ProgramOption{
+	tuiOpts := []tea.ProgramOption{
 		tea.WithAltScreen(),
 	}
 
 	if cfg.UI.MouseEnabled {
-		opts = append(opts, tea.WithMouseCellMotion())
+		tuiOpts = append(tuiOpts, tea.WithMouseCellMotion())
 	}
 
+	m := initialModel(cfg)
+	opts.applyModel(&m, cfg)
+
 	p := tea.NewProgram(
-		initialModel(cfg),
-		opts...,
+		m,
+		tuiOpts...,
 	)
 
 	if _, err := p.Run(); err != nil {
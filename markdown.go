@@ -0,0 +1,136 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// markdown.go - Terminal Markdown Rendering
+// Purpose: Render GitHub-flavored markdown bodies (PRs, issues, comments) as styled lines
+// Handles headings, lists, task lists, block quotes, code fences, rules and
+// inline code/bold.
+// When to extend: Add block rules to renderMarkdown, inline rules to renderInline
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdBulletRe  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdNumberRe  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	mdTaskRe    = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	mdRuleRe    = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdCodeRe    = regexp.MustCompile("`([^`]+)`")
+	mdBoldRe    = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+)
+
+// renderMarkdown renders markdown text as styled lines no wider than width
+func renderMarkdown(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+
+	var lines []string
+	inCode := false
+
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		// Code fences: keep content verbatim in a gutter, only truncate
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			if lang := strings.TrimSpace(strings.TrimLeft(trimmed, "`")); inCode && lang != "" {
+				lines = append(lines, dividerStyle.Render("┃ ")+mdCodeLangStyle.Render(truncateString(lang, width-2)))
+			}
+			continue
+		}
+		if inCode {
+			lines = append(lines, dividerStyle.Render("┃ ")+mdCodeStyle.Render(truncateString(expandTabs(raw), width-2)))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			// Collapse runs of blank lines
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+
+		case mdRuleRe.MatchString(trimmed):
+			lines = append(lines, dividerStyle.Render(strings.Repeat("─", width)))
+
+		case mdHeadingRe.MatchString(trimmed):
+			heading := mdHeadingRe.FindStringSubmatch(trimmed)[2]
+			for _, line := range wrapText(heading, width) {
+				lines = append(lines, mdHeadingStyle.Render(line))
+			}
+
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimLeft(trimmed, ">"))
+			for _, line := range wrapText(quote, width-2) {
+				lines = append(lines, dividerStyle.Render("│ ")+mdQuoteStyle.Render(line))
+			}
+
+		case mdBulletRe.MatchString(raw):
+			m := mdBulletRe.FindStringSubmatch(raw)
+			marker, style, item := "• ", highlightStyle, m[2]
+			if task := mdTaskRe.FindStringSubmatch(item); task != nil {
+				marker, style, item = "☐ ", mdTaskOpenStyle, task[2]
+				if task[1] != " " {
+					marker, style = "☑ ", mdTaskDoneStyle
+				}
+			}
+			lines = append(lines, renderListItem(listIndent(m[1]), marker, style, item, width)...)
+
+		case mdNumberRe.MatchString(raw):
+			m := mdNumberRe.FindStringSubmatch(raw)
+			lines = append(lines, renderListItem(listIndent(m[1]), m[2]+" ", highlightStyle, m[3], width)...)
+
+		default:
+			for _, line := range wrapText(trimmed, width) {
+				lines = append(lines, renderInline(line))
+			}
+		}
+	}
+
+	// Drop trailing blank lines
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// renderListItem wraps a list item with a hanging indent, styling its marker
+func renderListItem(indent, marker string, style lipgloss.Style, text string, width int) []string {
+	prefix := indent + marker
+	pad := strings.Repeat(" ", len([]rune(prefix)))
+
+	var lines []string
+	for i, line := range wrapText(text, width-len([]rune(prefix))) {
+		if i == 0 {
+			lines = append(lines, indent+style.Render(marker)+renderInline(line))
+		} else {
+			lines = append(lines, pad+renderInline(line))
+		}
+	}
+	return lines
+}
+
+// listIndent converts source indentation to two spaces per nesting level
+func listIndent(ws string) string {
+	return strings.Repeat("  ", len(expandTabs(ws))/2)
+}
+
+// renderInline styles inline code spans and bold text within a line
+func renderInline(line string) string {
+	line = mdCodeRe.ReplaceAllStringFunc(line, func(s string) string {
+		return mdCodeStyle.Render(strings.Trim(s, "`"))
+	})
+	return mdBoldRe.ReplaceAllStringFunc(line, func(s string) string {
+		return mdBoldStyle.Render(s[2 : len(s)-2])
+	})
+}
+
+// expandTabs replaces tabs with four spaces so widths stay predictable
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
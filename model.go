@@ -20,6 +20,8 @@ func initialModel(cfg Config) model {
 		statusMsg:        "gh-tui - GitHub CLI Interactive Interface - Press ? for help",
 		activeView:       ViewPullRequests,
 		views:            make(map[ViewType]View),
+		cachedAt:         make(map[ViewType]time.Time),
+		pendingRefresh:   make(map[ViewType]bool),
 		loading:          false,
 		showHelp:         false,
 		showLandingPage:  true, // Start with landing page
@@ -31,6 +33,10 @@ func initialModel(cfg Config) model {
 	m.views[ViewRepositories] = NewRepositoryView()
 	m.views[ViewActions] = NewActionsView()
 	m.views[ViewGists] = NewGistView()
+	m.views[ViewSearch] = NewSearchView()
+	m.views[ViewNotifications] = NewNotificationsView()
+	m.views[ViewDashboard] = NewDashboardView()
+	m.addCustomTabs(cfg.Tabs)
 
 	// Focus the initial view (Pull Requests)
 	if view, ok := m.views[ViewPullRequests]; ok {
@@ -106,18 +112,92 @@ func (m model) Init() tea.Cmd {
 		cmds = append(cmds, landingTick())
 	} else {
 		// Load data immediately if not showing landing page
-		cmds = append(cmds,
-			fetchPullRequests(""),
-			fetchIssues(""),
-			fetchRepositories(""),
-			fetchWorkflowRuns(""),
-			fetchGists(),
-		)
+		cmds = append(cmds, m.loadInitialData())
 	}
 
 	return tea.Batch(cmds...)
 }
 
+// loadInitialData shows cached lists right away and revalidates them in the
+// background. With LazyLoading, only the active view is fetched; the others
+// show their cached data and are fetched when first switched to.
+func (m model) loadInitialData() tea.Cmd {
+	vts := append([]ViewType{ViewPullRequests, ViewIssues, ViewRepositories, ViewActions, ViewGists, ViewNotifications, ViewDashboard}, m.customTabViews()...)
+	cmd := m.loadViews(vts...)
+	if m.currentRepo == "" {
+		return tea.Batch(cmd, detectCurrentRepo())
+	}
+	return cmd
+}
+
+// loadViews loads cached then fresh data for the given views
+func (m model) loadViews(vts ...ViewType) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, vt := range vts {
+		if m.config.Performance.LazyLoading && vt != m.activeView {
+			m.pendingRefresh[vt] = true
+			cmds = append(cmds, m.cachedViewData(vt))
+			continue
+		}
+		delete(m.pendingRefresh, vt)
+		cmds = append(cmds, tea.Sequence(m.cachedViewData(vt), m.fetchViewData(vt)))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// cachedViewData returns the command that serves a view's cached data
+func (m model) cachedViewData(vt ViewType) tea.Cmd {
+	switch vt {
+	case ViewPullRequests:
+		return cachedPullRequests(m.currentRepo)
+	case ViewIssues:
+		return cachedIssues(m.currentRepo)
+	case ViewRepositories:
+		return cachedRepositories("")
+	case ViewActions:
+		return cachedWorkflowRuns(m.currentRepo)
+	case ViewGists:
+		return cachedGists()
+	}
+	return nil
+}
+
+// fetchViewData returns the command that fetches fresh data for a view
+func (m model) fetchViewData(vt ViewType) tea.Cmd {
+	if tab, ok := m.customTabs[vt]; ok {
+		return fetchTabPage(vt, tab, m.currentRepo, "")
+	}
+	switch vt {
+	case ViewPullRequests:
+		return fetchPullRequests(m.currentRepo)
+	case ViewIssues:
+		return fetchIssues(m.currentRepo)
+	case ViewRepositories:
+		return fetchRepositories("")
+	case ViewActions:
+		return fetchWorkflowRuns(m.currentRepo)
+	case ViewGists:
+		return fetchGists()
+	case ViewSearch:
+		// Searches aren't cached; run the current query again
+		if view, ok := m.views[ViewSearch].(*SearchView); ok {
+			return view.Refresh()
+		}
+	case ViewNotifications:
+		// The inbox isn't cached either
+		if view, ok := m.views[ViewNotifications].(*NotificationsView); ok {
+			return view.Refresh()
+		}
+	case ViewDashboard:
+		// Each tile is its own query, loaded side by side
+		if view, ok := m.views[ViewDashboard].(*DashboardView); ok {
+			return view.Refresh()
+		}
+	}
+	return nil
+}
+
 // landingTick creates a tick command for landing page animation
 func landingTick() tea.Cmd {
 	return tea.Tick(time.Second/20, func(t time.Time) tea.Msg {
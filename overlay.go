@@ -0,0 +1,83 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// overlay.go - Overlay Views
+// Purpose: Full-size views opened over the tabs (diff viewer, dialogs)
+// Overlays are stacked; the top one gets keys, wheel events and the content
+// area, and every message the model doesn't handle itself.
+// When to extend: Open new overlays with openOverlay from any view
+
+// openOverlayMsg pushes a view on top of the tabs
+type openOverlayMsg struct {
+	view View
+}
+
+// closeOverlayMsg pops the top overlay
+type closeOverlayMsg struct{}
+
+// openOverlay opens view over the tabs
+func openOverlay(view View) tea.Cmd {
+	return func() tea.Msg {
+		return openOverlayMsg{view: view}
+	}
+}
+
+// closeOverlay closes the top overlay, returning to whatever was below it
+func closeOverlay() tea.Cmd {
+	return func() tea.Msg {
+		return closeOverlayMsg{}
+	}
+}
+
+// pushOverlay focuses view on top of the stack
+func (m *model) pushOverlay(view View) {
+	if len(m.overlays) > 0 {
+		m.overlays[len(m.overlays)-1].Blur()
+	} else if active, ok := m.views[m.activeView]; ok {
+		active.Blur()
+	}
+
+	m.overlays = append(m.overlays, view)
+	view.Focus()
+	m.focusedComponent = "overlay"
+}
+
+// popOverlay closes the top overlay and refocuses the one below (or the tab)
+func (m *model) popOverlay() {
+	if len(m.overlays) == 0 {
+		return
+	}
+	m.overlays[len(m.overlays)-1].Blur()
+	m.overlays = m.overlays[:len(m.overlays)-1]
+
+	if len(m.overlays) > 0 {
+		m.overlays[len(m.overlays)-1].Focus()
+		return
+	}
+	if active, ok := m.views[m.activeView]; ok {
+		active.Focus()
+	}
+	m.focusedComponent = "main"
+}
+
+// updateTopOverlay sends input to the focused overlay
+func (m model) updateTopOverlay(msg tea.Msg) (tea.Model, tea.Cmd) {
+	top := len(m.overlays) - 1
+	updatedView, cmd := m.overlays[top].Update(msg)
+	m.overlays[top] = updatedView
+	return m, cmd
+}
+
+// updateOverlays sends a message to every overlay, e.g. results of their fetches
+func (m model) updateOverlays(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i, overlay := range m.overlays {
+		updatedView, cmd := overlay.Update(msg)
+		m.overlays[i] = updatedView
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
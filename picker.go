@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// picker.go - Reusable Fuzzy Picker Component
+// Purpose: Filterable list of choices shown as a centered dialog
+// Type to filter, ↑/↓ to move, Enter to choose, Esc to cancel
+// Multi-select pickers toggle items with Space and confirm with Enter.
+
+// PickerItem is a single choice in a picker
+type PickerItem struct {
+	Label  string // Text that is matched and displayed
+	Detail string // Dimmed text shown after the label
+	Value  string // Value returned when chosen (defaults to Label)
+	Color  string // Hex color of a swatch shown before the label, e.g. for labels
+}
+
+// value returns what choosing the item returns
+func (item PickerItem) value() string {
+	if item.Value == "" {
+		return item.Label
+	}
+	return item.Value
+}
+
+// PickerResult describes what a key press did to the picker
+type PickerResult int
+
+const (
+	PickerOpen PickerResult = iota
+	PickerChosen
+	PickerCancelled
+)
+
+// Picker holds the state of a fuzzy picker
+type Picker struct {
+	Title    string
+	items    []PickerItem
+	filtered []int // Indexes into items, best match first
+	query    string
+	cursor   int
+	multi    bool
+	checked  map[string]bool // Values toggled on in a multi-select picker
+}
+
+// NewPicker creates a picker over items
+func NewPicker(title string, items []PickerItem) *Picker {
+	p := &Picker{Title: title, items: items}
+	p.filter()
+	return p
+}
+
+// NewMultiPicker creates a picker to toggle any number of items, starting
+// with the items whose values are in selected
+func NewMultiPicker(title string, items []PickerItem, selected []string) *Picker {
+	p := NewPicker(title, items)
+	p.multi = true
+	p.checked = make(map[string]bool)
+	for _, value := range selected {
+		p.checked[value] = true
+	}
+	return p
+}
+
+// Checked returns the values toggled on in a multi-select picker, in item order
+func (p *Picker) Checked() []string {
+	var values []string
+	for _, item := range p.items {
+		if p.checked[item.value()] {
+			values = append(values, item.value())
+		}
+	}
+	return values
+}
+
+// Query returns the current filter text
+func (p *Picker) Query() string {
+	return p.query
+}
+
+// Selected returns the highlighted item
+func (p *Picker) Selected() (PickerItem, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.filtered) {
+		return PickerItem{}, false
+	}
+	item := p.items[p.filtered[p.cursor]]
+	item.Value = item.value()
+	return item, true
+}
+
+// HandleKey applies a key press and reports whether the picker is done
+func (p *Picker) HandleKey(msg tea.KeyMsg) PickerResult {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return PickerCancelled
+	case tea.KeyEnter:
+		return PickerChosen
+	case tea.KeyUp, tea.KeyCtrlP:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case tea.KeyDown, tea.KeyCtrlN:
+		if p.cursor < len(p.filtered)-1 {
+			p.cursor++
+		}
+	case tea.KeyBackspace:
+		if p.query != "" {
+			runes := []rune(p.query)
+			p.query = string(runes[:len(runes)-1])
+			p.filter()
+		}
+	case tea.KeyCtrlU:
+		p.query = ""
+		p.filter()
+	case tea.KeySpace:
+		if p.multi {
+			// Spaces mean nothing to the fuzzy match anyway
+			if item, ok := p.Selected(); ok {
+				p.checked[item.Value] = !p.checked[item.Value]
+			}
+			break
+		}
+		p.query += string(msg.Runes)
+		p.filter()
+	case tea.KeyRunes:
+		p.query += string(msg.Runes)
+		p.filter()
+	}
+	return PickerOpen
+}
+
+// filter recomputes the matching items for the current query
+func (p *Picker) filter() {
+	type match struct {
+		index int
+		score int
+	}
+
+	var matches []match
+	for i, item := range p.items {
+		if score, ok := fuzzyScore(p.query, item.Label); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+
+	// Stable sort keeps the original order (e.g. most recent first) for ties
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	p.filtered = p.filtered[:0]
+	for _, m := range matches {
+		p.filtered = append(p.filtered, m.index)
+	}
+	p.cursor = 0
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of text
+// Consecutive characters and matches at word starts score higher
+func fuzzyScore(query, text string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	// Gaps are allowed anyway, so spaces in the query carry no meaning
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+
+	score, qi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+
+		score++
+		if ti == prev+1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2
+		}
+		prev = ti
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// View renders the picker dialog
+func (p *Picker) View(width, height int) string {
+	var lines []string
+
+	lines = append(lines, listTitleStyle.Render(" "+p.Title))
+	lines = append(lines, "")
+	lines = append(lines, highlightStyle.Render("> ")+p.query+"█")
+	lines = append(lines, "")
+
+	maxVisible := height - 8
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+	start := 0
+	if p.cursor >= maxVisible {
+		start = p.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(p.filtered))
+
+	if len(p.filtered) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
+
+	for i := start; i < end; i++ {
+		item := p.items[p.filtered[i]]
+		cursor := "  "
+		style := listItemStyle
+		if i == p.cursor {
+			cursor = "▶ "
+			style = listSelectedStyle
+		}
+
+		if p.multi {
+			cursor += "[ ] "
+			if p.checked[item.value()] {
+				cursor = strings.Replace(cursor, "[ ]", "[x]", 1)
+			}
+		}
+		label := truncateString(item.Label, width-6-lipgloss.Width(cursor))
+		if item.Color != "" {
+			label = lipgloss.NewStyle().Foreground(lipgloss.Color("#"+item.Color)).Render("● ") + label
+		}
+		line := cursor + label
+		if item.Detail != "" && lipgloss.Width(line)+len(item.Detail)+3 < width-4 {
+			line += strings.Repeat(" ", max(1, width-len(item.Detail)-7-lipgloss.Width(line))) + dimmedStyle.Render(item.Detail)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	lines = append(lines, "")
+	help := fmt.Sprintf("%d/%d • ↑/↓: Navigate • Enter: Select • Esc: Cancel", len(p.filtered), len(p.items))
+	if p.multi {
+		help = fmt.Sprintf("%d selected • Space: Toggle • Enter: Done • Esc: Cancel", len(p.Checked()))
+	}
+	lines = append(lines, helpStyle.Render(help))
+
+	return lipgloss.NewStyle().
+		Width(width).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
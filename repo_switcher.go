@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// repo_switcher.go - Repository Context Switcher
+// Purpose: Choose which repo the PR, Issues and Actions tabs are scoped to
+// Recently used repos are kept in ~/.config/gh-tui/recent_repos.json
+
+const maxRecentRepos = 10
+
+// repoScopedViews are the tabs that follow the current repo: PRs, Issues,
+// Actions and the custom tabs
+func (m model) repoScopedViews() []ViewType {
+	return append([]ViewType{ViewPullRequests, ViewIssues, ViewActions}, m.customTabViews()...)
+}
+
+// getRecentReposPath returns the path of the recent repos file
+func getRecentReposPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), "recent_repos.json")
+}
+
+// loadRecentRepos returns recently used repos, most recent first
+func loadRecentRepos() []string {
+	data, err := os.ReadFile(getRecentReposPath())
+	if err != nil {
+		return nil
+	}
+
+	var repos []string
+	if err := json.Unmarshal(data, &repos); err != nil {
+		return nil
+	}
+	return repos
+}
+
+// saveRecentRepo moves repo to the front of the recent repos list
+func saveRecentRepo(repo string) {
+	repos := []string{repo}
+	for _, r := range loadRecentRepos() {
+		if r != repo && len(repos) < maxRecentRepos {
+			repos = append(repos, r)
+		}
+	}
+
+	data, err := json.MarshalIndent(repos, "", "  ")
+	if err != nil {
+		return
+	}
+
+	path := getRecentReposPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0644)
+}
+
+// switchRepo re-scopes the repo-specific tabs to repo
+func switchRepo(repo string) tea.Cmd {
+	return func() tea.Msg {
+		return repoChangedMsg{repo: repo}
+	}
+}
+
+// detectCurrentRepo resolves the repo of the working directory for display
+func detectCurrentRepo() tea.Cmd {
+	return func() tea.Msg {
+		repo, err := ghClient.CurrentRepo()
+		if err != nil {
+			return nil
+		}
+		return repoDetectedMsg{repo: repo}
+	}
+}
+
+// openRepoPicker shows the fuzzy picker over recent and owned repos
+func (m *model) openRepoPicker() {
+	var items []PickerItem
+	seen := make(map[string]bool)
+
+	for _, repo := range loadRecentRepos() {
+		detail := "recent"
+		if repo == m.currentRepo {
+			detail = "current"
+		}
+		items = append(items, PickerItem{Label: repo, Detail: detail})
+		seen[repo] = true
+	}
+
+	for _, repo := range m.repositories {
+		if seen[repo.NameWithOwner] {
+			continue
+		}
+		items = append(items, PickerItem{
+			Label:  repo.NameWithOwner,
+			Detail: fmt.Sprintf("★ %d", repo.StargazerCount),
+		})
+		seen[repo.NameWithOwner] = true
+	}
+
+	m.repoPicker = NewPicker("Switch Repository", items)
+	m.focusedComponent = "picker"
+	m.statusMsg = "Type to filter repos, or enter owner/name"
+}
+
+// handlePickerKeys handles input while the repo picker is open
+func (m model) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.repoPicker.HandleKey(msg) {
+	case PickerCancelled:
+		m.closeRepoPicker()
+		m.statusMsg = "Repository unchanged"
+		return m, nil
+
+	case PickerChosen:
+		// An owner/name typed in full is taken as is, even when the fuzzy
+		// match is a longer name like owner/name-fork; this also allows
+		// repos that aren't in the list
+		repo := ""
+		query := strings.TrimSpace(m.repoPicker.Query())
+		_, _, err := splitRepo(query)
+		fullName := err == nil && strings.Count(query, "/") == 1 && !strings.ContainsAny(query, " \t")
+		item, selected := m.repoPicker.Selected()
+		switch {
+		case selected && strings.EqualFold(item.Value, query):
+			repo = item.Value // As the list spells it
+		case fullName:
+			repo = query
+		case selected:
+			repo = item.Value
+		}
+		m.closeRepoPicker()
+		if repo == "" {
+			return m, nil
+		}
+		return m, switchRepo(repo)
+	}
+
+	return m, nil
+}
+
+// closeRepoPicker hides the picker and returns focus to the main view
+func (m *model) closeRepoPicker() {
+	m.repoPicker = nil
+	m.focusedComponent = "main"
+}
+
+// setRepo scopes the repo-specific tabs to repo and reloads them
+func (m *model) setRepo(repo string) tea.Cmd {
+	m.currentRepo = repo
+	m.pullRequests = nil
+	m.issues = nil
+	m.workflowRuns = nil
+	saveRecentRepo(repo)
+
+	m.scopeViews(repo)
+
+	m.statusMsg = "Switched to " + repo
+	return m.loadViews(m.repoScopedViews()...)
+}
+
+// scopeViews points the repo-specific views at repo, clearing their data
+func (m *model) scopeViews(repo string) {
+	for _, vt := range m.repoScopedViews() {
+		delete(m.cachedAt, vt)
+		if view, ok := m.views[vt]; ok {
+			updatedView, _ := view.Update(repoChangedMsg{repo: repo})
+			m.views[vt] = updatedView
+		}
+	}
+}
+
+// renderRepoPicker renders the picker centered over the content area
+func (m model) renderRepoPicker(width, height int) string {
+	pickerWidth := min(70, width-4)
+	pickerHeight := min(20, height-2)
+	return lipgloss.Place(width, height,
+		lipgloss.Center, lipgloss.Center,
+		m.repoPicker.View(pickerWidth, pickerHeight))
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRepoPickerChoice(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // No recent repos
+
+	tests := []struct {
+		query string
+		want  string // "" for no switch
+	}{
+		{"foo/bar", "foo/bar"},         // Typed in full, not the longer foo/bar-baz
+		{"FOO/BAR-BAZ", "foo/bar-baz"}, // As the list spells it
+		{"barbaz", "foo/bar-baz"},      // Fuzzy
+		{"other/repo", "other/repo"},   // Not in the list
+		{"foo/", "foo/bar-baz"},        // Not a full name
+		{"zzz", ""},                    // Nothing matches
+	}
+	for _, tt := range tests {
+		m := initialModel(getDefaultConfig())
+		m.repositories = []Repository{{NameWithOwner: "foo/bar-baz"}}
+		m.openRepoPicker()
+		for _, r := range tt.query {
+			m.handlePickerKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+
+		_, cmd := m.handlePickerKeys(tea.KeyMsg{Type: tea.KeyEnter})
+		got := ""
+		if msg, ok := runCmd(cmd).(repoChangedMsg); ok {
+			got = msg.repo
+		}
+		if got != tt.want {
+			t.Errorf("%q: switched to %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
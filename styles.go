@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -198,8 +200,125 @@ var menuSelectedStyle = lipgloss.NewStyle().
 var menuSeparatorStyle = lipgloss.NewStyle().
 	Foreground(colorBorder)
 
+// Inline status styles (no padding, for icons and words inside a line)
+var statusSuccessStyle = lipgloss.NewStyle().
+	Foreground(colorAccent)
+
+var statusFailureStyle = lipgloss.NewStyle().
+	Foreground(colorError)
+
+var statusPendingStyle = lipgloss.NewStyle().
+	Foreground(colorWarning)
+
+// Markdown styles
+var mdHeadingStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(colorPrimary)
+
+var mdCodeStyle = lipgloss.NewStyle().
+	Foreground(colorInfo)
+
+var mdQuoteStyle = lipgloss.NewStyle().
+	Foreground(colorDimmed).
+	Italic(true)
+
+var mdBoldStyle = lipgloss.NewStyle().
+	Bold(true)
+
+var mdCodeLangStyle = lipgloss.NewStyle().
+	Foreground(colorDimmed).
+	Italic(true)
+
+var mdTaskOpenStyle = lipgloss.NewStyle().
+	Foreground(colorWarning)
+
+var mdTaskDoneStyle = lipgloss.NewStyle().
+	Foreground(colorAccent)
+
+// Diff styles
+var diffAddStyle = lipgloss.NewStyle().
+	Foreground(colorAccent)
+
+var diffDelStyle = lipgloss.NewStyle().
+	Foreground(colorError)
+
+var diffHunkStyle = lipgloss.NewStyle().
+	Foreground(colorSecondary)
+
+var diffLineNoStyle = lipgloss.NewStyle().
+	Foreground(colorDimmed)
+
+// Syntax highlighting styles (highlight.go)
+
+var syntaxKeywordStyle = lipgloss.NewStyle().
+	Foreground(colorSecondary)
+
+var syntaxStringStyle = lipgloss.NewStyle().
+	Foreground(colorInfo)
+
+var syntaxCommentStyle = lipgloss.NewStyle().
+	Foreground(colorDimmed).
+	Italic(true)
+
+var syntaxNumberStyle = lipgloss.NewStyle().
+	Foreground(colorPrimary)
+
+// Log styles
+var logGroupStyle = lipgloss.NewStyle().
+	Foreground(colorSecondary)
+
+var logMatchStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#000")).
+	Background(colorWarning)
+
+var filterMatchStyle = lipgloss.NewStyle().
+	Foreground(colorWarning).
+	Bold(true).
+	Underline(true)
+
 // Helper functions for dynamic styling
 
+// themePresets are the built-in themes selectable by name
+var themePresets = map[string]ThemeColors{
+	"dark": {
+		Primary: "#58A6FF", Secondary: "#BC8CFF", Background: "#0D1117",
+		Foreground: "#C9D1D9", Accent: "#3FB950", Error: "#F85149",
+	},
+	"light": {
+		Primary: "#0969DA", Secondary: "#8250DF", Background: "#FFFFFF",
+		Foreground: "#1F2328", Accent: "#1A7F37", Error: "#CF222E",
+	},
+	"solarized": {
+		Primary: "#268BD2", Secondary: "#6C71C4", Background: "#002B36",
+		Foreground: "#93A1A1", Accent: "#859900", Error: "#DC322F",
+	},
+	"dracula": {
+		Primary: "#BD93F9", Secondary: "#FF79C6", Background: "#282A36",
+		Foreground: "#F8F8F2", Accent: "#50FA7B", Error: "#FF5555",
+	},
+	"nord": {
+		Primary: "#88C0D0", Secondary: "#B48EAD", Background: "#2E3440",
+		Foreground: "#D8DEE9", Accent: "#A3BE8C", Error: "#BF616A",
+	},
+}
+
+// applyNamedTheme applies a built-in theme, or custom for "custom"
+func applyNamedTheme(name string, custom ThemeColors) error {
+	if name == "custom" {
+		if custom.Primary != "" {
+			applyTheme(custom)
+		}
+		return nil
+	}
+
+	theme, ok := themePresets[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (dark, light, solarized, dracula, nord, custom)", name)
+	}
+	applyTheme(theme)
+	return nil
+}
+
 // applyTheme applies a theme to all styles
 func applyTheme(theme ThemeColors) {
 	colorPrimary = lipgloss.Color(theme.Primary)
@@ -208,12 +327,41 @@ func applyTheme(theme ThemeColors) {
 	colorForeground = lipgloss.Color(theme.Foreground)
 	colorAccent = lipgloss.Color(theme.Accent)
 	colorError = lipgloss.Color(theme.Error)
+	colorSelected = colorPrimary
+	colorFocused = colorAccent
 
 	// Update all styles
 	titleStyle = titleStyle.Foreground(colorPrimary)
 	statusStyle = statusStyle.Foreground(colorDimmed)
-	selectedStyle = selectedStyle.Foreground(colorPrimary)
-	// ... update other styles as needed
+	selectedStyle = selectedStyle.Foreground(colorSelected)
+	focusedStyle = focusedStyle.Foreground(colorFocused)
+	highlightStyle = highlightStyle.Foreground(colorAccent)
+	listItemStyle = listItemStyle.Foreground(colorForeground)
+	listSelectedStyle = listSelectedStyle.Foreground(colorSelected)
+	listCursorStyle = listCursorStyle.Foreground(colorAccent)
+	dialogBoxStyle = dialogBoxStyle.BorderForeground(colorPrimary)
+	dialogTitleStyle = dialogTitleStyle.Foreground(colorPrimary)
+	errorStyle = errorStyle.Foreground(colorError)
+	successStyle = successStyle.Foreground(colorAccent)
+	tableHeaderStyle = tableHeaderStyle.Foreground(colorPrimary)
+	tableSelectedStyle = tableSelectedStyle.Foreground(colorSelected)
+	activeTabStyle = activeTabStyle.Background(colorPrimary)
+	detailPanelStyle = detailPanelStyle.BorderForeground(colorSecondary)
+	listTitleStyle = listTitleStyle.Foreground(colorPrimary)
+	detailTitleStyle = detailTitleStyle.Foreground(colorSecondary)
+	actionStyle = actionStyle.Foreground(colorAccent)
+	helpSectionStyle = helpSectionStyle.Foreground(colorPrimary)
+	helpKeyStyle = helpKeyStyle.Foreground(colorAccent)
+	statusSuccessStyle = statusSuccessStyle.Foreground(colorAccent)
+	statusFailureStyle = statusFailureStyle.Foreground(colorError)
+	mdHeadingStyle = mdHeadingStyle.Foreground(colorPrimary)
+	mdTaskDoneStyle = mdTaskDoneStyle.Foreground(colorAccent)
+	diffAddStyle = diffAddStyle.Foreground(colorAccent)
+	diffDelStyle = diffDelStyle.Foreground(colorError)
+	diffHunkStyle = diffHunkStyle.Foreground(colorSecondary)
+	syntaxKeywordStyle = syntaxKeywordStyle.Foreground(colorSecondary)
+	syntaxNumberStyle = syntaxNumberStyle.Foreground(colorPrimary)
+	logGroupStyle = logGroupStyle.Foreground(colorSecondary)
 }
 
 // getTheme returns the current theme colors
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tabs.go - Tab Bar and Custom Tabs
+// Purpose: The order and titles of the tabs, and the custom tabs declared in
+// config.yaml. A custom tab shows a saved query in the list and detail view
+// of its kind, scoped to the current repo like the built-in tabs:
+//
+//	tabs:
+//	  - name: Review requests
+//	    kind: prs
+//	    query: review-requested:@me is:open
+//	    sort: updated
+//	    columns: [author, branch, updated]
+//	  - name: Failing main
+//	    kind: runs
+//	    query: branch:main status:failure
+//
+// Messages for a kind of view reach every tab of that kind, or the active
+// one when only the view that asked should handle them (see update.go).
+// When to extend: Add a kind to tabKinds and teach its view to run a saved query
+
+// builtinTabs are the tabs of every session, in tab bar order
+var builtinTabs = []ViewType{ViewPullRequests, ViewIssues, ViewRepositories, ViewActions, ViewGists, ViewSearch, ViewNotifications, ViewDashboard}
+
+// tabTitles are the titles of the built-in tabs
+var tabTitles = map[ViewType]string{
+	ViewPullRequests:  "Pull Requests",
+	ViewIssues:        "Issues",
+	ViewRepositories:  "Repositories",
+	ViewActions:       "Actions",
+	ViewGists:         "Gists",
+	ViewSearch:        "Search",
+	ViewNotifications: "Notifications",
+	ViewDashboard:     "Dashboard",
+}
+
+// tabKinds maps the kind of a custom tab to the built-in tab whose view it uses
+var tabKinds = map[string]ViewType{
+	"prs":    ViewPullRequests,
+	"issues": ViewIssues,
+	"runs":   ViewActions,
+}
+
+// tabColumns are the columns the rows of each kind of tab can show
+var tabColumns = map[ViewType][]string{
+	ViewPullRequests: {"author", "updated", "created", "branch", "base", "review", "state"},
+	ViewIssues:       {"author", "updated", "created", "labels", "assignees", "milestone", "state"},
+	ViewActions:      {"branch", "created", "number", "sha", "status"},
+}
+
+// tabSorts are the sort: values of GitHub search; each may end in -asc or -desc
+var tabSorts = []string{"created", "updated", "comments", "reactions", "interactions"}
+
+// validateTabs checks the custom tabs of a config
+func validateTabs(tabs []TabConfig) error {
+	for i, tab := range tabs {
+		if tab.Name == "" {
+			return fmt.Errorf("tab %d has no name", i+1)
+		}
+		kind, ok := tabKinds[tab.Kind]
+		if !ok {
+			return fmt.Errorf("tab %q: unknown kind %q (prs, issues, runs)", tab.Name, tab.Kind)
+		}
+		if kind == ViewActions {
+			if tab.Sort != "" {
+				return fmt.Errorf("tab %q: runs are always newest first and can't be sorted", tab.Name)
+			}
+			if _, _, err := parseRunsQuery(tab.Query); err != nil {
+				return fmt.Errorf("tab %q: %w", tab.Name, err)
+			}
+		} else if tab.Sort != "" {
+			field := strings.TrimSuffix(strings.TrimSuffix(tab.Sort, "-asc"), "-desc")
+			if !containsString(tabSorts, field) {
+				return fmt.Errorf("tab %q: unknown sort %q (%s)", tab.Name, tab.Sort, strings.Join(tabSorts, ", "))
+			}
+		}
+		for _, column := range tab.Columns {
+			if !containsString(tabColumns[kind], column) {
+				return fmt.Errorf("tab %q: unknown column %q (%s)", tab.Name, column, strings.Join(tabColumns[kind], ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// containsString reports whether list has s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// addCustomTabs puts the built-in tabs and then the custom ones in the tab
+// bar, creating a view for each custom tab
+func (m *model) addCustomTabs(tabs []TabConfig) {
+	m.tabs = append([]ViewType(nil), builtinTabs...)
+	m.customTabs = make(map[ViewType]TabConfig)
+	for i, tab := range tabs {
+		vt := ViewCustom + ViewType(i)
+		m.customTabs[vt] = tab
+		m.views[vt] = newTabView(vt, tab)
+		m.tabs = append(m.tabs, vt)
+	}
+}
+
+// newTabView creates the view of custom tab vt. It starts blurred like
+// every tab but the first; switching to it focuses it.
+func newTabView(vt ViewType, tab TabConfig) View {
+	var view View
+	switch tabKinds[tab.Kind] {
+	case ViewIssues:
+		v := NewIssueView()
+		v.tab, v.title, v.saved = vt, tab.Name, &tab
+		if len(tab.Columns) > 0 {
+			v.columns = tab.Columns
+		}
+		view = v
+	case ViewActions:
+		v := NewActionsView()
+		v.tab, v.title, v.saved = vt, tab.Name, &tab
+		if len(tab.Columns) > 0 {
+			v.columns = tab.Columns
+		}
+		view = v
+	default:
+		v := NewPullRequestView()
+		v.tab, v.title, v.saved = vt, tab.Name, &tab
+		if len(tab.Columns) > 0 {
+			v.columns = tab.Columns
+		}
+		view = v
+	}
+	view.Blur()
+	return view
+}
+
+// tabQuery is the search query of a custom tab, with its sort
+func tabQuery(tab TabConfig) string {
+	if tab.Sort == "" {
+		return tab.Query
+	}
+	return strings.TrimSpace(tab.Query + " sort:" + tab.Sort)
+}
+
+// fetchTabPage fetches the page after cursor of custom tab vt in repo
+func fetchTabPage(vt ViewType, tab TabConfig, repo, cursor string) tea.Cmd {
+	switch tabKinds[tab.Kind] {
+	case ViewIssues:
+		return fetchIssuesMatching(vt, repo, tabQuery(tab), cursor)
+	case ViewActions:
+		return fetchWorkflowRunsMatching(vt, repo, tabQuery(tab), cursor)
+	default:
+		return fetchPullRequestsMatching(vt, repo, tabQuery(tab), cursor)
+	}
+}
+
+// tabTitle is the title of vt in the tab bar
+func (m model) tabTitle(vt ViewType) string {
+	if tab, ok := m.customTabs[vt]; ok {
+		return tab.Name
+	}
+	return tabTitles[vt]
+}
+
+// customTabViews are the custom tabs, in tab bar order
+func (m model) customTabViews() []ViewType {
+	var vts []ViewType
+	for _, vt := range m.tabs {
+		if _, ok := m.customTabs[vt]; ok {
+			vts = append(vts, vt)
+		}
+	}
+	return vts
+}
+
+// nextCustomTab is the custom tab 9 goes to: the first one, or the one
+// after the active custom tab, wrapping around. ok is false without any.
+func (m model) nextCustomTab() (vt ViewType, ok bool) {
+	custom := m.customTabViews()
+	if len(custom) == 0 {
+		return 0, false
+	}
+	for i, tab := range custom {
+		if tab == m.activeView {
+			return custom[(i+1)%len(custom)], true
+		}
+	}
+	return custom[0], true
+}
+
+// nextTab is the tab offset tabs after the active one, wrapping around
+func (m model) nextTab(offset int) ViewType {
+	current := 0
+	for i, vt := range m.tabs {
+		if vt == m.activeView {
+			current = i
+		}
+	}
+	n := len(m.tabs)
+	return m.tabs[((current+offset)%n+n)%n]
+}
+
+// tabsOfKind are the built-in tab kind and the custom tabs shown with its view
+func (m model) tabsOfKind(kind ViewType) []ViewType {
+	vts := []ViewType{kind}
+	for _, vt := range m.customTabViews() {
+		if tabKinds[m.customTabs[vt].Kind] == kind {
+			vts = append(vts, vt)
+		}
+	}
+	return vts
+}
+
+// kindView is the active tab if it is of kind, or else the built-in tab of
+// kind: where the result of something started in a view of kind goes
+func (m model) kindView(kind ViewType) ViewType {
+	if tab, ok := m.customTabs[m.activeView]; ok && tabKinds[tab.Kind] == kind {
+		return m.activeView
+	}
+	return kind
+}
+
+// updateViews hands msg to the views vts
+func (m *model) updateViews(msg tea.Msg, vts ...ViewType) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, vt := range vts {
+		if view, ok := m.views[vt]; ok {
+			updatedView, cmd := view.Update(msg)
+			m.views[vt] = updatedView
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateCustomTab hands a page of custom tab vt to its view. Unlike the
+// built-in tabs, the rows are kept only by the view.
+func (m *model) updateCustomTab(vt ViewType, msg tea.Msg, count int, noun string, err error) tea.Cmd {
+	m.loading = false
+	m.lastSync = time.Now()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error loading %s: %v", m.tabTitle(vt), err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Loaded %d %s in %s", count, noun, m.tabTitle(vt))
+	}
+	return m.updateViews(msg, vt)
+}
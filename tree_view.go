@@ -34,6 +34,9 @@ const (
 	TreeItemPlugin                       // A plugin
 	TreeItemPluginFile                   // File within a plugin
 	TreeItemDirectory                    // Directory/folder
+	TreeItemDiffFile                     // File in a PR diff
+	TreeItemJob                          // Job of a workflow run
+	TreeItemStep                         // Step of a job
 )
 
 // TreeConfig holds configuration for tree rendering
@@ -94,7 +97,7 @@ func renderTreeBranches(item TreeItem, config TreeConfig) string {
 func getExpandCollapseIcon(item TreeItem, config TreeConfig) string {
 	// Only show expand/collapse for items that can have children
 	switch item.Type {
-	case TreeItemCategory, TreeItemGist, TreeItemPlugin, TreeItemDirectory:
+	case TreeItemCategory, TreeItemGist, TreeItemPlugin, TreeItemDirectory, TreeItemJob:
 		if item.Expanded {
 			return config.CollapseIcon
 		}
@@ -225,7 +228,7 @@ func renderTreeView(state *TreeViewState, width, height int) string {
 			style = listTitleStyle
 		case TreeItemGist, TreeItemPlugin:
 			style = highlightStyle
-		case TreeItemGistFile, TreeItemPluginFile:
+		case TreeItemGistFile, TreeItemPluginFile, TreeItemStep:
 			style = dimmedStyle
 		}
 
@@ -330,6 +333,121 @@ func BuildGistTree(gists []Gist, expandedGists map[string]bool) []TreeItem {
 	return buildTreeItemsRecursive(rootItems, 0, []bool{}, expandedGists, getChildren, getItemKey)
 }
 
+// BuildDiffTree builds a directory tree of the files in a diff
+// Directories are always expanded, and chains of single directories are
+// merged ("cmd/gh-tui/"). File items carry their index into files as Data.
+func BuildDiffTree(files []DiffFile) []TreeItem {
+	type node struct {
+		name     string
+		file     int // -1 for directories
+		children []*node
+	}
+
+	root := &node{file: -1}
+	for i, file := range files {
+		parts := strings.Split(file.Path(), "/")
+		dir := root
+		for _, part := range parts[:len(parts)-1] {
+			var next *node
+			for _, child := range dir.children {
+				if child.file < 0 && child.name == part {
+					next = child
+					break
+				}
+			}
+			if next == nil {
+				next = &node{name: part, file: -1}
+				dir.children = append(dir.children, next)
+			}
+			dir = next
+		}
+		dir.children = append(dir.children, &node{name: parts[len(parts)-1], file: i})
+	}
+
+	toItems := func(nodes []*node) []TreeItem {
+		items := make([]TreeItem, len(nodes))
+		for i, n := range nodes {
+			if n.file >= 0 {
+				items[i] = TreeItem{Type: TreeItemDiffFile, Name: n.name, Data: n.file}
+				continue
+			}
+			name := n.name + "/"
+			for len(n.children) == 1 && n.children[0].file < 0 {
+				n = n.children[0]
+				name += n.name + "/"
+			}
+			items[i] = TreeItem{Type: TreeItemDirectory, Name: name, Data: n}
+		}
+		return items
+	}
+
+	getChildren := func(item TreeItem) []TreeItem {
+		if n, ok := item.Data.(*node); ok {
+			return toItems(n.children)
+		}
+		return nil
+	}
+
+	getItemKey := func(item TreeItem) string {
+		if item.Type == TreeItemDirectory {
+			return "dir"
+		}
+		return ""
+	}
+
+	return buildTreeItemsRecursive(toItems(root.children), 0, []bool{}, map[string]bool{"dir": true}, getChildren, getItemKey)
+}
+
+// BuildRunTree builds a tree of a run's jobs with their steps
+// Jobs are keyed by ID in expandedJobs. Items carry *WorkflowJob or
+// *WorkflowStep as Data, pointing into jobs.
+func BuildRunTree(jobs []WorkflowJob, expandedJobs map[string]bool) []TreeItem {
+	rootItems := make([]TreeItem, len(jobs))
+	for i := range jobs {
+		job := &jobs[i]
+		rootItems[i] = TreeItem{
+			Type: TreeItemJob,
+			Name: formatRunTreeName(job.Status, job.Conclusion, job.Name, formatElapsed(job.StartedAt, job.CompletedAt)),
+			Data: job,
+		}
+	}
+
+	getChildren := func(item TreeItem) []TreeItem {
+		job, ok := item.Data.(*WorkflowJob)
+		if !ok {
+			return nil
+		}
+		children := make([]TreeItem, len(job.Steps))
+		for i := range job.Steps {
+			step := &job.Steps[i]
+			children[i] = TreeItem{
+				Type: TreeItemStep,
+				Name: formatRunTreeName(step.Status, step.Conclusion, step.Name, formatElapsed(step.StartedAt, step.CompletedAt)),
+				Data: step,
+			}
+		}
+		return children
+	}
+
+	getItemKey := func(item TreeItem) string {
+		if job, ok := item.Data.(*WorkflowJob); ok {
+			return fmt.Sprintf("%d", job.ID)
+		}
+		return ""
+	}
+
+	return buildTreeItemsRecursive(rootItems, 0, []bool{}, expandedJobs, getChildren, getItemKey)
+}
+
+// formatRunTreeName formats a job or step as "✓ build 1m22s"
+func formatRunTreeName(status, conclusion, name, elapsed string) string {
+	line := formatStatusIcon(status, conclusion) + " " + name
+	if elapsed != "" {
+		line += "  " + elapsed
+	}
+	return line
+}
+
 // formatGistName formats a gist for tree display
 func formatGistName(gist Gist) string {
 	name := gist.Description
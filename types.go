@@ -36,6 +36,19 @@ type model struct {
 	// View management
 	activeView ViewType
 	views      map[ViewType]View
+	tabs       []ViewType             // Tab bar order (see tabs.go)
+	customTabs map[ViewType]TabConfig // Custom tabs from config by their view
+
+	// Repository context ("" means the repo of the working directory)
+	currentRepo string
+	repoPicker  *Picker
+
+	// Views opened over the tabs, topmost last (see overlay.go)
+	overlays []View
+
+	// Cache state
+	cachedAt       map[ViewType]time.Time // Set while a view shows cached data
+	pendingRefresh map[ViewType]bool      // Lazily loaded views not yet fetched
 
 	// UI state
 	loading  bool
@@ -68,6 +81,15 @@ type Config struct {
 
 	// Logging
 	Logging LogConfig
+
+	// GitHub backend
+	GitHub GitHubConfig
+
+	// Local git integration
+	Git GitConfig
+
+	// Custom tabs, after the built-in ones
+	Tabs []TabConfig
 }
 
 // ThemeColors defines a color theme
@@ -100,7 +122,7 @@ type UIConfig struct {
 // PerformanceConfig defines performance settings
 type PerformanceConfig struct {
 	LazyLoading     bool
-	CacheSize       int
+	CacheSize       int // Lists kept in the on-disk cache; negative disables it, 0 is the default
 	AsyncOperations bool
 }
 
@@ -111,6 +133,28 @@ type LogConfig struct {
 	File    string
 }
 
+// GitHubConfig selects the backend used to talk to GitHub
+type GitHubConfig struct {
+	Backend     string // gh, api, fake
+	BaseURL     string // REST root for the api backend (GitHub Enterprise: https://host/api/v3)
+	FixturesDir string // JSON fixtures for the fake backend
+}
+
+// GitConfig defines how gh-tui works with the local clone
+type GitConfig struct {
+	WorktreeDir string // Where PR worktrees are created; relative paths are relative to the clone
+}
+
+// TabConfig is a custom tab: a saved query shown in the list and detail
+// view of its kind (see tabs.go)
+type TabConfig struct {
+	Name    string   // Title in the tab bar
+	Kind    string   // prs, issues or runs
+	Query   string   // GitHub search syntax for prs and issues, e.g. "review-requested:@me is:open"; qualifiers like branch:main for runs
+	Sort    string   // GitHub search sort for prs and issues, e.g. "updated" or "created-asc"
+	Columns []string // Shown after the title of each row, e.g. [author, updated]
+}
+
 // Custom message types
 // Add your application-specific messages here
 
@@ -122,6 +166,234 @@ type statusMsg struct {
 	message string
 }
 
+// prDetailLoadedMsg carries the detail of a single PR
+type prDetailLoadedMsg struct {
+	repo   string // Repo the PR is in
+	number int
+	detail PullRequestDetail
+	err    error
+}
+
+// issueDetailLoadedMsg carries the body and timeline of a single issue
+type issueDetailLoadedMsg struct {
+	repo   string // Repo the issue is in
+	number int
+	detail IssueDetail
+	err    error
+}
+
+// prDiffLoadedMsg carries the unified diff of a single PR
+type prDiffLoadedMsg struct {
+	repo   string // Repo the PR is in
+	number int
+	diff   string
+	err    error
+}
+
+// reviewSubmittedMsg reports the result of submitting a PR review
+type reviewSubmittedMsg struct {
+	repo   string // Repo the PR is in
+	number int
+	event  string
+	err    error
+}
+
+// mergeInfoLoadedMsg carries what the merge dialog needs to know about a PR
+type mergeInfoLoadedMsg struct {
+	repo   string // Repo the PR is in
+	number int
+	info   MergeInfo
+	err    error
+}
+
+// prMergedMsg reports the result of merging a PR
+type prMergedMsg struct {
+	repo      string // Repo the PR is in
+	number    int
+	method    string
+	branch    string // Head branch deleted after the merge, "" when kept
+	branchErr error  // Deleting the branch failed (the merge itself succeeded)
+	err       error
+}
+
+// runDetailLoadedMsg carries the jobs and current status of a workflow run
+type runDetailLoadedMsg struct {
+	view   ViewType // Tab whose view asked; it watches the run
+	runID  int64
+	detail WorkflowRunDetail
+	err    error
+}
+
+// runPollMsg asks for a fresh look at a run that hasn't completed
+type runPollMsg struct {
+	view  ViewType // Tab whose view is watching the run
+	runID int64
+}
+
+// runActionStartedMsg is sent as a run action is requested, so the list
+// shows its outcome before GitHub confirms it
+type runActionStartedMsg struct {
+	runID  int64
+	action RunAction
+}
+
+// runActionDoneMsg reports the result of a run action
+type runActionDoneMsg struct {
+	runID  int64
+	number int // Run number, for display
+	action RunAction
+	err    error
+}
+
+// workflowListLoadedMsg carries the workflows of the Actions tab's repo
+type workflowListLoadedMsg struct {
+	workflows []Workflow
+	err       error
+}
+
+// dispatchFormLoadedMsg carries the workflow_dispatch inputs of a workflow at ref
+type dispatchFormLoadedMsg struct {
+	workflowID   int64
+	ref          string
+	inputs       []WorkflowInput
+	dispatchable bool     // The workflow has a workflow_dispatch trigger at ref
+	environments []string // Choices for environment inputs
+	err          error
+}
+
+// workflowDispatchedMsg reports the result of dispatching a workflow
+type workflowDispatchedMsg struct {
+	workflow Workflow
+	ref      string
+	run      WorkflowRun
+	found    bool // The new run showed up in time
+	err      error
+}
+
+// composerOptionsLoadedMsg carries what the issue composer offers to pick from
+// Any of the lists may be missing when err is set; the others still apply.
+type composerOptionsLoadedMsg struct {
+	repo       string
+	labels     []Label
+	assignees  []string
+	milestones []Milestone
+	templates  []IssueTemplate
+	err        error
+}
+
+// issueCreatedMsg reports the result of creating an issue
+type issueCreatedMsg struct {
+	repo  string
+	issue Issue
+	err   error
+}
+
+// commentAddedMsg reports the result of commenting on an issue or PR
+type commentAddedMsg struct {
+	repo   string
+	number int
+	err    error
+}
+
+// issueOptionsLoadedMsg carries the choices of one issue field picker
+type issueOptionsLoadedMsg struct {
+	repo       string
+	field      issueField
+	labels     []Label
+	assignees  []string
+	milestones []Milestone
+	err        error
+}
+
+// issueEditedMsg carries an issue after its labels, assignees or milestone changed
+type issueEditedMsg struct {
+	repo  string
+	issue Issue
+	edit  IssueEdit
+	err   error
+}
+
+// searchResultsMsg carries the results of one kind of search for query
+type searchResultsMsg struct {
+	query  string
+	kind   searchKind
+	issues []SearchIssue
+	repos  []Repository
+	code   []CodeResult
+	total  int // Matches on GitHub, which may be more than were returned
+	err    error
+}
+
+// showIssueMsg opens an issue in the Issues tab, switching to its repo
+type showIssueMsg struct {
+	repo  string
+	issue Issue
+}
+
+// showPullRequestMsg opens a PR in the Pull Requests tab, switching to its repo
+type showPullRequestMsg struct {
+	repo string
+	pr   PullRequest
+}
+
+// showRepoMsg selects a repository in the Repositories tab
+type showRepoMsg struct {
+	repo Repository
+}
+
+// showFilteredMsg opens a list tab of repo with its filter set to query, or
+// the Search tab searching for query
+type showFilteredMsg struct {
+	view  ViewType
+	repo  string
+	query string
+}
+
+// notificationsLoadedMsg carries a page of the notifications inbox
+type notificationsLoadedMsg struct {
+	participating bool
+	notifications []Notification
+	page          PageInfo
+	appended      bool
+	err           error
+}
+
+// notificationDoneMsg reports the result of marking a thread read or done,
+// or unsubscribing from it
+type notificationDoneMsg struct {
+	thread Notification
+	action string // read, done, unsubscribe
+	err    error
+}
+
+// dashboardTileMsg carries what one tile of the dashboard loaded
+type dashboardTileMsg struct {
+	tile   dashboardTile
+	issues []SearchIssue
+	runs   []dashboardRun
+	total  int
+	note   string // What couldn't be looked at, when the tile partly loaded
+	err    error
+}
+
+// codeFileLoadedMsg carries the content of a file opened from a code search
+type codeFileLoadedMsg struct {
+	repo    string
+	path    string
+	content string
+	err     error
+}
+
+// repoChangedMsg re-scopes the repo-specific tabs to another repo
+type repoChangedMsg struct {
+	repo string
+}
+
+// repoDetectedMsg reports the repo of the working directory
+type repoDetectedMsg struct {
+	repo string
+}
+
 type resizeMsg struct {
 	width  int
 	height int
@@ -136,7 +408,10 @@ const (
 	ViewRepositories
 	ViewActions
 	ViewGists
-	ViewPlugins // Added for future use
+	ViewSearch
+	ViewNotifications
+	ViewDashboard
+	ViewCustom // First custom tab from config; the others follow it
 )
 
 // View interface for all view implementations
@@ -200,6 +475,69 @@ type WorkflowRun struct {
 	URL        string    `json:"url"`
 }
 
+// Workflow is a workflow file of a repo
+type Workflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`  // .github/workflows/<file>.yml
+	State string `json:"state"` // active, disabled_manually, ...
+	URL   string `json:"url"`
+}
+
+// WorkflowInput is an input of a workflow's workflow_dispatch trigger
+type WorkflowInput struct {
+	Name        string
+	Description string
+	Type        string // string, boolean, choice, environment or number
+	Required    bool
+	Default     string
+	Options     []string // Choices of a choice input
+}
+
+// Artifact is a file archive uploaded by a workflow run
+type Artifact struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	SizeInBytes int64     `json:"sizeInBytes"` // Size of the zip archive
+	Expired     bool      `json:"expired"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// RunAction is a re-run or cancellation of a workflow run
+type RunAction struct {
+	Kind  string // "rerun", "rerun-failed" or "cancel"
+	Debug bool   // Re-run with debug logging
+}
+
+// WorkflowRunDetail is a run's current state with its jobs, fetched when a run is selected
+type WorkflowRunDetail struct {
+	Run  WorkflowRun   `json:"run"`
+	Jobs []WorkflowJob `json:"jobs"`
+}
+
+// WorkflowJob is a job of a workflow run
+type WorkflowJob struct {
+	ID          int64          `json:"databaseId"`
+	Name        string         `json:"name"`
+	Status      string         `json:"status"` // queued, in_progress, completed, waiting
+	Conclusion  string         `json:"conclusion"`
+	StartedAt   time.Time      `json:"startedAt"`
+	CompletedAt time.Time      `json:"completedAt"`
+	URL         string         `json:"url"`
+	Steps       []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep is a step of a job
+type WorkflowStep struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
 type Gist struct {
 	ID          string    `json:"id"`
 	Description string    `json:"description"`
@@ -210,17 +548,196 @@ type Gist struct {
 	URL         string    `json:"url"`
 }
 
+// Notification is a thread of the authenticated user's notifications inbox
+type Notification struct {
+	ID        string    `json:"id"`
+	Repo      string    `json:"repo"`   // owner/name
+	Reason    string    `json:"reason"` // review_requested, mention, ci_activity, ...
+	Unread    bool      `json:"unread"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Type      string    `json:"type"` // Of the subject: PullRequest, Issue, CheckSuite, Release, ...
+	Title     string    `json:"title"`
+	Number    int       `json:"number"` // Of the PR or issue; 0 for other subjects
+}
+
+// PullRequestDetail holds the extra PR data fetched when a PR is selected
+type PullRequestDetail struct {
+	Number         int                 `json:"number"`
+	Body           string              `json:"body"`
+	Additions      int                 `json:"additions"`
+	Deletions      int                 `json:"deletions"`
+	ChangedFiles   int                 `json:"changedFiles"`
+	Files          []PullRequestFile   `json:"files"`
+	ChecksState    string              `json:"checksState"` // Rollup: SUCCESS, FAILURE, PENDING, ERROR, EXPECTED
+	Checks         []PullRequestCheck  `json:"checks"`
+	ReviewRequests []string            `json:"reviewRequests"` // Requested user logins and team names
+	Reviews        []PullRequestReview `json:"reviews"`
+}
+
+// PullRequestFile is a file changed by a PR
+type PullRequestFile struct {
+	Path      string `json:"path"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// PullRequestCheck is a check run or commit status on the PR head
+type PullRequestCheck struct {
+	Name  string `json:"name"`
+	State string `json:"state"` // SUCCESS, FAILURE, PENDING, IN_PROGRESS, SKIPPED, ...
+	URL   string `json:"url"`
+}
+
+// PullRequestReview is the latest review by one reviewer
+type PullRequestReview struct {
+	Author      Author    `json:"author"`
+	State       string    `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// ReviewSubmission is a PR review as sent to the REST API
+type ReviewSubmission struct {
+	Event    string          `json:"event"` // APPROVE, REQUEST_CHANGES, COMMENT
+	Body     string          `json:"body,omitempty"`
+	Comments []ReviewComment `json:"comments,omitempty"`
+}
+
+// ReviewComment is a review comment on a line, or a range of lines, of the diff
+type ReviewComment struct {
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"` // LEFT (old file) or RIGHT (new file)
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+	Body      string `json:"body"`
+}
+
+// PendingReview holds review comments queued until the review is submitted
+type PendingReview struct {
+	Comments []ReviewComment
+}
+
+// MergeInfo is what the merge dialog needs to know about a PR and its repo
+type MergeInfo struct {
+	Number           int      `json:"number"`
+	Title            string   `json:"title"`
+	Body             string   `json:"body"`
+	State            string   `json:"state"`
+	IsDraft          bool     `json:"isDraft"`
+	Mergeable        string   `json:"mergeable"`        // MERGEABLE, CONFLICTING, UNKNOWN
+	MergeStateStatus string   `json:"mergeStateStatus"` // CLEAN, BLOCKED, BEHIND, DIRTY, UNSTABLE, HAS_HOOKS, DRAFT, UNKNOWN
+	ReviewDecision   string   `json:"reviewDecision"`
+	HeadRefName      string   `json:"headRefName"`
+	BaseRefName      string   `json:"baseRefName"`
+	HeadRefOid       string   `json:"headRefOid"`
+	CanDeleteBranch  bool     `json:"canDeleteBranch"` // Viewer may delete the head branch (false for forks)
+	AllowedMethods   []string `json:"allowedMethods"`  // merge, squash, rebase - as allowed by the repo
+}
+
+// MergeRequest is a merge as chosen in the merge dialog
+type MergeRequest struct {
+	Method string // merge, squash, rebase
+	Title  string // Commit title; empty uses GitHub's default
+	Body   string // Commit message
+	SHA    string // Head the dialog was opened on; GitHub refuses the merge if it moved
+}
+
+// IssueDetail holds the body and timeline fetched when an issue is selected
+type IssueDetail struct {
+	Number        int          `json:"number"`
+	Body          string       `json:"body"`
+	Timeline      []IssueEvent `json:"timeline"`      // Oldest first
+	EarlierEvents int          `json:"earlierEvents"` // Events before the first one fetched
+}
+
+// IssueEvent is a comment or an event in an issue's timeline
+type IssueEvent struct {
+	Type      string          `json:"type"` // comment, labeled, unlabeled, assigned, unassigned, cross-referenced, closed, reopened, renamed, milestoned, demilestoned
+	Actor     Author          `json:"actor"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Body      string          `json:"body,omitempty"`      // comment
+	Label     *Label          `json:"label,omitempty"`     // labeled, unlabeled
+	Assignee  string          `json:"assignee,omitempty"`  // assigned, unassigned
+	Source    *IssueReference `json:"source,omitempty"`    // cross-referenced
+	Milestone string          `json:"milestone,omitempty"` // milestoned, demilestoned
+	From      string          `json:"from,omitempty"`      // renamed
+	To        string          `json:"to,omitempty"`        // renamed
+}
+
+// IssueReference is the issue or PR a cross-reference came from
+type IssueReference struct {
+	Repo          string `json:"repo"`
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	State         string `json:"state"`
+	IsPullRequest bool   `json:"isPullRequest"`
+	URL           string `json:"url"`
+}
+
+// NewIssue is an issue as filled in by the composer, in the REST API's shape
+type NewIssue struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone int      `json:"milestone,omitempty"` // Milestone number, 0 for none
+}
+
+// IssueEdit is a change to the labels, assignees and milestone of an issue,
+// as a diff against what it had
+type IssueEdit struct {
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+	Milestone       *int // New milestone number, 0 to clear; nil leaves it as is
+}
+
+// IssueTemplate is a template from .github/ISSUE_TEMPLATE, see composer.go
+type IssueTemplate struct {
+	Name      string
+	About     string
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+}
+
+// SearchIssue is an issue or PR found by a search, in any repo
+type SearchIssue struct {
+	Issue
+	Repo        string       // owner/name
+	PullRequest *PullRequest // Set for pull requests
+}
+
+// CodeResult is a file found by a code search, with the fragments of it
+// that matched
+type CodeResult struct {
+	Repo      string
+	Path      string
+	URL       string
+	Fragments []CodeFragment
+}
+
+// CodeFragment is an excerpt of a file matched by a code search
+type CodeFragment struct {
+	Text    string
+	Matches [][2]int // Byte offsets of the matched terms in Text
+}
+
 // Helper types
 type Author struct {
 	Login string `json:"login"`
 }
 
 type Label struct {
-	Name string `json:"name"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"` // Hex without the #, when known
 }
 
 type Milestone struct {
-	Title string `json:"title"`
+	Number int    `json:"number,omitempty"`
+	Title  string `json:"title"`
 }
 
 type Language struct {
@@ -231,30 +748,60 @@ type GistFile struct {
 	Filename string `json:"filename"`
 }
 
+// PageInfo describes where a loaded page sits in a paginated list
+type PageInfo struct {
+	EndCursor   string // Opaque cursor for the next page
+	HasNextPage bool
+	TotalCount  int // -1 when the API doesn't report a total
+}
+
 // GitHub-specific messages
+// appended is true when the message carries a next page rather than a reload;
+// cachedAt is set when the data was served from the on-disk cache
 type prLoadedMsg struct {
-	prs []PullRequest
-	err error
+	tab      ViewType // Custom tab the page is for; zero for the built-in tab
+	repo     string   // Repo the page is of
+	prs      []PullRequest
+	page     PageInfo
+	appended bool
+	cachedAt time.Time
+	err      error
 }
 
 type issuesLoadedMsg struct {
-	issues []Issue
-	err    error
+	tab      ViewType // Custom tab the page is for; zero for the built-in tab
+	repo     string   // Repo the page is of
+	issues   []Issue
+	page     PageInfo
+	appended bool
+	cachedAt time.Time
+	err      error
 }
 
 type reposLoadedMsg struct {
-	repos []Repository
-	err   error
+	repos    []Repository
+	page     PageInfo
+	appended bool
+	cachedAt time.Time
+	err      error
 }
 
 type workflowsLoadedMsg struct {
-	runs []WorkflowRun
-	err  error
+	tab      ViewType // Custom tab the page is for; zero for the built-in tab
+	repo     string   // Repo the page is of
+	runs     []WorkflowRun
+	page     PageInfo
+	appended bool
+	cachedAt time.Time
+	err      error
 }
 
 type gistsLoadedMsg struct {
-	gists []Gist
-	err   error
+	gists    []Gist
+	page     PageInfo
+	appended bool
+	cachedAt time.Time
+	err      error
 }
 
 // Editor-related messages
@@ -42,6 +42,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = msg.message
 		return m, nil
 
+	// Repository context
+	case repoChangedMsg:
+		return m, m.setRepo(msg.repo)
+
+	case repoDetectedMsg:
+		if m.currentRepo == "" {
+			m.currentRepo = msg.repo
+			saveRecentRepo(msg.repo)
+		}
+		return m, nil
+
+	// Overlays
+	case openOverlayMsg:
+		m.pushOverlay(msg.view)
+		return m, nil
+
+	case closeOverlayMsg:
+		m.popOverlay()
+		return m, nil
+
 	// Landing page animation tick
 	case landingTickMsg:
 		if m.showLandingPage && m.landingPage != nil {
@@ -52,36 +72,125 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// GitHub data loaded messages - forward to views
 	case prLoadedMsg:
+		if msg.tab != 0 {
+			return m, m.updateCustomTab(msg.tab, msg, len(msg.prs), "pull requests", msg.err)
+		}
+		if staleRepo(msg.repo, m.currentRepo) {
+			// Asked for before switching repos; the status line and cache
+			// age are the new repo's
+			return m, nil
+		}
 		m.loading = false
 		m.lastSync = time.Now()
 		if msg.err != nil {
-			m.err = msg.err
+			// A failed refresh keeps showing what is already loaded
+			if !msg.appended && len(m.pullRequests) == 0 {
+				m.err = msg.err
+			}
 			m.statusMsg = "Error loading PRs: " + msg.err.Error()
 		} else {
-			m.pullRequests = msg.prs
-			m.statusMsg = fmt.Sprintf("Loaded %d pull requests", len(msg.prs))
+			if msg.appended {
+				m.pullRequests = append(m.pullRequests, msg.prs...)
+				m.statusMsg = fmt.Sprintf("Loaded %d more pull requests", len(msg.prs))
+			} else {
+				m.pullRequests = msg.prs
+				m.noteCached(ViewPullRequests, msg.cachedAt)
+				m.statusMsg = fmt.Sprintf("Loaded %d pull requests", len(msg.prs))
+			}
 		}
-		// Forward to PR view
+		// Forward to PR view (which starts loading the selected PR's detail)
 		if view, ok := m.views[ViewPullRequests]; ok {
-			updatedView, _ := view.Update(msg)
+			updatedView, cmd := view.Update(msg)
 			m.views[ViewPullRequests] = updatedView
+			return m, cmd
+		}
+		return m, nil
+
+	// Details are kept by every tab showing the PR or issue
+	case prDetailLoadedMsg:
+		return m, m.updateViews(msg, m.tabsOfKind(ViewPullRequests)...)
+
+	case issueDetailLoadedMsg:
+		return m, m.updateViews(msg, m.tabsOfKind(ViewIssues)...)
+
+	case reviewSubmittedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Review failed: " + msg.err.Error()
+		} else {
+			m.statusMsg = fmt.Sprintf("Review submitted on #%d (%s)", msg.number, reviewEventLabel(msg.event))
+		}
+		// The dialog closes itself; the PR views reload the PR's reviews
+		cmd := m.updateViews(msg, m.tabsOfKind(ViewPullRequests)...)
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	case prMergedMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = "Merge failed: " + msg.err.Error()
+		case msg.branchErr != nil:
+			m.statusMsg = fmt.Sprintf("Merged #%d, but deleting %s failed: %v", msg.number, msg.branch, msg.branchErr)
+		case msg.branch != "":
+			m.statusMsg = fmt.Sprintf("Merged #%d (%s) and deleted %s", msg.number, mergeMethodLabel(msg.method), msg.branch)
+		default:
+			m.statusMsg = fmt.Sprintf("Merged #%d (%s)", msg.number, mergeMethodLabel(msg.method))
+		}
+		// The dialog closes itself; the PR views mark the PR merged
+		cmd := m.updateViews(msg, m.tabsOfKind(ViewPullRequests)...)
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	case worktreeReadyMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = fmt.Sprintf("Checkout of #%d failed: %v", msg.number, msg.err)
+		case msg.note != "":
+			m.statusMsg = fmt.Sprintf("Worktree of #%d at %s %s", msg.number, msg.path, msg.note)
+		default:
+			m.statusMsg = fmt.Sprintf("Checked out #%d at %s", msg.number, msg.path)
+		}
+		return m.updateOverlays(msg)
+
+	case worktreeExecFinishedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Failed to open " + msg.path + ": " + msg.err.Error()
+		} else {
+			m.statusMsg = "Back from " + msg.path
 		}
 		return m, nil
 
 	case issuesLoadedMsg:
+		if msg.tab != 0 {
+			return m, m.updateCustomTab(msg.tab, msg, len(msg.issues), "issues", msg.err)
+		}
+		if staleRepo(msg.repo, m.currentRepo) {
+			// Asked for before switching repos; the status line and cache
+			// age are the new repo's
+			return m, nil
+		}
 		m.loading = false
 		m.lastSync = time.Now()
 		if msg.err != nil {
-			m.err = msg.err
+			// A failed refresh keeps showing what is already loaded
+			if !msg.appended && len(m.issues) == 0 {
+				m.err = msg.err
+			}
 			m.statusMsg = "Error loading issues: " + msg.err.Error()
 		} else {
-			m.issues = msg.issues
-			m.statusMsg = fmt.Sprintf("Loaded %d issues", len(msg.issues))
+			if msg.appended {
+				m.issues = append(m.issues, msg.issues...)
+				m.statusMsg = fmt.Sprintf("Loaded %d more issues", len(msg.issues))
+			} else {
+				m.issues = msg.issues
+				m.noteCached(ViewIssues, msg.cachedAt)
+				m.statusMsg = fmt.Sprintf("Loaded %d issues", len(msg.issues))
+			}
 		}
 		// Forward to Issues view
 		if view, ok := m.views[ViewIssues]; ok {
-			updatedView, _ := view.Update(msg)
+			updatedView, cmd := view.Update(msg)
 			m.views[ViewIssues] = updatedView
+			return m, cmd
 		}
 		return m, nil
 
@@ -89,11 +198,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.lastSync = time.Now()
 		if msg.err != nil {
-			m.err = msg.err
+			// A failed refresh keeps showing what is already loaded
+			if !msg.appended && len(m.repositories) == 0 {
+				m.err = msg.err
+			}
 			m.statusMsg = "Error loading repositories: " + msg.err.Error()
 		} else {
-			m.repositories = msg.repos
-			m.statusMsg = fmt.Sprintf("Loaded %d repositories", len(msg.repos))
+			if msg.appended {
+				m.repositories = append(m.repositories, msg.repos...)
+				m.statusMsg = fmt.Sprintf("Loaded %d more repositories", len(msg.repos))
+			} else {
+				m.repositories = msg.repos
+				m.noteCached(ViewRepositories, msg.cachedAt)
+				m.statusMsg = fmt.Sprintf("Loaded %d repositories", len(msg.repos))
+			}
 		}
 		// Forward to Repositories view
 		if view, ok := m.views[ViewRepositories]; ok {
@@ -102,32 +220,196 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case runActionDoneMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = fmt.Sprintf("Run #%d: %s failed: %v", msg.number, runActionLabel(msg.action), msg.err)
+		case msg.action.Kind == "cancel":
+			m.statusMsg = fmt.Sprintf("Run #%d is being cancelled", msg.number)
+		default:
+			m.statusMsg = fmt.Sprintf("Run #%d: %s requested", msg.number, runActionLabel(msg.action))
+		}
+		// The Actions views roll back their optimistic status on failure
+		return m, m.updateViews(msg, m.tabsOfKind(ViewActions)...)
+
+	case workflowDispatchedMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = fmt.Sprintf("Running %s failed: %v", msg.workflow.Name, msg.err)
+		case msg.found:
+			m.statusMsg = fmt.Sprintf("Started %s #%d on %s", msg.workflow.Name, msg.run.RunNumber, msg.ref)
+		default:
+			m.statusMsg = fmt.Sprintf("Dispatched %s on %s; the run hasn't shown up yet", msg.workflow.Name, msg.ref)
+		}
+		// The dialog closes itself; the Actions view it was opened from
+		// selects the new run
+		cmd := m.updateViews(msg, m.kindView(ViewActions))
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	case issueCreatedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Creating issue failed: " + msg.err.Error()
+		} else {
+			m.statusMsg = fmt.Sprintf("Created issue #%d", msg.issue.Number)
+		}
+		// The composer closes itself; the Issues view it was opened from
+		// shows the new issue
+		cmd := m.updateViews(msg, m.kindView(ViewIssues))
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	case commentAddedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Commenting on #%d failed: %v", msg.number, msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("Commented on #%d", msg.number)
+		}
+		cmd := m.updateViews(msg, m.tabsOfKind(ViewIssues)...)
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	case batchProgressMsg:
+		m.statusMsg = batchStatus(msg)
+		if !msg.finished {
+			return m, waitForBatch(msg.stream)
+		}
+		// The view that started the batch refreshes what changed
+		if view, ok := m.views[msg.view]; ok {
+			updatedView, cmd := view.Update(msg)
+			m.views[msg.view] = updatedView
+			return m, cmd
+		}
+		return m, nil
+
+	case issueEditedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Editing #%d failed: %v", msg.issue.Number, msg.err)
+		} else {
+			milestone := ""
+			if msg.issue.Milestone != nil {
+				milestone = msg.issue.Milestone.Title
+			}
+			m.statusMsg = fmt.Sprintf("Updated #%d: %s", msg.issue.Number, describeIssueEdit(msg.edit, milestone))
+		}
+		// The dialog closes itself; the Issues views update the issue in place
+		cmd := m.updateViews(msg, m.tabsOfKind(ViewIssues)...)
+		updated, overlayCmd := m.updateOverlays(msg)
+		return updated, tea.Batch(cmd, overlayCmd)
+
+	// Run details and polls go back to the Actions view watching the run
+	case runDetailLoadedMsg:
+		return m, m.updateViews(msg, msg.view)
+
+	case runPollMsg:
+		return m, m.updateViews(msg, msg.view)
+
+	case runActionStartedMsg, workflowListLoadedMsg:
+		return m, m.updateViews(msg, m.tabsOfKind(ViewActions)...)
+
 	case workflowsLoadedMsg:
+		if msg.tab != 0 {
+			return m, m.updateCustomTab(msg.tab, msg, len(msg.runs), "workflow runs", msg.err)
+		}
+		if staleRepo(msg.repo, m.currentRepo) {
+			// Asked for before switching repos; the status line and cache
+			// age are the new repo's
+			return m, nil
+		}
 		m.loading = false
 		m.lastSync = time.Now()
 		if msg.err != nil {
-			m.err = msg.err
+			// A failed refresh keeps showing what is already loaded
+			if !msg.appended && len(m.workflowRuns) == 0 {
+				m.err = msg.err
+			}
 			m.statusMsg = "Error loading workflow runs: " + msg.err.Error()
 		} else {
-			m.workflowRuns = msg.runs
-			m.statusMsg = fmt.Sprintf("Loaded %d workflow runs", len(msg.runs))
+			if msg.appended {
+				m.workflowRuns = append(m.workflowRuns, msg.runs...)
+				m.statusMsg = fmt.Sprintf("Loaded %d more workflow runs", len(msg.runs))
+			} else {
+				m.workflowRuns = msg.runs
+				m.noteCached(ViewActions, msg.cachedAt)
+				m.statusMsg = fmt.Sprintf("Loaded %d workflow runs", len(msg.runs))
+			}
 		}
-		// Forward to Actions view
+		// Forward to Actions view (which starts watching the selected run)
 		if view, ok := m.views[ViewActions]; ok {
-			updatedView, _ := view.Update(msg)
+			updatedView, cmd := view.Update(msg)
 			m.views[ViewActions] = updatedView
+			return m, cmd
+		}
+		return m, nil
+
+	// Search
+	case searchResultsMsg:
+		if view, ok := m.views[ViewSearch]; ok {
+			updatedView, cmd := view.Update(msg)
+			m.views[ViewSearch] = updatedView
+			return m, cmd
 		}
 		return m, nil
 
+	case showIssueMsg:
+		return m, m.showInView(ViewIssues, msg.repo, msg)
+
+	case showPullRequestMsg:
+		return m, m.showInView(ViewPullRequests, msg.repo, msg)
+
+	case showRepoMsg:
+		return m, m.showInView(ViewRepositories, "", msg)
+
+	case showFilteredMsg:
+		return m, m.showInView(msg.view, msg.repo, msg)
+
+	// Notifications
+	case notificationsLoadedMsg:
+		m.loading = false
+		m.lastSync = time.Now()
+		switch {
+		case msg.err != nil:
+			m.statusMsg = "Error loading notifications: " + msg.err.Error()
+		case msg.appended:
+			m.statusMsg = fmt.Sprintf("Loaded %d more notifications", len(msg.notifications))
+		default:
+			m.statusMsg = fmt.Sprintf("Loaded %d notifications", len(msg.notifications))
+		}
+		return m, m.updateViews(msg, ViewNotifications)
+
+	case notificationDoneMsg:
+		label := notificationActionLabels[msg.action]
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Couldn't %s %q: %v", label[0], truncateString(msg.thread.Title, 50), msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("%s %q", label[1], truncateString(msg.thread.Title, 50))
+		}
+		// The view puts back what it changed if the action failed
+		return m, m.updateViews(msg, ViewNotifications)
+
+	case dashboardTileMsg:
+		// Tiles show their own errors; one failing isn't worth the status bar
+		m.lastSync = time.Now()
+		return m, m.updateViews(msg, ViewDashboard)
+
 	case gistsLoadedMsg:
 		m.loading = false
 		m.lastSync = time.Now()
 		if msg.err != nil {
-			m.err = msg.err
+			// A failed refresh keeps showing what is already loaded
+			if !msg.appended && len(m.gists) == 0 {
+				m.err = msg.err
+			}
 			m.statusMsg = "Error loading gists: " + msg.err.Error()
 		} else {
-			m.gists = msg.gists
-			m.statusMsg = fmt.Sprintf("Loaded %d gists", len(msg.gists))
+			if msg.appended {
+				m.gists = append(m.gists, msg.gists...)
+				m.statusMsg = fmt.Sprintf("Loaded %d more gists", len(msg.gists))
+			} else {
+				m.gists = msg.gists
+				m.noteCached(ViewGists, msg.cachedAt)
+				m.statusMsg = fmt.Sprintf("Loaded %d gists", len(msg.gists))
+			}
 		}
 		// Forward to Gists view
 		if view, ok := m.views[ViewGists]; ok {
@@ -137,11 +419,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Anything else may be the result of an overlay's own fetch
+	if len(m.overlays) > 0 {
+		return m.updateOverlays(msg)
+	}
+
 	return m, nil
 }
 
 // Helper functions for message handling
 
+// noteCached records whether a view's first page was served from the cache
+func (m *model) noteCached(vt ViewType, cachedAt time.Time) {
+	if cachedAt.IsZero() {
+		delete(m.cachedAt, vt)
+		return
+	}
+	m.cachedAt[vt] = cachedAt
+}
+
+// showInView switches to vt, scoped to repo unless it is "", and hands it msg
+// to select what it names
+func (m *model) showInView(vt ViewType, repo string, msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	if repo != "" && repo != m.currentRepo {
+		cmds = append(cmds, m.setRepo(repo))
+	}
+	cmds = append(cmds, m.switchToView(vt))
+	if view, ok := m.views[vt]; ok {
+		updatedView, cmd := view.Update(msg)
+		m.views[vt] = updatedView
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
 // sendStatus creates a status message command
 func sendStatus(message string) tea.Cmd {
 	return func() tea.Msg {
@@ -28,6 +28,29 @@ func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The repo picker takes text input, so it gets keys before global bindings
+	if m.focusedComponent == "picker" && m.repoPicker != nil {
+		return m.handlePickerKeys(msg)
+	}
+
+	// Overlays get every key (they may take text input); ctrl+c still quits
+	if m.focusedComponent == "overlay" && len(m.overlays) > 0 {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.updateTopOverlay(msg)
+	}
+
+	// A filter prompt takes text input too
+	if view, ok := m.views[m.activeView].(filterableView); ok && m.focusedComponent == "main" && view.Filtering() {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		updatedView, cmd := view.Update(msg)
+		m.views[m.activeView] = updatedView
+		return m, cmd
+	}
+
 	// Global keybindings (work in all modes)
 	switch {
 	case key.Matches(msg, keys.Quit):
@@ -38,6 +61,10 @@ func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, keys.Refresh):
 		return m.refresh()
+
+	case key.Matches(msg, keys.SwitchRepo):
+		m.openRepoPicker()
+		return m, nil
 	}
 
 	// Mode-specific keybindings
@@ -67,13 +94,7 @@ func (m model) handleLandingPageKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.switchToView(ViewType(selectedIdx))
 
 			// Start loading GitHub data now that we're entering the app
-			return m, tea.Batch(
-				fetchPullRequests(""),
-				fetchIssues(""),
-				fetchRepositories(""),
-				fetchWorkflowRuns(""),
-				fetchGists(),
-			)
+			return m, m.loadInitialData()
 		}
 		return m, nil
 	}
@@ -104,40 +125,38 @@ func (m model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Tab switching
 	case "tab":
-		newView := (m.activeView + 1) % 5
-		m.switchToView(newView)
-		return m, nil
+		cmd := m.switchToView(m.nextTab(1))
+		return m, cmd
 
 	case "shift+tab":
-		var newView ViewType
-		if m.activeView == 0 {
-			newView = 4
-		} else {
-			newView = m.activeView - 1
+		cmd := m.switchToView(m.nextTab(-1))
+		return m, cmd
+
+	// Direct tab access, in tab bar order; 9 steps through the custom tabs
+	case "9":
+		if vt, ok := m.nextCustomTab(); ok {
+			return m, m.switchToView(vt)
 		}
-		m.switchToView(newView)
 		return m, nil
 
-	// Direct tab access
-	case "1":
-		m.switchToView(ViewPullRequests)
-		return m, nil
-	case "2":
-		m.switchToView(ViewIssues)
-		return m, nil
-	case "3":
-		m.switchToView(ViewRepositories)
-		return m, nil
-	case "4":
-		m.switchToView(ViewActions)
-		return m, nil
-	case "5":
-		m.switchToView(ViewGists)
-		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8":
+		n := int(msg.String()[0] - '1')
+		if n >= len(m.tabs) {
+			return m, nil
+		}
+		cmd := m.switchToView(m.tabs[n])
+		return m, cmd
 
 	// Refresh current view
 	case "r":
 		return m, m.refreshActiveView()
+
+	}
+
+	// Space marks the item under the cursor in lists with batch actions;
+	// other views get it as usual
+	if _, ok := m.views[m.activeView].(selectableView); ok && msg.String() == " " {
+		return m.toggleSelection()
 	}
 
 	// Delegate to active view
@@ -153,24 +172,12 @@ func (m model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // refreshActiveView refreshes the current view's data
 func (m model) refreshActiveView() tea.Cmd {
-	switch m.activeView {
-	case ViewPullRequests:
-		m.loading = true
-		return fetchPullRequests("")
-	case ViewIssues:
-		m.loading = true
-		return fetchIssues("")
-	case ViewRepositories:
-		m.loading = true
-		return fetchRepositories("")
-	case ViewActions:
-		m.loading = true
-		return fetchWorkflowRuns("")
-	case ViewGists:
-		m.loading = true
-		return fetchGists()
+	// The Actions tabs also check the selected run again without waiting
+	// for its next poll
+	if view, ok := m.views[m.activeView].(*ActionsView); ok {
+		return view.refresh()
 	}
-	return nil
+	return m.fetchViewData(m.activeView)
 }
 
 // Navigation helper functions
@@ -227,7 +234,9 @@ func (m model) selectItem() (tea.Model, tea.Cmd) {
 }
 
 func (m model) toggleSelection() (tea.Model, tea.Cmd) {
-	// Implement toggle selection
+	if view, ok := m.views[m.activeView].(selectableView); ok {
+		view.ToggleSelection()
+	}
 	return m, nil
 }
 
@@ -254,7 +263,8 @@ func (m model) refresh() (tea.Model, tea.Cmd) {
 }
 
 // switchToView changes the active view and manages focus
-func (m *model) switchToView(newView ViewType) {
+// It returns the fetch for a lazily loaded view that hasn't been refreshed yet
+func (m *model) switchToView(newView ViewType) tea.Cmd {
 	// Blur the old view
 	if oldView, ok := m.views[m.activeView]; ok {
 		oldView.Blur()
@@ -267,6 +277,12 @@ func (m *model) switchToView(newView ViewType) {
 	if view, ok := m.views[m.activeView]; ok {
 		view.Focus()
 	}
+
+	if m.pendingRefresh[newView] {
+		delete(m.pendingRefresh, newView)
+		return m.fetchViewData(newView)
+	}
+	return nil
 }
 
 // Key bindings definition
@@ -280,6 +296,8 @@ type keyMap struct {
 	Right   key.Binding
 	Select  key.Binding
 	Toggle  key.Binding
+
+	SwitchRepo key.Binding
 }
 
 var keys = keyMap{
@@ -319,4 +337,8 @@ var keys = keyMap{
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle"),
 	),
+	SwitchRepo: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "switch repo"),
+	),
 }
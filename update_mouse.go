@@ -78,6 +78,10 @@ func (m model) handleWheelUp(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if len(m.overlays) > 0 {
+		return m.updateTopOverlay(msg)
+	}
+
 	// Forward wheel event to the active view
 	if view, ok := m.views[m.activeView]; ok {
 		updatedView, cmd := view.Update(msg)
@@ -95,6 +99,10 @@ func (m model) handleWheelDown(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if len(m.overlays) > 0 {
+		return m.updateTopOverlay(msg)
+	}
+
 	// Forward wheel event to the active view
 	if view, ok := m.views[m.activeView]; ok {
 		updatedView, cmd := view.Update(msg)
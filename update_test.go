@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModelDropsStaleRepoPages(t *testing.T) {
+	newTestClient(t)
+	t.Setenv("HOME", t.TempDir()) // Recent repos
+
+	tests := []struct {
+		name string
+		msg  tea.Msg
+	}{
+		{"prs", prLoadedMsg{repo: fixtureRepo, prs: []PullRequest{{Number: 42}}, cachedAt: time.Now()}},
+		{"issues", issuesLoadedMsg{repo: fixtureRepo, issues: []Issue{{Number: 57}}, cachedAt: time.Now()}},
+		{"runs", workflowsLoadedMsg{repo: fixtureRepo, runs: []WorkflowRun{{DatabaseId: 9001}}, cachedAt: time.Now()}},
+	}
+	for _, tt := range tests {
+		m := initialModel(getDefaultConfig())
+		m.setRepo("octo-org/other")
+		status := m.statusMsg
+
+		updated, _ := m.Update(tt.msg)
+		m = updated.(model)
+		if m.statusMsg != status || len(m.cachedAt) != 0 {
+			t.Errorf("%s: status %q, cached ages %v", tt.name, m.statusMsg, m.cachedAt)
+		}
+		if len(m.pullRequests)+len(m.issues)+len(m.workflowRuns) != 0 {
+			t.Errorf("%s: kept the page", tt.name)
+		}
+	}
+}
+
+func TestNineStepsThroughCustomTabs(t *testing.T) {
+	newTestClient(t)
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := getDefaultConfig()
+	cfg.Tabs = []TabConfig{
+		{Name: "Review requests", Kind: "prs"},
+		{Name: "Bugs", Kind: "issues"},
+		{Name: "Failing main", Kind: "runs"},
+	}
+	m := initialModel(cfg)
+	m.showLandingPage = false
+
+	// Custom tabs start blurred, whatever their kind
+	for _, vt := range m.customTabViews() {
+		var focused bool
+		switch v := m.views[vt].(type) {
+		case *PullRequestView:
+			focused = v.focused
+		case *IssueView:
+			focused = v.focused
+		case *ActionsView:
+			focused = v.focused
+		}
+		if focused {
+			t.Errorf("%s is focused", m.customTabs[vt].Name)
+		}
+	}
+
+	// 9 goes to the first custom tab, then steps through the rest
+	for _, want := range []ViewType{ViewCustom, ViewCustom + 1, ViewCustom + 2, ViewCustom} {
+		updated, _ := m.Update(keyPress("9"))
+		m = updated.(model)
+		if m.activeView != want {
+			t.Fatalf("9 went to %v, want %v", m.activeView, want)
+		}
+	}
+}
@@ -131,7 +131,11 @@ func (m model) renderTabbed() string {
 	contentHeight -= 3 // Subtract space for tabs
 
 	view := m.views[m.activeView]
-	if view != nil {
+	if len(m.overlays) > 0 {
+		sections = append(sections, m.overlays[len(m.overlays)-1].View(contentWidth, contentHeight))
+	} else if m.repoPicker != nil {
+		sections = append(sections, m.renderRepoPicker(contentWidth, contentHeight))
+	} else if view != nil {
 		content := view.View(contentWidth, contentHeight)
 		sections = append(sections, content)
 	} else {
@@ -148,20 +152,12 @@ func (m model) renderTabbed() string {
 
 // renderTabs renders the tab bar
 func (m model) renderTabs() string {
-	tabs := []string{
-		"Pull Requests",
-		"Issues",
-		"Repositories",
-		"Actions",
-		"Gists",
-	}
-
 	var renderedTabs []string
-	for i, tab := range tabs {
-		if ViewType(i) == m.activeView {
-			renderedTabs = append(renderedTabs, activeTabStyle.Render(tab))
+	for _, vt := range m.tabs {
+		if vt == m.activeView {
+			renderedTabs = append(renderedTabs, activeTabStyle.Render(m.tabTitle(vt)))
 		} else {
-			renderedTabs = append(renderedTabs, inactiveTabStyle.Render(tab))
+			renderedTabs = append(renderedTabs, inactiveTabStyle.Render(m.tabTitle(vt)))
 		}
 	}
 
@@ -173,16 +169,27 @@ func (m model) renderTabs() string {
 // renderTitleBar renders the title bar
 func (m model) renderTitleBar() string {
 	title := titleStyle.Render("gh-tui - GitHub CLI Interactive Interface")
-	padding := m.width - lipgloss.Width(title)
+
+	// Current repo context on the right
+	repo := ""
+	if m.currentRepo != "" {
+		repo = statusStyle.Render(" " + m.currentRepo + " (ctrl+p to switch)")
+	}
+
+	padding := m.width - lipgloss.Width(title) - lipgloss.Width(repo)
 	if padding < 0 {
 		padding = 0
+		repo = ""
 	}
-	return title + strings.Repeat(" ", padding)
+	return title + strings.Repeat(" ", padding) + repo
 }
 
 // renderStatusBar renders the status bar
 func (m model) renderStatusBar() string {
 	status := m.statusMsg
+	if cachedAt, ok := m.cachedAt[m.activeView]; ok && !m.showLandingPage {
+		status += " • cached " + formatTimeAgo(cachedAt)
+	}
 	width := m.width - lipgloss.Width(status) - 4
 
 	if width < 0 {
@@ -287,6 +294,7 @@ func (m model) renderHelpScreen() string {
 	sections = append(sections, helpKeyStyle.Render("  ?        ")+"  Toggle this help screen")
 	sections = append(sections, helpKeyStyle.Render("  q        ")+"  Quit application")
 	sections = append(sections, helpKeyStyle.Render("  r        ")+"  Refresh current view")
+	sections = append(sections, helpKeyStyle.Render("  Ctrl+P   ")+"  Switch repository")
 	sections = append(sections, helpKeyStyle.Render("  Esc      ")+"  Close help / dialogs")
 	sections = append(sections, "")
 
@@ -294,45 +302,68 @@ func (m model) renderHelpScreen() string {
 	sections = append(sections, helpSectionStyle.Render("Navigation"))
 	sections = append(sections, helpKeyStyle.Render("  Tab      ")+"  Next tab")
 	sections = append(sections, helpKeyStyle.Render("  Shift+Tab")+"  Previous tab")
-	sections = append(sections, helpKeyStyle.Render("  1-5      ")+"  Jump to tab (1=PRs, 2=Issues, 3=Repos, 4=Actions, 5=Gists)")
+	sections = append(sections, helpKeyStyle.Render("  1-9      ")+"  Jump to tab (1=PRs, 2=Issues, 3=Repos, 4=Actions, 5=Gists, 6=Search, 7=Notifications, 8=Dashboard, 9=next custom tab)")
 	sections = append(sections, helpKeyStyle.Render("  ↑/↓, j/k ")+"  Navigate list items")
 	sections = append(sections, "")
 
 	// Common Actions
 	sections = append(sections, helpSectionStyle.Render("Common Actions (All Tabs)"))
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open item in browser")
+	sections = append(sections, helpKeyStyle.Render("  /        ")+"  Filter, e.g. author:me label:bug -is:draft \"text\" (Esc clears)")
+	sections = append(sections, helpKeyStyle.Render("  Space    ")+"  Select item for batch actions (PRs, Issues, Repos)")
+	sections = append(sections, helpKeyStyle.Render("  V        ")+"  Start/end a visual range selection")
+	sections = append(sections, helpKeyStyle.Render("  Ctrl+A   ")+"  Select all loaded items (Esc clears)")
 	sections = append(sections, "")
 
 	// Pull Requests Tab
 	sections = append(sections, helpSectionStyle.Render("Pull Requests Tab"))
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open PR in browser")
-	sections = append(sections, helpKeyStyle.Render("  d        ")+"  View diff in pager")
-	sections = append(sections, helpKeyStyle.Render("  m        ")+"  Merge PR (coming soon)")
+	sections = append(sections, helpKeyStyle.Render("  J/K      ")+"  Scroll PR details")
+	sections = append(sections, helpKeyStyle.Render("  d        ")+"  View diff (n/p file, s split, w whitespace)")
+	sections = append(sections, helpKeyStyle.Render("  c/v/x    ")+"  In diff: comment, select range, delete comment")
+	sections = append(sections, helpKeyStyle.Render("  R        ")+"  Submit review with pending comments")
+	sections = append(sections, helpKeyStyle.Render("  C        ")+"  Comment on PR")
+	sections = append(sections, helpKeyStyle.Render("  l        ")+"  Add labels (to every selected PR)")
+	sections = append(sections, helpKeyStyle.Render("  c        ")+"  Check out into a worktree, then open a shell or $EDITOR")
+	sections = append(sections, helpKeyStyle.Render("  m        ")+"  Merge PR (merge, squash or rebase)")
 	sections = append(sections, "")
 
 	// Issues Tab
 	sections = append(sections, helpSectionStyle.Render("Issues Tab"))
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open issue in browser")
-	sections = append(sections, helpKeyStyle.Render("  n        ")+"  Create new issue")
-	sections = append(sections, helpKeyStyle.Render("  x        ")+"  Close issue")
-	sections = append(sections, helpKeyStyle.Render("  r        ")+"  Reopen issue (or refresh if open)")
+	sections = append(sections, helpKeyStyle.Render("  J/K      ")+"  Scroll issue description and comments")
+	sections = append(sections, helpKeyStyle.Render("  n        ")+"  Compose new issue (N: in browser)")
+	sections = append(sections, helpKeyStyle.Render("  C        ")+"  Comment on issue (Ctrl+O: write it in $EDITOR)")
+	sections = append(sections, helpKeyStyle.Render("  x/X      ")+"  Close/reopen issue (or every selected issue)")
+	sections = append(sections, helpKeyStyle.Render("  l/a/m    ")+"  Edit labels, assignees or milestone (l adds labels to a selection)")
 	sections = append(sections, helpKeyStyle.Render("  e        ")+"  Edit issue (coming soon)")
 	sections = append(sections, "")
 
 	// Repositories Tab
 	sections = append(sections, helpSectionStyle.Render("Repositories Tab"))
+	sections = append(sections, helpKeyStyle.Render("  Enter    ")+"  Switch PRs/Issues/Actions to repo")
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open repo in browser")
 	sections = append(sections, helpKeyStyle.Render("  v        ")+"  Toggle list/table view")
-	sections = append(sections, helpKeyStyle.Render("  s        ")+"  Star/unstar repository")
-	sections = append(sections, helpKeyStyle.Render("  c        ")+"  Clone repository")
+	sections = append(sections, helpKeyStyle.Render("  s/S      ")+"  Star/unstar repository (or star/unstar every selected one)")
+	sections = append(sections, helpKeyStyle.Render("  c        ")+"  Clone repository (or every selected one)")
 	sections = append(sections, helpKeyStyle.Render("  f        ")+"  Fork repository")
 	sections = append(sections, "")
 
 	// Actions Tab
 	sections = append(sections, helpSectionStyle.Render("Actions Tab"))
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open workflow run in browser")
-	sections = append(sections, helpKeyStyle.Render("  l        ")+"  View logs")
-	sections = append(sections, helpKeyStyle.Render("  r        ")+"  Re-run workflow (coming soon)")
+	sections = append(sections, helpKeyStyle.Render("  J/K      ")+"  Move through jobs and steps (live while running)")
+	sections = append(sections, helpKeyStyle.Render("  space    ")+"  Expand/collapse job steps")
+	sections = append(sections, helpKeyStyle.Render("  l        ")+"  View logs at the first failure")
+	sections = append(sections, helpKeyStyle.Render("  Enter    ")+"  View logs of the selected job/step")
+	sections = append(sections, helpKeyStyle.Render("  /, n/N   ")+"  Search logs, next/previous match")
+	sections = append(sections, helpKeyStyle.Render("  f, e/E   ")+"  First failure, next/previous error in logs")
+	sections = append(sections, helpKeyStyle.Render("  s        ")+"  Save logs to a file")
+	sections = append(sections, helpKeyStyle.Render("  R        ")+"  Re-run workflow (all or failed jobs, debug logging)")
+	sections = append(sections, helpKeyStyle.Render("  c        ")+"  Cancel a running workflow")
+	sections = append(sections, helpKeyStyle.Render("  a        ")+"  Browse, download and preview run artifacts")
+	sections = append(sections, helpKeyStyle.Render("  w        ")+"  Switch between runs and workflows")
+	sections = append(sections, helpKeyStyle.Render("  d/Enter  ")+"  Run the selected workflow (workflow list)")
 	sections = append(sections, "")
 
 	// Gists Tab
@@ -343,6 +374,31 @@ func (m model) renderHelpScreen() string {
 	sections = append(sections, helpKeyStyle.Render("  b        ")+"  Open gist in browser")
 	sections = append(sections, "")
 
+	// Search Tab
+	sections = append(sections, helpSectionStyle.Render("Search Tab"))
+	sections = append(sections, helpKeyStyle.Render("  /        ")+"  Search GitHub (issues, PRs, repos, code)")
+	sections = append(sections, helpKeyStyle.Render("  [/]      ")+"  Previous/next group of results")
+	sections = append(sections, helpKeyStyle.Render("  Enter    ")+"  Open in its tab, or view the file")
+	sections = append(sections, helpKeyStyle.Render("  n/N      ")+"  Next/previous match (file viewer)")
+	sections = append(sections, "")
+
+	// Notifications Tab
+	sections = append(sections, helpSectionStyle.Render("Notifications Tab"))
+	sections = append(sections, helpKeyStyle.Render("  Enter    ")+"  Open the PR, issue or runs in their tab (marks read)")
+	sections = append(sections, helpKeyStyle.Render("  m        ")+"  Mark read")
+	sections = append(sections, helpKeyStyle.Render("  d        ")+"  Mark done (leaves the inbox)")
+	sections = append(sections, helpKeyStyle.Render("  u        ")+"  Unsubscribe from the thread")
+	sections = append(sections, helpKeyStyle.Render("  p        ")+"  Participating only / all")
+	sections = append(sections, helpKeyStyle.Render("  [/]      ")+"  Previous/next group")
+	sections = append(sections, "")
+
+	// Dashboard Tab
+	sections = append(sections, helpSectionStyle.Render("Dashboard Tab"))
+	sections = append(sections, helpKeyStyle.Render("  ←/→      ")+"  Previous/next tile")
+	sections = append(sections, helpKeyStyle.Render("  Enter    ")+"  Open the PR, issue or failed run in its tab")
+	sections = append(sections, helpKeyStyle.Render("  a        ")+"  Everything the tile counts, in the Search or Actions tab")
+	sections = append(sections, "")
+
 	// Footer
 	sections = append(sections, dimmedStyle.Render("Press ? or Esc to close this help screen"))
 
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -10,68 +12,253 @@ import (
 
 // ActionsView displays a list of workflow runs
 type ActionsView struct {
-	data     []WorkflowRun
-	cursor   int
-	focused  bool
-	err      error
-	loading  bool
-	width    int
-	height   int
+	all         []WorkflowRun // Everything loaded; data is what the filter lets through
+	data        []WorkflowRun
+	repo        string   // Repo being shown ("" for the working directory)
+	page        PageInfo // Pagination state of data
+	loadingMore bool     // Next page request in flight
+	cursor      int
+	focused     bool
+	err         error
+	loading     bool
+	width       int
+	height      int
+
+	// Jobs of the selected run, kept per run ID. Runs that haven't
+	// completed are polled while selected, backing off while nothing changes.
+	details       map[int64]WorkflowRunDetail
+	detailErrs    map[int64]error
+	detailLoading map[int64]bool          // Fetch in flight or poll scheduled
+	pollDelay     map[int64]time.Duration // Wait before the next poll
+	jobs          *TreeViewState          // Job/step tree of the selected run
+	expandedJobs  map[string]bool
+
+	// Runs with a re-run or cancel in flight, with the state to restore if it fails
+	pendingActions map[int64]WorkflowRun
+
+	// Workflow list, shown instead of the runs to pick a workflow to run
+	showWorkflows    bool
+	workflows        []Workflow // Loaded when first shown
+	workflowsLoading bool
+	workflowsErr     error
+	workflowCursor   int
+
+	filter *ListFilter
+
+	// Tab the view is shown in; a custom tab lists its saved query (see tabs.go)
+	tab     ViewType
+	title   string
+	saved   *TabConfig
+	columns []string // Shown after the title of each row
 }
 
+// Poll intervals for runs that haven't completed
+const (
+	runPollMin = 2 * time.Second
+	runPollMax = 30 * time.Second
+)
+
 // NewActionsView creates a new actions view
 func NewActionsView() *ActionsView {
 	return &ActionsView{
-		data:    []WorkflowRun{},
-		cursor:  0,
-		focused: false,
-		loading: true,
+		data:           []WorkflowRun{},
+		details:        make(map[int64]WorkflowRunDetail),
+		detailErrs:     make(map[int64]error),
+		detailLoading:  make(map[int64]bool),
+		pollDelay:      make(map[int64]time.Duration),
+		jobs:           NewTreeViewState(),
+		expandedJobs:   make(map[string]bool),
+		pendingActions: make(map[int64]WorkflowRun),
+		filter:         NewListFilter("status", "conclusion", "branch", "workflow", "is"),
+		tab:            ViewActions,
+		title:          "Workflow Runs",
+		columns:        []string{"branch", "created"},
+		cursor:         0,
+		focused:        false,
+		loading:        true,
 	}
 }
 
 // Update handles messages for the actions view
 func (v *ActionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
+	case repoChangedMsg:
+		// The model fetches the new repo's data; start over until it arrives
+		v.repo = msg.repo
+		v.all = nil
+		v.data = nil
+		v.page = PageInfo{}
+		v.cursor = 0
+		v.err = nil
+		v.loading = true
+		v.loadingMore = false
+		v.details = make(map[int64]WorkflowRunDetail)
+		v.detailErrs = make(map[int64]error)
+		v.detailLoading = make(map[int64]bool)
+		v.pollDelay = make(map[int64]time.Duration)
+		v.expandedJobs = make(map[string]bool)
+		v.pendingActions = make(map[int64]WorkflowRun)
+		v.jobs = NewTreeViewState()
+		v.workflows = nil
+		v.workflowsErr = nil
+		v.workflowCursor = 0
+		if v.showWorkflows {
+			v.workflowsLoading = true
+			return v, fetchWorkflowList(v.repo)
+		}
+
 	case workflowsLoadedMsg:
+		if staleRepo(msg.repo, v.repo) {
+			// Asked for before switching repos
+			return v, nil
+		}
 		v.loading = false
+		v.loadingMore = false
 		if msg.err != nil {
-			v.err = msg.err
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
 		} else {
-			v.data = msg.runs
-			if len(v.data) > 0 && v.cursor >= len(v.data) {
-				v.cursor = len(v.data) - 1
+			if msg.appended {
+				v.all = append(v.all, msg.runs...)
+			} else {
+				v.all = msg.runs
 			}
+			v.page = msg.page
+			v.applyFilter()
 		}
+		v.buildJobTree()
+		return v, v.loadDetail()
+
+	case workflowListLoadedMsg:
+		v.workflowsLoading = false
+		v.workflowsErr = msg.err
+		if msg.err == nil {
+			v.workflows = msg.workflows
+			if v.workflowCursor >= len(v.workflows) {
+				v.workflowCursor = max(0, len(v.workflows)-1)
+			}
+		}
+
+	case workflowDispatchedMsg:
+		return v, v.showDispatchedRun(msg)
+
+	case showFilteredMsg:
+		// Opened from another tab on the newest of the runs it names
+		v.showWorkflows = false
+		v.filter.Set(msg.query)
+		v.applyFilter()
+		v.cursor = 0
+		v.buildJobTree()
+		return v, v.loadDetail()
+
+	case runDetailLoadedMsg:
+		return v, v.handleRunDetail(msg)
+
+	case runActionStartedMsg:
+		v.startRunAction(msg)
+
+	case runActionDoneMsg:
+		return v, v.finishRunAction(msg)
+
+	case runPollMsg:
+		// Polling only continues while the run is selected; it resumes
+		// when the run is selected again
+		if run, ok := v.selectedRun(); !ok || run.DatabaseId != msg.runID {
+			delete(v.detailLoading, msg.runID)
+			return v, nil
+		}
+		return v, fetchRunDetail(v.tab, v.repo, msg.runID)
 
 	case tea.KeyMsg:
 		if !v.focused {
 			return v, nil
 		}
+		if v.filter.Editing() {
+			// Before w, so the prompt can take workflow:
+			cmd := v.filter.HandleKey(msg)
+			v.applyFilter()
+			return v, tea.Batch(cmd, v.selectRun())
+		}
+		if msg.String() == "w" {
+			return v, v.toggleWorkflows()
+		}
+		if v.showWorkflows {
+			return v, v.handleWorkflowKeys(msg)
+		}
 
 		switch msg.String() {
+		case "/":
+			return v, v.filter.Start()
+		case "esc":
+			if v.filter.Active() {
+				v.filter.Clear()
+				v.applyFilter()
+				return v, v.selectRun()
+			}
 		case "up", "k":
 			if v.cursor > 0 {
 				v.cursor--
+				return v, v.selectRun()
 			}
 		case "down", "j":
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				return v, tea.Batch(v.loadMore(), v.selectRun())
+			}
+			return v, v.loadMore()
+		case "K":
+			v.jobs.MoveUp()
+		case "J":
+			v.jobs.MoveDown()
+		case " ":
+			// Expand or collapse the selected job
+			v.toggleJob()
+		case "R":
+			if run, ok := v.selectedRun(); ok {
+				if run.Status != "completed" {
+					return v, func() tea.Msg {
+						return statusMsg{message: fmt.Sprintf("Run #%d is still running; cancel it first (c)", run.RunNumber)}
+					}
+				}
+				return v, openOverlay(NewRunActionDialog(v.repo, run, false))
+			}
+		case "c":
+			if run, ok := v.selectedRun(); ok {
+				if run.Status == "completed" {
+					return v, func() tea.Msg {
+						return statusMsg{message: fmt.Sprintf("Run #%d has already completed", run.RunNumber)}
+					}
+				}
+				if run.Status == "cancelling" {
+					return v, func() tea.Msg {
+						return statusMsg{message: fmt.Sprintf("Run #%d is already being cancelled", run.RunNumber)}
+					}
+				}
+				return v, openOverlay(NewRunActionDialog(v.repo, run, true))
 			}
-		case "r":
-			v.loading = true
-			v.err = nil
-			return v, fetchWorkflowRuns("")
 		case "b":
 			// Open workflow run in browser
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				run := v.data[v.cursor]
-				return v, openInBrowser("run", fmt.Sprintf("%d", run.DatabaseId), "")
+				return v, openInBrowser("run", fmt.Sprintf("%d", run.DatabaseId), v.repo)
 			}
 		case "l":
-			// View workflow logs in pager
-			if len(v.data) > 0 && v.cursor < len(v.data) {
-				run := v.data[v.cursor]
-				return v, viewWorkflowLogs(fmt.Sprintf("%d", run.DatabaseId))
+			// Logs of the run, at its first failure
+			if run, ok := v.selectedRun(); ok {
+				return v, openLogView(v.repo, run, "", "")
+			}
+		case "a":
+			// Artifacts the run uploaded
+			if run, ok := v.selectedRun(); ok {
+				return v, openArtifactView(v.repo, run)
+			}
+		case "enter":
+			// Logs of the selected job or step
+			if run, ok := v.selectedRun(); ok {
+				job, step := v.selectedJobStep()
+				return v, openLogView(v.repo, run, job, step)
 			}
 		}
 
@@ -82,13 +269,22 @@ func (v *ActionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 
 		switch msg.Type {
 		case tea.MouseWheelUp:
+			if v.showWorkflows {
+				return v, v.handleWorkflowKeys(tea.KeyMsg{Type: tea.KeyUp})
+			}
 			if v.cursor > 0 {
 				v.cursor--
+				return v, v.selectRun()
 			}
 		case tea.MouseWheelDown:
+			if v.showWorkflows {
+				return v, v.handleWorkflowKeys(tea.KeyMsg{Type: tea.KeyDown})
+			}
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				return v, tea.Batch(v.loadMore(), v.selectRun())
 			}
+			return v, v.loadMore()
 		}
 	}
 
@@ -100,6 +296,10 @@ func (v *ActionsView) View(width, height int) string {
 	v.width = width
 	v.height = height
 
+	if v.showWorkflows {
+		return v.renderWorkflows(width, height)
+	}
+
 	if v.loading {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
@@ -112,7 +312,7 @@ func (v *ActionsView) View(width, height int) string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", v.err)))
 	}
 
-	if len(v.data) == 0 {
+	if len(v.all) == 0 && !v.filter.Editing() {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
 			dimmedStyle.Render("No workflow runs found"))
@@ -138,9 +338,15 @@ func (v *ActionsView) renderList(width, height int) string {
 	var lines []string
 
 	// Header
-	title := listTitleStyle.Render(fmt.Sprintf(" Workflow Runs (%d)", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" %s (%s)", v.title, formatListCount(len(v.data), v.page)) + v.filter.Title())
+	if v.loadingMore {
+		title += dimmedStyle.Render(" loading more...")
+	}
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
+	if len(v.data) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 3
@@ -172,12 +378,12 @@ func (v *ActionsView) renderList(width, height int) string {
 			status,
 			truncateString(run.Name, width-35))
 
-		meta := fmt.Sprintf("%s • %s",
-			run.HeadBranch,
-			formatTimeAgo(run.CreatedAt))
+		meta := runColumns(run, v.columns)
 
 		if len(line)+len(meta)+3 < width {
-			line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+			line = v.filter.Highlight(padRight(line, width-len(meta)-3), style) + dimmedStyle.Render(meta)
+		} else {
+			line = v.filter.Highlight(line, style)
 		}
 
 		lines = append(lines, style.Render(line))
@@ -218,6 +424,11 @@ func (v *ActionsView) renderDetail(width, height int) string {
 	lines = append(lines, fmt.Sprintf("Run Number: #%d", run.RunNumber))
 	lines = append(lines, fmt.Sprintf("Created:    %s", formatTime(run.CreatedAt)))
 	lines = append(lines, fmt.Sprintf("Age:        %s", formatTimeAgo(run.CreatedAt)))
+	if detail, ok := v.details[run.DatabaseId]; ok {
+		if elapsed := runElapsed(detail); elapsed != "" {
+			lines = append(lines, fmt.Sprintf("Duration:   %s", elapsed))
+		}
+	}
 
 	lines = append(lines, "")
 	// Truncate URL to prevent wrapping in narrow detail pane
@@ -225,9 +436,36 @@ func (v *ActionsView) renderDetail(width, height int) string {
 	clickableURL := makeHyperlink(run.URL, displayURL)
 	lines = append(lines, dimmedStyle.Render(fmt.Sprintf("URL: %s", clickableURL)))
 
+	// Jobs and steps, live while the run is going
+	lines = append(lines, "")
+	jobsTitle := detailTitleStyle.Render("Jobs")
+	if run.Status != "completed" {
+		jobsTitle += dimmedStyle.Render(fmt.Sprintf(" ● watching, every %s", formatDuration(v.currentPollDelay(run.DatabaseId))))
+	}
+	lines = append(lines, jobsTitle)
+
 	// Keyboard hints
+	hints := helpStyle.Width(width - 4).Render("↑/↓: Navigate • J/K: Jobs • Space: Expand • l/Enter: Logs • a: Artifacts • c: Cancel • w: Workflows • r: Refresh")
+	if run.Status == "completed" {
+		hints = helpStyle.Width(width - 4).Render("↑/↓: Navigate • J/K: Jobs • Space: Expand • l/Enter: Logs • a: Artifacts • R: Re-run • w: Workflows • r: Refresh")
+	}
+	treeHeight := height - 2 - len(lines) - 1 - lipgloss.Height(hints) // padding, blank line and hints
+
+	_, loaded := v.details[run.DatabaseId]
+	err, failed := v.detailErrs[run.DatabaseId]
+	switch {
+	case !loaded && failed:
+		lines = append(lines, statusFailureStyle.Render(truncateString(fmt.Sprintf("Failed to load jobs: %v", err), width-4)))
+	case !loaded:
+		lines = append(lines, dimmedStyle.Render("Loading jobs..."))
+	case len(v.jobs.TreeItems) == 0:
+		lines = append(lines, dimmedStyle.Render("No jobs yet"))
+	case treeHeight > 2:
+		lines = append(lines, renderTreeView(v.jobs, width-4, treeHeight))
+	}
+
 	lines = append(lines, "")
-	lines = append(lines, helpStyle.Render("↑/↓: Navigate • l: Logs • b: Browser • r: Refresh • q: Quit"))
+	lines = append(lines, hints)
 
 	content := strings.Join(lines, "\n")
 	return lipgloss.NewStyle().
@@ -237,6 +475,490 @@ func (v *ActionsView) renderDetail(width, height int) string {
 		Render(content)
 }
 
+// applyFilter narrows the loaded runs to those matching the filter,
+// keeping the cursor on the same run while it still matches
+func (v *ActionsView) applyFilter() {
+	var current int64 = -1
+	if v.cursor < len(v.data) {
+		current = v.data[v.cursor].DatabaseId
+	}
+	data := []WorkflowRun{}
+	cursor := -1
+	for _, run := range v.all {
+		if !v.filter.Match(runFilterItem(run)) {
+			continue
+		}
+		if run.DatabaseId == current {
+			cursor = len(data)
+		}
+		data = append(data, run)
+	}
+	v.data = data
+	if cursor >= 0 {
+		v.cursor = cursor
+	} else {
+		v.cursor = max(0, min(v.cursor, len(v.data)-1))
+	}
+}
+
+// forEachRun applies change to the run with id, both where it was loaded
+// and where it is shown. A run that stops matching the filter stays shown
+// until the filter changes or the runs are reloaded.
+func (v *ActionsView) forEachRun(id int64, change func(run *WorkflowRun)) {
+	for i := range v.all {
+		if v.all[i].DatabaseId == id {
+			change(&v.all[i])
+		}
+	}
+	for i := range v.data {
+		if v.data[i].DatabaseId == id {
+			change(&v.data[i])
+		}
+	}
+}
+
+// runColumns renders the columns of a run's row, e.g. "main • 2h ago"
+func runColumns(run WorkflowRun, columns []string) string {
+	var values []string
+	for _, column := range columns {
+		value := ""
+		switch column {
+		case "branch":
+			value = run.HeadBranch
+		case "created":
+			value = formatTimeAgo(run.CreatedAt)
+		case "number":
+			value = fmt.Sprintf("#%d", run.RunNumber)
+		case "sha":
+			value = run.HeadSha[:min(7, len(run.HeadSha))]
+		case "status":
+			value = run.Status
+			if run.Conclusion != "" {
+				value = run.Conclusion
+			}
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " • ")
+}
+
+// runFilterItem is what the filter matches a run on
+func runFilterItem(run WorkflowRun) filterItem {
+	return filterItem{
+		fields: map[string][]string{
+			"status":     {run.Status},
+			"conclusion": {run.Conclusion},
+			"branch":     {run.HeadBranch},
+			"workflow":   {run.Name},
+			"is":         {run.Status, run.Conclusion},
+		},
+		text: []string{run.Name, run.HeadBranch, fmt.Sprintf("#%d", run.RunNumber), run.HeadSha},
+	}
+}
+
+// Filtering reports whether the filter prompt is open
+func (v *ActionsView) Filtering() bool {
+	return v.filter.Editing() && !v.showWorkflows
+}
+
+// selectedRun returns the run under the cursor
+func (v *ActionsView) selectedRun() (WorkflowRun, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.data) {
+		return WorkflowRun{}, false
+	}
+	return v.data[v.cursor], true
+}
+
+// selectRun shows the job tree of the run under the cursor
+func (v *ActionsView) selectRun() tea.Cmd {
+	v.jobs.SelectedIndex = 0
+	v.buildJobTree()
+	return v.loadDetail()
+}
+
+// loadDetail fetches the selected run's jobs unless they are known to be
+// final or a fetch or poll is already pending
+func (v *ActionsView) loadDetail() tea.Cmd {
+	run, ok := v.selectedRun()
+	if !ok || v.detailLoading[run.DatabaseId] {
+		return nil
+	}
+	if detail, ok := v.details[run.DatabaseId]; ok && detail.Run.Status == "completed" {
+		return nil
+	}
+	v.detailLoading[run.DatabaseId] = true
+	return fetchRunDetail(v.tab, v.repo, run.DatabaseId)
+}
+
+// handleRunDetail stores a run's jobs and schedules the next poll while
+// the run is selected and hasn't completed
+func (v *ActionsView) handleRunDetail(msg runDetailLoadedMsg) tea.Cmd {
+	delete(v.detailLoading, msg.runID)
+
+	changed := true
+	if msg.err != nil {
+		v.detailErrs[msg.runID] = msg.err
+		changed = false
+	} else {
+		delete(v.detailErrs, msg.runID)
+		if previous, ok := v.details[msg.runID]; ok {
+			changed = runSignature(previous) != runSignature(msg.detail)
+		}
+		v.details[msg.runID] = msg.detail
+
+		// Keep the list's status in step with the run, unless an action
+		// on it hasn't been confirmed yet
+		if _, pending := v.pendingActions[msg.runID]; !pending {
+			v.forEachRun(msg.runID, func(run *WorkflowRun) {
+				// A cancelled run stays cancelling until it has stopped
+				if run.Status == "cancelling" && msg.detail.Run.Status != "completed" {
+					return
+				}
+				run.Status = msg.detail.Run.Status
+				run.Conclusion = msg.detail.Run.Conclusion
+			})
+		}
+	}
+
+	run, ok := v.selectedRun()
+	if !ok || run.DatabaseId != msg.runID {
+		return nil
+	}
+	v.buildJobTree()
+
+	if run.Status == "completed" {
+		delete(v.pollDelay, msg.runID)
+		return nil
+	}
+
+	// Back off while nothing changes, check quickly again once it does
+	delay := runPollMin
+	if !changed {
+		delay = v.currentPollDelay(msg.runID) * 2
+		if delay > runPollMax {
+			delay = runPollMax
+		}
+	}
+	v.pollDelay[msg.runID] = delay
+	v.detailLoading[msg.runID] = true
+	return pollRun(v.tab, msg.runID, delay)
+}
+
+// currentPollDelay returns the wait before the next poll of a run
+func (v *ActionsView) currentPollDelay(runID int64) time.Duration {
+	if delay, ok := v.pollDelay[runID]; ok {
+		return delay
+	}
+	return runPollMin
+}
+
+// buildJobTree rebuilds the job tree of the selected run, keeping the selection
+// Jobs that are running or failed start expanded.
+func (v *ActionsView) buildJobTree() {
+	run, ok := v.selectedRun()
+	detail, loaded := v.details[run.DatabaseId]
+	if !ok || !loaded {
+		v.jobs.TreeItems = nil
+		return
+	}
+
+	for _, job := range detail.Jobs {
+		key := fmt.Sprintf("%d", job.ID)
+		if _, seen := v.expandedJobs[key]; !seen {
+			v.expandedJobs[key] = job.Status == "in_progress" || job.Conclusion == "failure"
+		}
+	}
+	v.jobs.TreeItems = BuildRunTree(detail.Jobs, v.expandedJobs)
+	if v.jobs.SelectedIndex >= len(v.jobs.TreeItems) {
+		v.jobs.SelectedIndex = max(0, len(v.jobs.TreeItems)-1)
+	}
+}
+
+// toggleJob expands or collapses the selected job (or the job of the selected step)
+func (v *ActionsView) toggleJob() {
+	// Steps follow their job; walk back to it
+	index := v.jobs.SelectedIndex
+	for index > 0 && index < len(v.jobs.TreeItems) && v.jobs.TreeItems[index].Type != TreeItemJob {
+		index--
+	}
+	if index < 0 || index >= len(v.jobs.TreeItems) {
+		return
+	}
+
+	job := v.jobs.TreeItems[index].Data.(*WorkflowJob)
+	key := fmt.Sprintf("%d", job.ID)
+	v.expandedJobs[key] = !v.expandedJobs[key]
+	v.jobs.SelectedIndex = index
+	v.buildJobTree()
+}
+
+// refresh loads the list again and checks the selected run right away,
+// instead of at its next poll
+func (v *ActionsView) refresh() tea.Cmd {
+	v.loading = true
+	v.err = nil
+	if run, ok := v.selectedRun(); ok {
+		delete(v.details, run.DatabaseId)
+		v.pollDelay[run.DatabaseId] = runPollMin
+	}
+	return v.fetchPage("")
+}
+
+// startRunAction shows the expected outcome of a run action in the list
+func (v *ActionsView) startRunAction(msg runActionStartedMsg) {
+	v.forEachRun(msg.runID, func(run *WorkflowRun) {
+		if _, pending := v.pendingActions[msg.runID]; !pending {
+			v.pendingActions[msg.runID] = *run
+		}
+		if msg.action.Kind == "cancel" {
+			// GitHub stops the run's jobs a while later; polling tells
+			// when it has, and how it ended
+			run.Status, run.Conclusion = "cancelling", ""
+		} else {
+			run.Status, run.Conclusion = "queued", ""
+		}
+	})
+}
+
+// finishRunAction restores a run whose action failed; otherwise it drops
+// the run's jobs so they are fetched (and watched) again
+func (v *ActionsView) finishRunAction(msg runActionDoneMsg) tea.Cmd {
+	previous, pending := v.pendingActions[msg.runID]
+	delete(v.pendingActions, msg.runID)
+
+	if msg.err != nil {
+		if pending {
+			v.forEachRun(msg.runID, func(run *WorkflowRun) { *run = previous })
+		}
+		return nil
+	}
+
+	delete(v.details, msg.runID)
+	v.pollDelay[msg.runID] = runPollMin
+	if run, ok := v.selectedRun(); ok && run.DatabaseId == msg.runID {
+		v.buildJobTree()
+		return v.loadDetail()
+	}
+	return nil
+}
+
+// selectedJobStep returns the names of the job and step selected in the
+// tree; step is "" when a job is selected
+func (v *ActionsView) selectedJobStep() (string, string) {
+	index := v.jobs.SelectedIndex
+	if index < 0 || index >= len(v.jobs.TreeItems) {
+		return "", ""
+	}
+
+	step := ""
+	if s, ok := v.jobs.TreeItems[index].Data.(*WorkflowStep); ok {
+		step = s.Name
+		for index > 0 && v.jobs.TreeItems[index].Type != TreeItemJob {
+			index--
+		}
+	}
+	if job, ok := v.jobs.TreeItems[index].Data.(*WorkflowJob); ok {
+		return job.Name, step
+	}
+	return "", ""
+}
+
+// runSignature summarizes the status of a run, its jobs and steps, to tell
+// whether a poll found anything new
+func runSignature(detail WorkflowRunDetail) string {
+	var b strings.Builder
+	b.WriteString(detail.Run.Status + detail.Run.Conclusion)
+	for _, job := range detail.Jobs {
+		fmt.Fprintf(&b, "|%d:%s:%s", job.ID, job.Status, job.Conclusion)
+		for _, step := range job.Steps {
+			fmt.Fprintf(&b, ",%s:%s", step.Status, step.Conclusion)
+		}
+	}
+	return b.String()
+}
+
+// runElapsed is the time from the first job starting to the last one
+// finishing, or until now while the run is going
+func runElapsed(detail WorkflowRunDetail) string {
+	var start, end time.Time
+	for _, job := range detail.Jobs {
+		if !job.StartedAt.IsZero() && (start.IsZero() || job.StartedAt.Before(start)) {
+			start = job.StartedAt
+		}
+		if job.CompletedAt.After(end) {
+			end = job.CompletedAt
+		}
+	}
+	if detail.Run.Status != "completed" {
+		end = time.Time{}
+	}
+	return formatElapsed(start, end)
+}
+
+// toggleWorkflows switches between the runs and the workflow list,
+// loading the workflows the first time they are shown
+func (v *ActionsView) toggleWorkflows() tea.Cmd {
+	v.showWorkflows = !v.showWorkflows
+	if !v.showWorkflows || v.workflowsLoading || (v.workflows != nil && v.workflowsErr == nil) {
+		return nil
+	}
+	v.workflowsLoading = true
+	v.workflowsErr = nil
+	return fetchWorkflowList(v.repo)
+}
+
+// handleWorkflowKeys handles keys while the workflow list is shown
+func (v *ActionsView) handleWorkflowKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if v.workflowCursor > 0 {
+			v.workflowCursor--
+		}
+	case "down", "j":
+		if v.workflowCursor < len(v.workflows)-1 {
+			v.workflowCursor++
+		}
+	case "esc":
+		v.showWorkflows = false
+	case "d", "enter":
+		if v.workflowCursor >= len(v.workflows) {
+			return nil
+		}
+		workflow := v.workflows[v.workflowCursor]
+		if workflow.State != "active" {
+			return func() tea.Msg {
+				return statusMsg{message: fmt.Sprintf("%s is disabled; enable it on GitHub to run it", workflow.Name)}
+			}
+		}
+		return openDispatchDialog(v.repo, workflow)
+	case "b":
+		if v.workflowCursor < len(v.workflows) {
+			return openInBrowser("workflow", path.Base(v.workflows[v.workflowCursor].Path), v.repo)
+		}
+	}
+	return nil
+}
+
+// showDispatchedRun switches back to the runs and selects the run a
+// dispatch started, which starts watching it. When the run hasn't shown up
+// yet, the runs are refreshed to look for it.
+func (v *ActionsView) showDispatchedRun(msg workflowDispatchedMsg) tea.Cmd {
+	if msg.err != nil {
+		return nil
+	}
+	v.showWorkflows = false
+	v.cursor = 0
+	if !msg.found {
+		return v.fetchPage("")
+	}
+
+	known := false
+	for i := range v.all {
+		if v.all[i].DatabaseId == msg.run.DatabaseId {
+			known = true
+		}
+	}
+	if !known {
+		v.all = append([]WorkflowRun{msg.run}, v.all...)
+	}
+	if !v.filter.Match(runFilterItem(msg.run)) {
+		// Don't hide the run that was just started
+		v.filter.Clear()
+	}
+	v.applyFilter()
+	for i := range v.data {
+		if v.data[i].DatabaseId == msg.run.DatabaseId {
+			v.cursor = i
+		}
+	}
+	v.loading = false
+	v.err = nil
+	return v.selectRun()
+}
+
+// renderWorkflows renders the workflow list and the selected workflow
+func (v *ActionsView) renderWorkflows(width, height int) string {
+	switch {
+	case v.workflowsLoading:
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			infoStyle.Render("Loading workflows..."))
+	case v.workflowsErr != nil:
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			errorStyle.Render(fmt.Sprintf("Error: %v", v.workflowsErr)))
+	case len(v.workflows) == 0:
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			dimmedStyle.Render("No workflows found (w: Back to runs)"))
+	}
+
+	listWidth := width / 2
+	detailWidth := width - listWidth - 1
+
+	lines := []string{listTitleStyle.Render(fmt.Sprintf(" Workflows (%d)", len(v.workflows))), ""}
+	maxVisible := height - 3
+	start := max(0, min(v.workflowCursor-maxVisible/2, len(v.workflows)-maxVisible))
+	end := min(start+maxVisible, len(v.workflows))
+	for i := start; i < end; i++ {
+		workflow := v.workflows[i]
+		cursor := "  "
+		style := listItemStyle
+		if i == v.workflowCursor {
+			cursor = "▶ "
+			style = listSelectedStyle
+		}
+
+		line := cursor + truncateString(workflow.Name, width/4)
+		meta := path.Base(workflow.Path)
+		if workflow.State != "active" {
+			meta = "disabled • " + meta
+		}
+		if len(line)+len(meta)+3 < listWidth {
+			line = padRight(line, listWidth-len(meta)-3) + dimmedStyle.Render(meta)
+		}
+		lines = append(lines, style.Render(line))
+	}
+	list := lipgloss.NewStyle().Width(listWidth).Height(height).Render(strings.Join(lines, "\n"))
+
+	workflow := v.workflows[v.workflowCursor]
+	lines = []string{detailTitleStyle.Render(" Workflow"), ""}
+	for _, line := range wrapText(workflow.Name, detailWidth-4) {
+		lines = append(lines, highlightStyle.Render(line))
+	}
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("File:   %s", workflow.Path))
+	lines = append(lines, fmt.Sprintf("State:  %s", strings.ReplaceAll(workflow.State, "_", " ")))
+	lines = append(lines, "")
+	displayURL := truncateString(workflow.URL, detailWidth-10)
+	lines = append(lines, dimmedStyle.Render(fmt.Sprintf("URL: %s", makeHyperlink(workflow.URL, displayURL))))
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("↑/↓: Navigate • d/Enter: Run • b: Browser • w: Runs"))
+	detail := lipgloss.NewStyle().Width(detailWidth).Height(height).Padding(1, 2).Render(strings.Join(lines, "\n"))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, dividerStyle.Render("│"), detail)
+}
+
+// fetchPage fetches the page of runs after cursor, of the tab's saved
+// query if it has one
+func (v *ActionsView) fetchPage(cursor string) tea.Cmd {
+	if v.saved != nil {
+		return fetchTabPage(v.tab, *v.saved, v.repo, cursor)
+	}
+	return fetchWorkflowRunsPage(v.repo, cursor)
+}
+
+// loadMore requests the next page when the cursor nears the end of the list
+func (v *ActionsView) loadMore() tea.Cmd {
+	if !needsNextPage(v.cursor, len(v.data), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return v.fetchPage(v.page.EndCursor)
+}
+
 // Focus sets the view as focused
 func (v *ActionsView) Focus() {
 	v.focused = true
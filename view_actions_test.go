@@ -0,0 +1,111 @@
+package main
+
+import "testing"
+
+// loadedActionsView returns a focused actions view of the fixture repo with
+// its first page of runs loaded
+func loadedActionsView(t *testing.T) *ActionsView {
+	t.Helper()
+	v := NewActionsView()
+	v.Focus()
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	v.Update(runCmd(fetchWorkflowRuns(fixtureRepo)))
+	if v.loading || v.err != nil || len(v.data) != 3 {
+		t.Fatalf("loading = %t, err = %v, %d runs", v.loading, v.err, len(v.data))
+	}
+	return v
+}
+
+func TestActionsViewDropsStaleRepo(t *testing.T) {
+	newTestClient(t)
+
+	v := NewActionsView()
+	v.Update(repoChangedMsg{repo: "octo-org/other"})
+	v.Update(runCmd(fetchWorkflowRuns(fixtureRepo)))
+	if len(v.all) != 0 || !v.loading {
+		t.Errorf("kept %d runs loaded for %s", len(v.all), fixtureRepo)
+	}
+}
+
+func TestActionsViewFilterPrompt(t *testing.T) {
+	newTestClient(t)
+	v := loadedActionsView(t)
+
+	// w is typed into the open prompt rather than showing the workflows
+	v.Update(keyPress("/"))
+	for _, r := range "workflow:release" {
+		v.Update(keyPress(string(r)))
+	}
+	if v.showWorkflows {
+		t.Fatal("w showed the workflow list")
+	}
+	if len(v.data) != 1 || v.data[0].Name != "Release" {
+		t.Errorf("workflow:release left %d runs", len(v.data))
+	}
+
+	// Esc goes back to the query from before the prompt opened
+	v.Update(keyPress("esc"))
+	if v.filter.Active() || len(v.data) != 3 {
+		t.Errorf("after esc: active = %t, %d runs", v.filter.Active(), len(v.data))
+	}
+
+	// and w shows the workflows once the prompt is closed
+	v.Update(keyPress("w"))
+	if !v.showWorkflows {
+		t.Error("w didn't show the workflow list")
+	}
+}
+
+func TestActionsViewFilter(t *testing.T) {
+	newTestClient(t)
+	v := loadedActionsView(t)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"is:failure", 1},
+		{"is:completed", 2},
+		{"-is:completed", 1},
+		{"branch:main", 1},
+		{"workflow:ci", 2},
+		{"workflow:ci -is:in_progress", 1},
+		{"status:in_progress,completed", 3},
+	}
+	for _, tt := range tests {
+		v.filter.Set(tt.query)
+		v.applyFilter()
+		if len(v.data) != tt.want {
+			t.Errorf("%q: %d runs, want %d", tt.query, len(v.data), tt.want)
+		}
+	}
+}
+
+func TestActionsViewCancelWaitsForGitHub(t *testing.T) {
+	newTestClient(t)
+	v := loadedActionsView(t)
+
+	var run WorkflowRun
+	for i, r := range v.data {
+		if r.Status == "in_progress" {
+			v.cursor, run = i, r
+		}
+	}
+	id := run.DatabaseId
+	cancel := RunAction{Kind: "cancel"}
+
+	// The run shows as cancelling, and is still watched, until GitHub says it stopped
+	v.Update(runActionStartedMsg{runID: id, action: cancel})
+	v.Update(runActionDoneMsg{runID: id, number: run.RunNumber, action: cancel})
+	running := WorkflowRunDetail{Run: WorkflowRun{DatabaseId: id, Status: "in_progress"}}
+	_, cmd := v.Update(runDetailLoadedMsg{runID: id, detail: running})
+	if got := v.data[v.cursor].Status; got != "cancelling" || cmd == nil {
+		t.Fatalf("while running: status %q, polling %t", got, cmd != nil)
+	}
+
+	stopped := WorkflowRunDetail{Run: WorkflowRun{DatabaseId: id, Status: "completed", Conclusion: "cancelled"}}
+	_, cmd = v.Update(runDetailLoadedMsg{runID: id, detail: stopped})
+	if got := v.data[v.cursor]; got.Status != "completed" || got.Conclusion != "cancelled" || cmd != nil {
+		t.Errorf("once stopped: %s/%s, polling %t", got.Status, got.Conclusion, cmd != nil)
+	}
+}
@@ -0,0 +1,565 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ArtifactView lists a workflow run's artifacts as an overlay. Artifacts
+// download into a chosen directory, and their files can be previewed
+// straight from the archive, read into memory (see artifacts.go).
+type ArtifactView struct {
+	repo      string
+	runID     int64
+	title     string
+	artifacts []Artifact
+	loading   bool
+	err       error
+	cursor    int
+	focused   bool
+	width     int
+	height    int
+	notice    string // Feedback shown in the hints line
+
+	// Downloads run one at a time while the view is open
+	dir         string           // Directory to download into
+	prompt      *textinput.Model // Directory prompt, nil when not asking
+	queued      []Artifact       // Artifacts the prompt is asking about
+	downloads   map[int64]*artifactDownload
+	downloading bool
+	stop        chan struct{} // Closed when the view closes, ending the downloads
+	stopped     bool
+
+	archives map[int64]*zip.Reader // Archives read for previews
+	preview  *artifactPreview      // nil while browsing the artifacts
+}
+
+// artifactDownload is the state of one artifact's download
+type artifactDownload struct {
+	done     int64
+	total    int64
+	finished bool
+	path     string
+	err      error
+}
+
+// artifactPreview browses the files of one artifact's archive
+type artifactPreview struct {
+	artifact Artifact
+	loading  bool
+	err      error
+	files    []*zip.File
+	cursor   int
+
+	// Open file, nil while listing the files
+	file      *zip.File
+	lines     []string
+	truncated bool // Only the start of the file is shown
+	scroll    int
+}
+
+// NewArtifactView creates the artifact browser for run; the artifacts arrive as artifactsLoadedMsg
+func NewArtifactView(repo string, run WorkflowRun) *ArtifactView {
+	dir, err := os.Getwd()
+	if err != nil {
+		dir = "."
+	}
+	return &ArtifactView{
+		repo:      repo,
+		runID:     run.DatabaseId,
+		title:     fmt.Sprintf("%s #%d", run.Name, run.RunNumber),
+		loading:   true,
+		dir:       dir,
+		downloads: make(map[int64]*artifactDownload),
+		stop:      make(chan struct{}),
+		archives:  make(map[int64]*zip.Reader),
+	}
+}
+
+// openArtifactView opens the artifact browser for run and loads its artifacts
+func openArtifactView(repo string, run WorkflowRun) tea.Cmd {
+	return tea.Sequence(openOverlay(NewArtifactView(repo, run)), fetchArtifacts(repo, run.DatabaseId))
+}
+
+// Update handles messages for the artifact view
+func (v *ArtifactView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case artifactsLoadedMsg:
+		if msg.runID != v.runID {
+			return v, nil
+		}
+		v.loading = false
+		v.err = msg.err
+		v.artifacts = msg.artifacts
+
+	case artifactDownloadMsg:
+		if msg.runID != v.runID || v.stopped {
+			return v, nil
+		}
+		if download, ok := v.downloads[msg.artifactID]; ok {
+			download.done = max64(download.done, msg.done)
+			download.finished = msg.finished
+			download.path = msg.path
+			download.err = msg.err
+		}
+		return v, waitForArtifactDownload(v.runID, msg.stream)
+
+	case artifactDownloadsDoneMsg:
+		if msg.runID != v.runID || v.stopped {
+			return v, nil
+		}
+		v.downloading = false
+		status := v.downloadSummary()
+		return v, func() tea.Msg { return statusMsg{message: status} }
+
+	case artifactArchiveMsg:
+		if msg.err == nil {
+			v.archives[msg.artifactID] = msg.archive
+		}
+		if v.preview != nil && v.preview.loading && v.preview.artifact.ID == msg.artifactID {
+			v.preview.loading = false
+			v.preview.err = msg.err
+			v.preview.setArchive(msg.archive)
+		}
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		if v.prompt != nil {
+			return v.updatePrompt(msg)
+		}
+		if v.preview != nil {
+			return v, v.updatePreview(msg)
+		}
+		return v.updateKeys(msg)
+
+	case tea.MouseMsg:
+		if !v.focused || v.prompt != nil {
+			return v, nil
+		}
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.move(-1)
+		case tea.MouseWheelDown:
+			v.move(1)
+		}
+	}
+
+	return v, nil
+}
+
+// updateKeys handles keys while browsing the artifacts
+func (v *ArtifactView) updateKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	v.notice = ""
+	switch msg.String() {
+	case "esc", "q":
+		return v, v.close()
+	case "down", "j":
+		v.move(1)
+	case "up", "k":
+		v.move(-1)
+	case "d":
+		if artifact, ok := v.selected(); ok {
+			v.askDirectory([]Artifact{artifact})
+			return v, textinput.Blink
+		}
+	case "D":
+		var artifacts []Artifact
+		for _, artifact := range v.artifacts {
+			if !artifactExpired(artifact) {
+				artifacts = append(artifacts, artifact)
+			}
+		}
+		if len(artifacts) == 0 {
+			v.notice = "No artifacts to download"
+			return v, nil
+		}
+		v.askDirectory(artifacts)
+		return v, textinput.Blink
+	case "enter", "p":
+		artifact, ok := v.selected()
+		if !ok {
+			return v, nil
+		}
+		if artifactExpired(artifact) {
+			v.notice = artifact.Name + " has expired"
+			return v, nil
+		}
+		v.preview = &artifactPreview{artifact: artifact}
+		if archive, ok := v.archives[artifact.ID]; ok {
+			v.preview.setArchive(archive)
+			return v, nil
+		}
+		v.preview.loading = true
+		return v, fetchArtifactArchive(v.repo, artifact)
+	case "b":
+		return v, openInBrowser("run", fmt.Sprintf("%d", v.runID), v.repo)
+	}
+	return v, nil
+}
+
+// askDirectory prompts for the directory to download artifacts into
+func (v *ArtifactView) askDirectory(artifacts []Artifact) {
+	if v.downloading {
+		v.notice = "Wait for the current downloads to finish"
+		return
+	}
+	for _, artifact := range artifacts {
+		if artifactExpired(artifact) {
+			v.notice = artifact.Name + " has expired"
+			return
+		}
+	}
+
+	prompt := textinput.New()
+	prompt.Prompt = fmt.Sprintf("Download %s to: ", pluralize(len(artifacts), "artifact"))
+	prompt.SetValue(v.dir)
+	prompt.CursorEnd()
+	prompt.Focus()
+	v.prompt = &prompt
+	v.queued = artifacts
+}
+
+// updatePrompt handles keys in the directory prompt
+func (v *ArtifactView) updatePrompt(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.prompt = nil
+		v.queued = nil
+		return v, nil
+	case "enter":
+		dir := strings.TrimSpace(v.prompt.Value())
+		v.prompt = nil
+		if dir == "" {
+			return v, nil
+		}
+		v.dir = dir
+		for _, artifact := range v.queued {
+			v.downloads[artifact.ID] = &artifactDownload{total: artifact.SizeInBytes}
+		}
+		v.downloading = true
+		cmd := downloadArtifacts(v.repo, v.runID, v.queued, dir, v.stop)
+		v.queued = nil
+		return v, cmd
+	}
+
+	prompt, cmd := v.prompt.Update(msg)
+	v.prompt = &prompt
+	return v, cmd
+}
+
+// updatePreview handles keys while previewing an archive or one of its files
+func (v *ArtifactView) updatePreview(msg tea.KeyMsg) tea.Cmd {
+	p := v.preview
+	v.notice = ""
+	page := max(1, v.height-5)
+
+	if p.file != nil {
+		switch msg.String() {
+		case "esc", "q", "backspace":
+			p.file = nil
+			p.lines = nil
+		case "down", "j":
+			p.scroll++
+		case "up", "k":
+			p.scroll--
+		case "ctrl+d", "pgdown", " ":
+			p.scroll += page
+		case "ctrl+u", "pgup":
+			p.scroll -= page
+		case "g", "home":
+			p.scroll = 0
+		case "G", "end":
+			p.scroll = len(p.lines)
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "backspace":
+		v.preview = nil
+	case "down", "j":
+		p.cursor = min(p.cursor+1, max(0, len(p.files)-1))
+	case "up", "k":
+		p.cursor = max(0, p.cursor-1)
+	case "enter":
+		if p.cursor >= len(p.files) {
+			return nil
+		}
+		file := p.files[p.cursor]
+		text, isText, err := readArtifactFile(file)
+		switch {
+		case err != nil:
+			v.notice = fmt.Sprintf("Can't read %s: %v", file.Name, err)
+		case !isText:
+			v.notice = file.Name + " isn't a text file; download the artifact to open it"
+		default:
+			p.file = file
+			p.lines = strings.Split(strings.TrimRight(strings.ReplaceAll(text, "\t", "    "), "\n"), "\n")
+			p.truncated = file.UncompressedSize64 > artifactFileLimit
+			p.scroll = 0
+		}
+	case "d":
+		v.preview = nil
+		v.askDirectory([]Artifact{p.artifact})
+		return textinput.Blink
+	}
+	return nil
+}
+
+// setArchive lists the files of an archive, leaving out directories
+func (p *artifactPreview) setArchive(archive *zip.Reader) {
+	if archive == nil {
+		return
+	}
+	p.files = nil
+	for _, f := range archive.File {
+		if !f.FileInfo().IsDir() {
+			p.files = append(p.files, f)
+		}
+	}
+}
+
+// move moves the cursor of whatever is shown
+func (v *ArtifactView) move(delta int) {
+	if p := v.preview; p != nil {
+		if p.file != nil {
+			p.scroll += delta
+		} else {
+			p.cursor = max(0, min(p.cursor+delta, len(p.files)-1))
+		}
+		return
+	}
+	v.cursor = max(0, min(v.cursor+delta, len(v.artifacts)-1))
+}
+
+// selected returns the artifact under the cursor
+func (v *ArtifactView) selected() (Artifact, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.artifacts) {
+		return Artifact{}, false
+	}
+	return v.artifacts[v.cursor], true
+}
+
+// downloadSummary describes how the last downloads went
+func (v *ArtifactView) downloadSummary() string {
+	saved, failed := 0, 0
+	var lastErr error
+	for _, download := range v.downloads {
+		switch {
+		case download.err != nil:
+			failed++
+			lastErr = download.err
+		case download.finished:
+			saved++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("%s failed (%v); %s saved to %s", pluralize(failed, "download"), lastErr, pluralize(saved, "artifact"), v.dir)
+	}
+	return fmt.Sprintf("Downloaded %s to %s", pluralize(saved, "artifact"), v.dir)
+}
+
+// close ends the downloads and closes the view
+func (v *ArtifactView) close() tea.Cmd {
+	if !v.stopped {
+		v.stopped = true
+		close(v.stop)
+	}
+	return closeOverlay()
+}
+
+// artifactExpired tells whether an artifact can no longer be downloaded
+func artifactExpired(artifact Artifact) bool {
+	return artifact.Expired || (!artifact.ExpiresAt.IsZero() && artifact.ExpiresAt.Before(time.Now()))
+}
+
+// formatExpiry describes when an artifact expires
+func formatExpiry(artifact Artifact) string {
+	switch {
+	case artifactExpired(artifact):
+		return "expired"
+	case artifact.ExpiresAt.IsZero():
+		return ""
+	}
+	left := time.Until(artifact.ExpiresAt)
+	if left >= 48*time.Hour {
+		return fmt.Sprintf("expires in %dd", int(left.Hours()/24))
+	}
+	return "expires in " + formatDuration(left)
+}
+
+// View renders the artifact view
+func (v *ArtifactView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	switch {
+	case v.loading:
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			infoStyle.Render(fmt.Sprintf("Loading artifacts of %s...", v.title)))
+	case v.err != nil:
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			errorStyle.Render(fmt.Sprintf("Error: %v\n\nPress Esc to go back", v.err)))
+	case len(v.artifacts) == 0:
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			dimmedStyle.Render(fmt.Sprintf("%s has no artifacts\n\nPress Esc to go back", v.title)))
+	}
+
+	if v.preview != nil {
+		return v.renderPreview(width, height)
+	}
+
+	// Header: run and totals
+	var total int64
+	for _, artifact := range v.artifacts {
+		total += artifact.SizeInBytes
+	}
+	title := detailTitleStyle.Render(" Artifacts ") + dimmedStyle.Render(truncateString(v.title, width/2))
+	totals := dimmedStyle.Render(pluralize(len(v.artifacts), "artifact") + ", " + formatBytes(total) + " ")
+	if v.downloading {
+		totals = statusPendingStyle.Render("● downloading ") + totals
+	}
+	header := title + strings.Repeat(" ", max(1, width-lipgloss.Width(title)-lipgloss.Width(totals))) + totals
+
+	// Name, size, expiry and download columns
+	const sizeWidth, expiryWidth, downloadWidth = 10, 16, 30
+	nameWidth := max(10, width-2-sizeWidth-expiryWidth-downloadWidth-3)
+	var lines []string
+	for i, artifact := range v.artifacts {
+		gutter := "  "
+		if i == v.cursor {
+			gutter = listCursorStyle.Render("▶ ")
+		}
+		name := highlightStyle.Render(fitWidth(artifact.Name, nameWidth))
+		expiry := dimmedStyle.Render(fitWidth(formatExpiry(artifact), expiryWidth))
+		if artifactExpired(artifact) {
+			name = dimmedStyle.Render(fitWidth(artifact.Name, nameWidth))
+			expiry = statusFailureStyle.Render(fitWidth(formatExpiry(artifact), expiryWidth))
+		}
+		size := fmt.Sprintf("%*s", sizeWidth, formatBytes(artifact.SizeInBytes))
+		lines = append(lines, gutter+name+" "+size+" "+expiry+" "+v.renderDownload(artifact.ID, downloadWidth))
+	}
+
+	// Where the selected artifact went
+	if artifact, ok := v.selected(); ok {
+		if download, ok := v.downloads[artifact.ID]; ok && download.finished {
+			lines = append(lines, "")
+			if download.err != nil {
+				lines = append(lines, statusFailureStyle.Render(truncateString("  Download failed: "+download.err.Error(), width)))
+			} else {
+				lines = append(lines, dimmedStyle.Render(truncateString("  Saved to "+download.path, width)))
+			}
+		}
+	}
+
+	body := lipgloss.NewStyle().Width(width).Height(max(1, height-3)).Render(strings.Join(lines, "\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body, v.renderFooter(width,
+		" j/k: Move • Enter: Preview files • d: Download • D: Download all • b: Browser • Esc: Back"))
+}
+
+// renderDownload renders the download state of an artifact in width columns
+func (v *ArtifactView) renderDownload(id int64, width int) string {
+	download, ok := v.downloads[id]
+	switch {
+	case !ok:
+		return ""
+	case download.err != nil:
+		return statusFailureStyle.Render("✗ failed")
+	case download.finished:
+		return statusSuccessStyle.Render("✓ saved")
+	case download.done == 0:
+		return dimmedStyle.Render("waiting...")
+	}
+	percent := 100
+	if download.total > 0 && download.done < download.total {
+		percent = int(download.done * 100 / download.total)
+	}
+	return statusPendingStyle.Render(renderProgressBar(download.done, download.total, width-6)) + fmt.Sprintf(" %3d%%", percent)
+}
+
+// renderFooter renders hints, with any notice first, or the directory prompt
+func (v *ArtifactView) renderFooter(width int, hints string) string {
+	if v.prompt != nil {
+		v.prompt.Width = max(10, width-lipgloss.Width(v.prompt.Prompt)-2)
+		return v.prompt.View()
+	}
+	if v.notice != "" {
+		hints = " " + v.notice + " •" + hints
+	}
+	return helpStyle.MaxWidth(width).Render(hints)
+}
+
+// renderPreview renders the files of an archive, or the open file
+func (v *ArtifactView) renderPreview(width, height int) string {
+	p := v.preview
+	rows := max(1, height-3)
+
+	title := detailTitleStyle.Render(" Preview ") + highlightStyle.Render(truncateString(p.artifact.Name, width/2))
+	var lines []string
+	var info, hints string
+	switch {
+	case p.loading:
+		info = statusPendingStyle.Render("● reading archive ")
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("Downloading %s (%s)...", p.artifact.Name, formatBytes(p.artifact.SizeInBytes))))
+		hints = " Esc: Back"
+	case p.err != nil:
+		lines = append(lines, errorStyle.Render(strings.Join(wrapText("Error: "+p.err.Error(), width-4), "\n")))
+		hints = " d: Download • Esc: Back"
+	case p.file != nil:
+		title += dimmedStyle.Render(" › " + truncateString(p.file.Name, width/3))
+		info = dimmedStyle.Render(pluralize(len(p.lines), "line") + " ")
+		if p.truncated {
+			info = warningStyle.Padding(0).Render(fmt.Sprintf("first %s only ", formatBytes(artifactFileLimit))) + info
+		}
+		p.scroll = max(0, min(p.scroll, len(p.lines)-rows))
+		numberWidth := len(fmt.Sprintf("%d", len(p.lines)))
+		for i := p.scroll; i < min(len(p.lines), p.scroll+rows); i++ {
+			number := dimmedStyle.Render(fmt.Sprintf("%*d ", numberWidth+1, i+1))
+			lines = append(lines, number+fitWidth(p.lines[i], max(1, width-numberWidth-2)))
+		}
+		hints = " j/k: Scroll • Ctrl+D/U: Page • g/G: Top/bottom • Esc: Files"
+	default:
+		var size uint64
+		for _, f := range p.files {
+			size += f.UncompressedSize64
+		}
+		info = dimmedStyle.Render(pluralize(len(p.files), "file") + ", " + formatBytes(int64(size)) + " unpacked ")
+		start := max(0, min(p.cursor-rows/2, len(p.files)-rows))
+		for i := start; i < min(len(p.files), start+rows); i++ {
+			f := p.files[i]
+			gutter := "  "
+			if i == p.cursor {
+				gutter = listCursorStyle.Render("▶ ")
+			}
+			size := fmt.Sprintf("%10s", formatBytes(int64(f.UncompressedSize64)))
+			lines = append(lines, gutter+fitWidth(f.Name, max(10, width-14))+dimmedStyle.Render(size))
+		}
+		if len(p.files) == 0 {
+			lines = append(lines, dimmedStyle.Render("  The archive is empty"))
+		}
+		hints = " j/k: Move • Enter: View file • d: Download • Esc: Artifacts"
+	}
+
+	header := title + strings.Repeat(" ", max(1, width-lipgloss.Width(title)-lipgloss.Width(info))) + info
+	body := lipgloss.NewStyle().Width(width).Height(rows).Render(strings.Join(lines, "\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body, v.renderFooter(width, hints))
+}
+
+// Focus sets the view as focused
+func (v *ArtifactView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *ArtifactView) Blur() {
+	v.focused = false
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CheckoutDialog checks a PR out into its worktree, then offers to open
+// a shell or the editor there
+type CheckoutDialog struct {
+	repo    string
+	number  int
+	branch  string // PR head branch, for display
+	loading bool
+	focused bool
+	path    string
+	reused  bool
+	note    string
+	err     error
+}
+
+// NewCheckoutDialog creates the checkout dialog for pr; the worktree arrives as worktreeReadyMsg
+func NewCheckoutDialog(repo string, pr PullRequest) *CheckoutDialog {
+	return &CheckoutDialog{
+		repo:    repo,
+		number:  pr.Number,
+		branch:  pr.HeadRefName,
+		loading: true,
+	}
+}
+
+// openCheckoutDialog opens the checkout dialog for pr and starts the checkout
+func openCheckoutDialog(repo string, pr PullRequest) tea.Cmd {
+	return tea.Sequence(openOverlay(NewCheckoutDialog(repo, pr)), checkoutPRWorktree(repo, pr.Number))
+}
+
+// Update handles messages for the checkout dialog
+func (d *CheckoutDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case worktreeReadyMsg:
+		if msg.number != d.number {
+			return d, nil
+		}
+		d.loading = false
+		d.path, d.reused, d.note, d.err = msg.path, msg.reused, msg.note, msg.err
+
+	case tea.KeyMsg:
+		if !d.focused {
+			return d, nil
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return d, closeOverlay()
+		case "s":
+			if d.path != "" {
+				return d, tea.Batch(closeOverlay(), openWorktreeShell(d.path))
+			}
+		case "e":
+			if d.path != "" {
+				return d, tea.Batch(closeOverlay(), openWorktreeEditor(d.path))
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// View renders the dialog centered in the content area
+func (d *CheckoutDialog) View(width, height int) string {
+	boxWidth := min(72, width-4)
+	inner := boxWidth - 6
+
+	lines := []string{dialogTitleStyle.Width(inner).Render(fmt.Sprintf("Check out #%d", d.number)), ""}
+	lines = append(lines, dimmedStyle.Render(truncateString(d.branch, inner)), "")
+
+	switch {
+	case d.loading:
+		lines = append(lines, infoStyle.Padding(0).Render("Fetching the PR head..."))
+		lines = append(lines, "", helpStyle.Render("Esc: Close (the checkout continues)"))
+	case d.err != nil:
+		lines = append(lines, statusFailureStyle.Render("Checkout failed"))
+		lines = append(lines, wrapText(d.err.Error(), inner)...)
+		lines = append(lines, "", helpStyle.Render("Esc: Close"))
+	default:
+		status := fmt.Sprintf("Created worktree on branch pr-%d", d.number)
+		if d.reused {
+			status = "Reused the existing worktree"
+		}
+		lines = append(lines, statusSuccessStyle.Render(status))
+		lines = append(lines, highlightStyle.Render(truncateString(d.path, inner)))
+		if d.note != "" {
+			lines = append(lines, "")
+			lines = append(lines, warningStyle.Padding(0).Render(strings.Join(wrapText("⚠ "+d.note, inner), "\n")))
+		}
+		lines = append(lines, "", helpStyle.Render("s: Open shell • e: Open $EDITOR • Esc: Close"))
+	}
+
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Focus sets the dialog as focused
+func (d *CheckoutDialog) Focus() {
+	d.focused = true
+}
+
+// Blur sets the dialog as unfocused
+func (d *CheckoutDialog) Blur() {
+	d.focused = false
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CodeView shows a file found by code search, scrolled to its first match,
+// with the searched terms picked out
+type CodeView struct {
+	result  CodeResult
+	terms   []string // Lower case text the search matched
+	loading bool
+	err     error
+	lines   []string
+	matches []int // Lines with a match
+	scroll  int
+	focused bool
+	width   int
+	height  int
+}
+
+// openCodeView opens the file of a code search result
+func openCodeView(result CodeResult) tea.Cmd {
+	v := &CodeView{result: result, loading: true}
+	seen := make(map[string]bool)
+	for _, fragment := range result.Fragments {
+		for _, span := range fragment.Matches {
+			term := strings.ToLower(fragment.Text[span[0]:span[1]])
+			if !seen[term] {
+				seen[term] = true
+				v.terms = append(v.terms, term)
+			}
+		}
+	}
+	return tea.Sequence(openOverlay(v), fetchCodeFile(result.Repo, result.Path))
+}
+
+// Update handles messages for the code view
+func (v *CodeView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case codeFileLoadedMsg:
+		if msg.repo != v.result.Repo || msg.path != v.result.Path || !v.loading {
+			return v, nil
+		}
+		v.loading = false
+		v.err = msg.err
+		if msg.err != nil {
+			return v, nil
+		}
+		v.lines = strings.Split(strings.TrimRight(strings.ReplaceAll(msg.content, "\t", "    "), "\n"), "\n")
+		for i, line := range v.lines {
+			if len(findSpans(line, v.terms)) > 0 {
+				v.matches = append(v.matches, i)
+			}
+		}
+		if len(v.matches) > 0 {
+			v.scrollTo(v.matches[0])
+		}
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		page := max(1, v.rows())
+		switch msg.String() {
+		case "esc", "q":
+			return v, closeOverlay()
+		case "down", "j":
+			v.scroll++
+		case "up", "k":
+			v.scroll--
+		case "ctrl+d", "pgdown", " ":
+			v.scroll += page
+		case "ctrl+u", "pgup":
+			v.scroll -= page
+		case "g", "home":
+			v.scroll = 0
+		case "G", "end":
+			v.scroll = len(v.lines)
+		case "n":
+			v.nextMatch(1)
+		case "N":
+			v.nextMatch(-1)
+		}
+
+	case tea.MouseMsg:
+		if !v.focused {
+			return v, nil
+		}
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.scroll -= 3
+		case tea.MouseWheelDown:
+			v.scroll += 3
+		}
+	}
+	return v, nil
+}
+
+// rows is the number of lines of the file shown at once
+func (v *CodeView) rows() int {
+	return max(1, v.height-3)
+}
+
+// scrollTo scrolls line into the upper third of the view
+func (v *CodeView) scrollTo(line int) {
+	v.scroll = line - v.rows()/3
+}
+
+// nextMatch scrolls to the next matching line below (1) or above (-1) the
+// one scrolled to
+func (v *CodeView) nextMatch(direction int) {
+	current := v.scroll + v.rows()/3
+	if direction > 0 {
+		for _, line := range v.matches {
+			if line > current {
+				v.scrollTo(line)
+				return
+			}
+		}
+		return
+	}
+	for i := len(v.matches) - 1; i >= 0; i-- {
+		if v.matches[i] < current {
+			v.scrollTo(v.matches[i])
+			return
+		}
+	}
+}
+
+// View renders the file
+func (v *CodeView) View(width, height int) string {
+	v.width = width
+	v.height = height
+	rows := v.rows()
+
+	title := detailTitleStyle.Render(" Code ") + highlightStyle.Render(truncateString(v.result.Repo, width/3)) +
+		dimmedStyle.Render(" › "+truncateString(v.result.Path, width/3))
+	var info string
+	var lines []string
+	switch {
+	case v.loading:
+		info = statusPendingStyle.Render("● loading ")
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("Fetching %s...", v.result.Path)))
+	case v.err != nil:
+		lines = append(lines, errorStyle.Render(strings.Join(wrapText("Error: "+v.err.Error(), width-4), "\n")))
+	default:
+		info = dimmedStyle.Render(pluralize(len(v.lines), "line") + " ")
+		if len(v.matches) > 0 {
+			info = highlightStyle.Render(fmt.Sprintf("%d matching ", len(v.matches))) + info
+		}
+		v.scroll = max(0, min(v.scroll, len(v.lines)-rows))
+		numberWidth := len(fmt.Sprintf("%d", len(v.lines)))
+		for i := v.scroll; i < min(len(v.lines), v.scroll+rows); i++ {
+			number := dimmedStyle.Render(fmt.Sprintf("%*d ", numberWidth+1, i+1))
+			text := strings.TrimRight(fitWidth(v.lines[i], max(1, width-numberWidth-2)), " ")
+			lines = append(lines, number+highlightSpans(text, findSpans(text, v.terms), lipgloss.NewStyle()))
+		}
+	}
+
+	header := title + strings.Repeat(" ", max(1, width-lipgloss.Width(title)-lipgloss.Width(info))) + info
+	body := lipgloss.NewStyle().Width(width).Height(rows).Render(strings.Join(lines, "\n"))
+	hints := helpStyle.MaxWidth(width).Render(" j/k: Scroll • Ctrl+D/U: Page • g/G: Top/bottom • n/N: Next/prev match • Esc: Back")
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body, hints)
+}
+
+// Focus sets the view as focused
+func (v *CodeView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *CodeView) Blur() {
+	v.focused = false
+}
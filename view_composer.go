@@ -0,0 +1,520 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fields of the composer, in tab order; comments only have the body
+const (
+	composerTitle = iota
+	composerBody
+	composerLabels
+	composerAssignees
+	composerMilestone
+	composerFieldCount
+)
+
+// ComposerDialog writes a new issue, or a comment on an issue or PR
+// The body can be typed inline or handed to $EDITOR with Ctrl+O. New issues
+// also get label, assignee and milestone pickers, and a choice of the repo's
+// issue templates before the form when it has any.
+type ComposerDialog struct {
+	repo    string
+	number  int    // Issue or PR commented on; 0 for a new issue
+	subject string // What is commented on, for the title
+	focused bool
+
+	title textinput.Model
+	body  textarea.Model
+	field int
+
+	// Choices for new issues, as loaded by fetchComposerOptions
+	loading    bool
+	optionsErr error
+	labels     []Label
+	assignees  []string
+	milestones []Milestone
+	templates  []IssueTemplate
+
+	choosingTemplate bool
+	templateCursor   int    // 0 is a blank issue, then one per template
+	template         string // Name of the template applied
+
+	picker      *Picker // Open label, assignee or milestone picker
+	pickerField int
+
+	chosenLabels    []string
+	chosenAssignees []string
+	milestone       *Milestone
+
+	submitting bool
+	discarding bool // Esc was pressed once with text in the composer
+	err        error
+}
+
+// newComposer creates the composer with its inputs set up
+func newComposer(repo string, number int, subject string) *ComposerDialog {
+	title := textinput.New()
+	title.Placeholder = "Title"
+	title.Prompt = ""
+
+	body := textarea.New()
+	body.ShowLineNumbers = false
+	body.Placeholder = "Leave a comment (Markdown)"
+	body.CharLimit = 0
+
+	return &ComposerDialog{repo: repo, number: number, subject: subject, title: title, body: body}
+}
+
+// openIssueComposer opens the composer for a new issue in repo
+func openIssueComposer(repo string) tea.Cmd {
+	d := newComposer(repo, 0, "")
+	d.body.Placeholder = "Describe the issue (Markdown)"
+	d.loading = true
+	d.title.Focus()
+	return tea.Sequence(openOverlay(d), fetchComposerOptions(repo))
+}
+
+// openCommentComposer opens the composer for a comment on issue or PR number
+func openCommentComposer(repo string, number int, subject string) tea.Cmd {
+	d := newComposer(repo, number, subject)
+	d.field = composerBody
+	return openOverlay(d)
+}
+
+// Update handles messages for the composer
+func (d *ComposerDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case composerOptionsLoadedMsg:
+		if msg.repo != d.repo || !d.loading {
+			return d, nil
+		}
+		d.loading = false
+		d.optionsErr = msg.err
+		d.labels = msg.labels
+		d.assignees = msg.assignees
+		d.milestones = msg.milestones
+		d.templates = msg.templates
+		// Offer the templates unless the user already started typing
+		if len(d.templates) > 0 && !d.dirty() {
+			d.choosingTemplate = true
+			d.title.Blur()
+		}
+		return d, nil
+
+	case composerEditedMsg:
+		if msg.err != nil {
+			d.err = fmt.Errorf("editor: %w", msg.err)
+			return d, nil
+		}
+		d.err = nil
+		d.body.SetValue(msg.body)
+		return d, nil
+
+	case issueCreatedMsg:
+		if msg.repo != d.repo || d.number != 0 || !d.submitting {
+			return d, nil
+		}
+		return d, d.submitted(msg.err)
+
+	case commentAddedMsg:
+		if msg.repo != d.repo || msg.number != d.number || !d.submitting {
+			return d, nil
+		}
+		return d, d.submitted(msg.err)
+
+	case tea.KeyMsg:
+		if !d.focused || d.submitting {
+			return d, nil
+		}
+		if d.picker != nil {
+			d.handlePickerKey(msg)
+			return d, nil
+		}
+		if d.choosingTemplate {
+			return d, d.handleTemplateKey(msg)
+		}
+
+		if msg.String() == "esc" {
+			if d.dirty() && !d.discarding {
+				d.discarding = true
+				return d, nil
+			}
+			return d, closeOverlay()
+		}
+		d.discarding = false
+
+		switch msg.String() {
+		case "ctrl+s":
+			return d, d.submit()
+		case "ctrl+o":
+			return d, editComposerBody(d.body.Value())
+		case "tab":
+			return d, d.moveField(1)
+		case "shift+tab":
+			return d, d.moveField(-1)
+		}
+
+		switch d.field {
+		case composerTitle:
+			if msg.String() == "enter" {
+				return d, d.moveField(1)
+			}
+		case composerLabels, composerAssignees, composerMilestone:
+			switch msg.String() {
+			case "enter", " ":
+				d.openPicker()
+			case "backspace", "delete":
+				d.clearField()
+			case "up", "k":
+				return d, d.moveField(-1)
+			case "down", "j":
+				return d, d.moveField(1)
+			}
+			return d, nil
+		}
+	}
+
+	// Text input, cursor blink
+	var cmd tea.Cmd
+	switch d.field {
+	case composerTitle:
+		d.title, cmd = d.title.Update(msg)
+	case composerBody:
+		d.body, cmd = d.body.Update(msg)
+	}
+	return d, cmd
+}
+
+// dirty reports whether anything was typed that closing would lose
+func (d *ComposerDialog) dirty() bool {
+	return strings.TrimSpace(d.title.Value()) != "" || strings.TrimSpace(d.body.Value()) != ""
+}
+
+// submitted closes the composer, or shows why submitting failed
+func (d *ComposerDialog) submitted(err error) tea.Cmd {
+	d.submitting = false
+	if err != nil {
+		d.err = err
+		return nil
+	}
+	return closeOverlay()
+}
+
+// handleTemplateKey moves through and applies the issue templates
+func (d *ComposerDialog) handleTemplateKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		return closeOverlay()
+	case "up", "k":
+		if d.templateCursor > 0 {
+			d.templateCursor--
+		}
+	case "down", "j":
+		if d.templateCursor < len(d.templates) {
+			d.templateCursor++
+		}
+	case "enter":
+		d.choosingTemplate = false
+		if d.templateCursor > 0 {
+			d.applyTemplate(d.templates[d.templateCursor-1])
+		}
+		d.field = composerTitle
+		return d.title.Focus()
+	}
+	return nil
+}
+
+// applyTemplate fills the form in from template
+func (d *ComposerDialog) applyTemplate(template IssueTemplate) {
+	d.template = template.Name
+	d.title.SetValue(template.Title)
+	d.title.CursorEnd()
+	d.body.SetValue(template.Body)
+	d.chosenLabels = append([]string{}, template.Labels...)
+	d.chosenAssignees = append([]string{}, template.Assignees...)
+}
+
+// moveField focuses the field delta away, wrapping around
+func (d *ComposerDialog) moveField(delta int) tea.Cmd {
+	if d.number != 0 {
+		return nil
+	}
+	d.field = (d.field + delta + composerFieldCount) % composerFieldCount
+
+	d.title.Blur()
+	d.body.Blur()
+	switch d.field {
+	case composerTitle:
+		return d.title.Focus()
+	case composerBody:
+		return d.body.Focus()
+	}
+	return nil
+}
+
+// openPicker opens the picker of the focused field
+func (d *ComposerDialog) openPicker() {
+	if d.loading {
+		return
+	}
+	d.pickerField = d.field
+
+	switch d.field {
+	case composerLabels:
+		items := make([]PickerItem, len(d.labels))
+		for i, label := range d.labels {
+			items[i] = PickerItem{Label: label.Name, Color: label.Color}
+		}
+		d.picker = NewMultiPicker("Labels", items, d.chosenLabels)
+	case composerAssignees:
+		items := make([]PickerItem, len(d.assignees))
+		for i, login := range d.assignees {
+			items[i] = PickerItem{Label: login}
+		}
+		d.picker = NewMultiPicker("Assignees", items, d.chosenAssignees)
+	case composerMilestone:
+		items := []PickerItem{{Label: "No milestone", Value: "0"}}
+		for _, milestone := range d.milestones {
+			items = append(items, PickerItem{Label: milestone.Title, Value: strconv.Itoa(milestone.Number)})
+		}
+		d.picker = NewPicker("Milestone", items)
+	}
+}
+
+// handlePickerKey passes a key to the open picker and applies its result
+func (d *ComposerDialog) handlePickerKey(msg tea.KeyMsg) {
+	switch d.picker.HandleKey(msg) {
+	case PickerCancelled:
+		d.picker = nil
+	case PickerChosen:
+		switch d.pickerField {
+		case composerLabels:
+			d.chosenLabels = d.picker.Checked()
+		case composerAssignees:
+			d.chosenAssignees = d.picker.Checked()
+		case composerMilestone:
+			if item, ok := d.picker.Selected(); ok {
+				d.milestone = nil
+				for i := range d.milestones {
+					if strconv.Itoa(d.milestones[i].Number) == item.Value {
+						d.milestone = &d.milestones[i]
+					}
+				}
+			}
+		}
+		d.picker = nil
+	}
+}
+
+// clearField empties the focused picker field
+func (d *ComposerDialog) clearField() {
+	switch d.field {
+	case composerLabels:
+		d.chosenLabels = nil
+	case composerAssignees:
+		d.chosenAssignees = nil
+	case composerMilestone:
+		d.milestone = nil
+	}
+}
+
+// submit checks the form and creates the issue or posts the comment
+func (d *ComposerDialog) submit() tea.Cmd {
+	body := strings.TrimSpace(d.body.Value())
+	if d.number != 0 {
+		if body == "" {
+			d.err = fmt.Errorf("the comment is empty")
+			return nil
+		}
+		d.err = nil
+		d.submitting = true
+		return postComment(d.repo, d.number, body)
+	}
+
+	title := strings.TrimSpace(d.title.Value())
+	if title == "" {
+		d.err = fmt.Errorf("an issue needs a title")
+		d.field = composerTitle + 1
+		return d.moveField(-1)
+	}
+	issue := NewIssue{Title: title, Body: body, Labels: d.chosenLabels, Assignees: d.chosenAssignees}
+	if d.milestone != nil {
+		issue.Milestone = d.milestone.Number
+	}
+	d.err = nil
+	d.submitting = true
+	return submitIssue(d.repo, issue)
+}
+
+// View renders the composer centered in the content area
+func (d *ComposerDialog) View(width, height int) string {
+	if d.picker != nil {
+		pickerWidth := min(60, width-4)
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			d.picker.View(pickerWidth, min(height, 24)))
+	}
+
+	boxWidth := min(90, width-4)
+	inner := boxWidth - 6
+
+	heading := "New issue"
+	if d.number != 0 {
+		heading = "Comment on " + d.subject
+	}
+	if d.repo != "" {
+		heading += " in " + d.repo
+	}
+	lines := []string{dialogTitleStyle.Width(inner).Render(truncateString(heading, inner)), ""}
+
+	var help string
+	switch {
+	case d.choosingTemplate:
+		lines = append(lines, d.renderTemplates(inner)...)
+		help = "↑/↓: Choose • Enter: Use template • Esc: Cancel"
+	case d.number != 0:
+		d.body.SetWidth(inner)
+		d.body.SetHeight(max(3, min(20, height-12)))
+		lines = append(lines, d.body.View())
+		help = "Ctrl+O: Editor • Ctrl+S: Comment • Esc: Cancel"
+	default:
+		if d.template != "" {
+			lines = append(lines, dimmedStyle.Render(truncateString("Template: "+d.template, inner)), "")
+		}
+		d.title.Width = inner - 4
+		lines = append(lines, d.label(composerTitle, "Title"), "  "+d.title.View(), "")
+
+		d.body.SetWidth(inner)
+		d.body.SetHeight(max(3, min(20, height-20)))
+		lines = append(lines, d.label(composerBody, "Body"), d.body.View(), "")
+
+		var labels []Label
+		for _, name := range d.chosenLabels {
+			labels = append(labels, d.labelNamed(name))
+		}
+		var assignees []Label
+		for _, login := range d.chosenAssignees {
+			assignees = append(assignees, Label{Name: login})
+		}
+		var milestone []Label
+		if d.milestone != nil {
+			milestone = append(milestone, Label{Name: d.milestone.Title})
+		}
+		lines = append(lines,
+			d.pickerLine(composerLabels, "Labels", labels, inner),
+			d.pickerLine(composerAssignees, "Assignees", assignees, inner),
+			d.pickerLine(composerMilestone, "Milestone", milestone, inner))
+		if d.loading {
+			lines = append(lines, dimmedStyle.Render("  Loading labels, assignees and milestones..."))
+		} else if d.optionsErr != nil {
+			lines = append(lines, statusPendingStyle.Render(truncateString("  Some choices failed to load: "+d.optionsErr.Error(), inner)))
+		}
+		help = "Tab: Field • Space: Pick • Ctrl+O: Editor • Ctrl+S: Create • Esc: Cancel"
+	}
+
+	lines = append(lines, "")
+	switch {
+	case d.submitting:
+		lines = append(lines, infoStyle.Padding(0).Render("Submitting..."))
+	case d.discarding:
+		lines = append(lines, statusPendingStyle.Render("Press Esc again to discard what you wrote"))
+	case d.err != nil:
+		lines = append(lines, statusFailureStyle.Render(strings.Join(wrapText("Error: "+d.err.Error(), inner), "\n")))
+	}
+	lines = append(lines, helpStyle.Render(strings.Join(wrapText(help, inner), "\n")))
+
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderTemplates renders the choice between a blank issue and the templates
+func (d *ComposerDialog) renderTemplates(width int) []string {
+	lines := []string{detailTitleStyle.Render("Choose a template"), ""}
+	names := []string{"Blank issue"}
+	abouts := []string{"Start from an empty form"}
+	for _, template := range d.templates {
+		names = append(names, template.Name)
+		abouts = append(abouts, template.About)
+	}
+
+	for i, name := range names {
+		cursor, style := "  ", listItemStyle
+		if i == d.templateCursor {
+			cursor, style = "▶ ", listSelectedStyle
+		}
+		lines = append(lines, style.Render(cursor+truncateString(name, width-2)))
+		if abouts[i] != "" {
+			lines = append(lines, dimmedStyle.Render("  "+truncateString(abouts[i], width-2)))
+		}
+	}
+	return lines
+}
+
+// label renders a field label, highlighted when the field is focused
+func (d *ComposerDialog) label(field int, text string) string {
+	if d.field == field {
+		return highlightStyle.Render("▶ " + text)
+	}
+	return dimmedStyle.Render("  " + text)
+}
+
+// pickerLine renders a picker field with the chosen values, as many as fit
+func (d *ComposerDialog) pickerLine(field int, text string, values []Label, width int) string {
+	line := d.label(field, padRight(text, 12))
+	if len(values) == 0 {
+		return line + dimmedStyle.Render("None")
+	}
+
+	room := width - 14
+	for i, value := range values {
+		more := ""
+		if i < len(values)-1 {
+			more = fmt.Sprintf(", +%d", len(values)-i-1)
+		}
+		if len(value.Name)+len(more) > room && i > 0 {
+			return line + dimmedStyle.Render(fmt.Sprintf("+%d", len(values)-i))
+		}
+		if i > 0 {
+			line += ", "
+		}
+		line += formatLabel(Label{Name: truncateString(value.Name, room), Color: value.Color})
+		room -= len(value.Name) + 2
+	}
+	return line
+}
+
+// labelNamed returns the repo's label called name, with its color when known
+func (d *ComposerDialog) labelNamed(name string) Label {
+	for _, label := range d.labels {
+		if label.Name == name {
+			return label
+		}
+	}
+	return Label{Name: name}
+}
+
+// Focus sets the composer as focused
+func (d *ComposerDialog) Focus() {
+	d.focused = true
+	switch {
+	case d.choosingTemplate:
+	case d.field == composerTitle:
+		d.title.Focus()
+	case d.field == composerBody:
+		d.body.Focus()
+	}
+}
+
+// Blur sets the composer as unfocused
+func (d *ComposerDialog) Blur() {
+	d.focused = false
+	d.title.Blur()
+	d.body.Blur()
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashboardTile is one of the counters of the dashboard
+type dashboardTile int
+
+const (
+	tileReviews    dashboardTile = iota // PRs awaiting my review
+	tileMyPRs                           // My open PRs with failing checks or changes requested
+	tileAssigned                        // Issues assigned to me
+	tileFailedRuns                      // Recent failed runs in repos I own
+)
+
+// dashboardTiles are the tiles in the order they are laid out, left to
+// right and top to bottom
+var dashboardTiles = []dashboardTile{tileReviews, tileMyPRs, tileAssigned, tileFailedRuns}
+
+// dashboardQuery is what a PR or issue tile searches for
+type dashboardQuery struct {
+	title    string
+	searches []string // Run side by side; their results are merged
+	labels   []string // What each search counts, when there is more than one
+}
+
+// dashboardQueries are the searches of the PR and issue tiles. GitHub search
+// can't OR qualifiers, so failing checks and requested changes are two searches.
+var dashboardQueries = map[dashboardTile]dashboardQuery{
+	tileReviews: {
+		title:    "Awaiting my review",
+		searches: []string{"is:pr is:open review-requested:@me archived:false"},
+	},
+	tileMyPRs: {
+		title: "My PRs: failing or changes requested",
+		searches: []string{
+			"is:pr is:open author:@me archived:false status:failure",
+			"is:pr is:open author:@me archived:false review:changes_requested",
+		},
+		labels: []string{"my PRs with failing checks", "my PRs with changes requested"},
+	},
+	tileAssigned: {
+		title:    "Issues assigned to me",
+		searches: []string{"is:issue is:open assignee:@me archived:false"},
+	},
+}
+
+// Failed runs are looked for over the last dashboardRunDays in the
+// dashboardRunRepos repos of the user pushed to most recently
+const (
+	dashboardRunDays  = 7
+	dashboardRunRepos = 10
+)
+
+// dashboardRun is a failed run of one of the user's repos
+type dashboardRun struct {
+	repo string
+	run  WorkflowRun
+}
+
+// tileState is what a tile has loaded
+type tileState struct {
+	loading bool
+	err     error
+	issues  []SearchIssue  // PR and issue tiles
+	runs    []dashboardRun // The failed runs tile
+	total   int            // Matches, which may be more than were loaded
+	note    string         // What the tile couldn't look at
+	cursor  int
+	drills  int // Times a was pressed; picks the search it opens next
+}
+
+// items is the number of things listed in the tile
+func (t *tileState) items() int {
+	return len(t.issues) + len(t.runs)
+}
+
+// DashboardView sums up what needs the user's attention across GitHub in
+// tiles that load side by side; a tile whose query fails says so and the
+// others carry on. Enter opens the thing under a tile's cursor and a shows
+// everything the tile counts in a pre-filtered tab.
+type DashboardView struct {
+	tiles   map[dashboardTile]*tileState
+	current int // Index into dashboardTiles
+	focused bool
+	width   int
+	height  int
+}
+
+// NewDashboardView creates a new dashboard view
+func NewDashboardView() *DashboardView {
+	tiles := make(map[dashboardTile]*tileState)
+	for _, tile := range dashboardTiles {
+		tiles[tile] = &tileState{loading: true}
+	}
+	return &DashboardView{tiles: tiles}
+}
+
+// Update handles messages for the dashboard view
+func (v *DashboardView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardTileMsg:
+		state := v.tiles[msg.tile]
+		state.loading = false
+		state.err = msg.err
+		if msg.err == nil {
+			state.issues, state.runs = msg.issues, msg.runs
+			state.total = msg.total
+			state.note = msg.note
+			state.cursor = max(0, min(state.cursor, state.items()-1))
+		}
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		state := v.tiles[dashboardTiles[v.current]]
+		switch msg.String() {
+		case "left", "h":
+			v.current = (v.current + len(dashboardTiles) - 1) % len(dashboardTiles)
+		case "right", "l":
+			v.current = (v.current + 1) % len(dashboardTiles)
+		case "up", "k":
+			state.cursor = max(0, state.cursor-1)
+		case "down", "j":
+			state.cursor = max(0, min(state.cursor+1, state.items()-1))
+		case "enter":
+			return v, v.openSelected()
+		case "a":
+			return v, v.drill()
+		case "b":
+			if state.cursor < len(state.issues) {
+				issue := state.issues[state.cursor]
+				if issue.PullRequest != nil {
+					return v, openInBrowser("pr", fmt.Sprint(issue.Number), issue.Repo)
+				}
+				return v, openInBrowser("issue", fmt.Sprint(issue.Number), issue.Repo)
+			}
+			if state.cursor < len(state.runs) {
+				run := state.runs[state.cursor]
+				return v, openInBrowser("run", fmt.Sprint(run.run.DatabaseId), run.repo)
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// Refresh loads every tile again, side by side; tiles keep showing what they
+// have until their query returns
+func (v *DashboardView) Refresh() tea.Cmd {
+	cmds := make([]tea.Cmd, len(dashboardTiles))
+	for i, tile := range dashboardTiles {
+		v.tiles[tile].loading = true
+		cmds[i] = fetchDashboardTile(tile)
+	}
+	return tea.Batch(cmds...)
+}
+
+// openSelected opens the PR or issue under the cursor of the current tile in
+// its tab, or the Actions tab of a failed run's repo on the run's workflow
+// and branch
+func (v *DashboardView) openSelected() tea.Cmd {
+	state := v.tiles[dashboardTiles[v.current]]
+	if state.cursor < len(state.issues) {
+		return openSearchResult(searchRow{issue: &state.issues[state.cursor]})
+	}
+	if state.cursor < len(state.runs) {
+		run := state.runs[state.cursor]
+		msg := showFilteredMsg{
+			view:  ViewActions,
+			repo:  run.repo,
+			query: fmt.Sprintf("is:failure workflow:%q branch:%s", run.run.Name, run.run.HeadBranch),
+		}
+		return func() tea.Msg { return msg }
+	}
+	return nil
+}
+
+// drill shows everything the current tile counts: its search in the Search
+// tab, or the failed runs of the selected run's repo in the Actions tab. A
+// tile counting more than one search opens them in turn, one per press.
+func (v *DashboardView) drill() tea.Cmd {
+	tile := dashboardTiles[v.current]
+	state := v.tiles[tile]
+	if tile == tileFailedRuns {
+		if len(state.runs) == 0 {
+			return nil
+		}
+		msg := showFilteredMsg{view: ViewActions, repo: state.runs[state.cursor].repo, query: "is:failure"}
+		return func() tea.Msg { return msg }
+	}
+
+	query := dashboardQueries[tile]
+	i := state.drills % len(query.searches)
+	state.drills++
+	msg := showFilteredMsg{view: ViewSearch, query: query.searches[i]}
+	show := func() tea.Msg { return msg }
+	if len(query.searches) == 1 {
+		return show
+	}
+	next := query.labels[(i+1)%len(query.searches)]
+	status := statusMsg{message: fmt.Sprintf("Showing %s (%d of %d); a on the tile again shows %s",
+		query.labels[i], i+1, len(query.searches), next)}
+	return tea.Batch(show, func() tea.Msg { return status })
+}
+
+// tileTitle is the heading of a tile
+func tileTitle(tile dashboardTile) string {
+	if tile == tileFailedRuns {
+		return fmt.Sprintf("Failed runs, last %d days", dashboardRunDays)
+	}
+	return dashboardQueries[tile].title
+}
+
+// View renders the dashboard: two columns of tiles, or one when narrow
+func (v *DashboardView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	header := []string{
+		listTitleStyle.Render(" Dashboard") + dimmedStyle.Render(" • what needs your attention"),
+		helpStyle.Render(" ←/→: Tile • ↑/↓: Navigate • Enter: Open • a: All • b: Browser • r: Reload"),
+	}
+	rows := height - len(header)
+
+	columns := 2
+	if width < 90 {
+		columns = 1
+	}
+	tileRows := (len(dashboardTiles) + columns - 1) / columns
+	tileWidth := width / columns
+	tileHeight := max(4, rows/tileRows)
+
+	var grid []string
+	for row := 0; row < tileRows; row++ {
+		var tiles []string
+		for col := 0; col < columns; col++ {
+			i := row*columns + col
+			if i < len(dashboardTiles) {
+				tiles = append(tiles, v.renderTile(i, tileWidth, tileHeight))
+			}
+		}
+		grid = append(grid, lipgloss.JoinHorizontal(lipgloss.Top, tiles...))
+	}
+
+	content := strings.Join(append(header, grid...), "\n")
+	return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+}
+
+// renderTile renders the tile at index i of dashboardTiles in a box
+func (v *DashboardView) renderTile(i, width, height int) string {
+	tile := dashboardTiles[i]
+	state := v.tiles[tile]
+	inner := max(10, width-4)
+	lines := make([]string, 0, height)
+
+	count := ""
+	switch {
+	case state.loading && state.items() == 0 && state.err == nil:
+		count = statusPendingStyle.Render("…")
+	case state.err != nil:
+		count = statusFailureStyle.Render("!")
+	default:
+		count = fmt.Sprint(state.total)
+		if state.total > 0 {
+			count = highlightStyle.Render(count)
+		}
+	}
+	title := truncateString(tileTitle(tile), inner-lipgloss.Width(count)-1)
+	lines = append(lines, detailTitleStyle.Render(title)+strings.Repeat(" ", max(1, inner-lipgloss.Width(title)-lipgloss.Width(count)))+count)
+
+	room := height - 3 // Borders and title
+	switch {
+	case state.err != nil:
+		for _, line := range wrapText("Couldn't load: "+state.err.Error(), inner) {
+			lines = append(lines, statusFailureStyle.Render(line))
+		}
+		lines = append(lines, dimmedStyle.Render("r tries again"))
+	case state.loading && state.items() == 0:
+		lines = append(lines, infoStyle.Render("Loading..."))
+	case state.items() == 0:
+		lines = append(lines, dimmedStyle.Render("Nothing here"))
+	default:
+		if state.note != "" {
+			room--
+		}
+		start := max(0, min(state.cursor-room/2, state.items()-room))
+		for j := start; j < min(state.items(), start+room); j++ {
+			lines = append(lines, v.renderTileItem(state, j, i == v.current, inner))
+		}
+		if state.note != "" {
+			lines = append(lines, dimmedStyle.Render(truncateString(state.note, inner)))
+		}
+	}
+
+	border := colorDimmed
+	if i == v.current {
+		border = colorPrimary
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height - 2).
+		MaxHeight(height)
+	if len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+	return box.Render(strings.Join(lines, "\n"))
+}
+
+// renderTileItem renders item j of a tile; the tile's cursor is shown when
+// the tile is the current one
+func (v *DashboardView) renderTileItem(state *tileState, j int, current bool, width int) string {
+	cursor := "  "
+	style := listItemStyle
+	if j == state.cursor && current {
+		cursor = "▶ "
+		style = listSelectedStyle
+	}
+
+	var line, meta string
+	if j < len(state.issues) {
+		issue := state.issues[j]
+		line = fmt.Sprintf("%s%s#%d %s", cursor, issue.Repo, issue.Number, issue.Title)
+		meta = formatTimeAgo(issue.UpdatedAt)
+		if pr := issue.PullRequest; pr != nil && pr.ReviewDecision == "CHANGES_REQUESTED" {
+			meta = "changes requested • " + meta
+		}
+	} else {
+		run := state.runs[j-len(state.issues)]
+		line = fmt.Sprintf("%s✗ %s %s • %s", cursor, run.repo, run.run.Name, run.run.HeadBranch)
+		meta = formatTimeAgo(run.run.CreatedAt)
+	}
+
+	room := width - lipgloss.Width(meta) - 3
+	if room < 15 {
+		return style.Render(fitWidth(line, max(1, width-2)))
+	}
+	return style.Render(fitWidth(line, room-1) + " " + dimmedStyle.Render(meta))
+}
+
+// Focus sets the view as focused
+func (v *DashboardView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *DashboardView) Blur() {
+	v.focused = false
+}
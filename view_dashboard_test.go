@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDashboardDrillsIntoTileSearches(t *testing.T) {
+	v := NewDashboardView()
+	v.focused = true
+	v.current = 1 // My PRs
+
+	// a opens each search the tile counts in turn, never a broader one
+	want := dashboardQueries[tileMyPRs].searches
+	for _, query := range append(want, want[0]) {
+		_, cmd := v.Update(keyPress("a"))
+		if cmd == nil {
+			t.Fatal("a did nothing")
+		}
+		msgs, ok := runCmd(cmd).(tea.BatchMsg)
+		if !ok || len(msgs) != 2 {
+			t.Fatalf("a: %#v", msgs)
+		}
+		show, ok := runCmd(msgs[0]).(showFilteredMsg)
+		if !ok || show.view != ViewSearch || show.query != query {
+			t.Errorf("a opened %#v, want %q", show, query)
+		}
+	}
+
+	// A tile with one search just opens it
+	v.current = 0
+	_, cmd := v.Update(keyPress("a"))
+	show, ok := runCmd(cmd).(showFilteredMsg)
+	if !ok || show.query != dashboardQueries[tileReviews].searches[0] {
+		t.Errorf("a on reviews opened %#v", runCmd(cmd))
+	}
+}
@@ -0,0 +1,652 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DiffView shows a PR's diff as an overlay: file tree on the left, the
+// selected file's hunks on the right. Lines can be commented on; comments
+// are queued in the PR's pending review until it is submitted.
+type DiffView struct {
+	repo    string
+	number  int
+	title   string
+	files   []DiffFile
+	tree    *TreeViewState // Directory tree; the selected item is the shown file
+	scroll  int
+	focused bool
+	err     error
+	loading bool
+	width   int
+	height  int
+
+	// Display toggles
+	split          bool // Side-by-side instead of unified
+	hideWhitespace bool // Hide whitespace-only changes
+
+	// Review state
+	pending  *PendingReview  // Shared with the PR view and the review dialog
+	cursor   int             // Row of the diff pane
+	anchor   int             // Start row of a range selection, -1 when none
+	composer *textarea.Model // Comment being written, nil when not composing
+	draft    ReviewComment   // Where the comment being written goes
+	notice   string          // Feedback shown in the hints line
+}
+
+// diffRow is a rendered row of the diff pane
+// line is the diff line a comment on the row applies to (nil for hunk
+// headers and comments); in split view it's the new side when there is one.
+type diffRow struct {
+	text string
+	line *DiffLine
+	hunk int
+}
+
+// NewDiffView creates a diff view for pr; its diff arrives as prDiffLoadedMsg
+func NewDiffView(repo string, pr PullRequest, pending *PendingReview) *DiffView {
+	return &DiffView{
+		repo:    repo,
+		number:  pr.Number,
+		title:   pr.Title,
+		tree:    NewTreeViewState(),
+		loading: true,
+		pending: pending,
+		anchor:  -1,
+	}
+}
+
+// openDiffView opens the diff viewer for pr and starts loading its diff
+func openDiffView(repo string, pr PullRequest, pending *PendingReview) tea.Cmd {
+	// Sequenced so the overlay is open before the diff arrives
+	return tea.Sequence(openOverlay(NewDiffView(repo, pr, pending)), fetchPRDiff(repo, pr.Number))
+}
+
+// Update handles messages for the diff view
+func (v *DiffView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case prDiffLoadedMsg:
+		if msg.number != v.number || staleRepo(msg.repo, v.repo) {
+			return v, nil
+		}
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		v.setFiles(parseUnifiedDiff(msg.diff))
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		if v.composer != nil {
+			return v.updateComposer(msg)
+		}
+
+		v.notice = ""
+		page := max(1, v.height-6)
+		switch msg.String() {
+		case "esc":
+			if v.anchor >= 0 {
+				v.anchor = -1
+				return v, nil
+			}
+			return v, closeOverlay()
+		case "q":
+			return v, closeOverlay()
+		case "down", "j":
+			v.cursor++
+		case "up", "k":
+			v.cursor = max(0, v.cursor-1)
+		case "ctrl+d":
+			v.cursor += page / 2
+		case "ctrl+u":
+			v.cursor = max(0, v.cursor-page/2)
+		case "pgdown", " ":
+			v.cursor += page
+		case "pgup":
+			v.cursor = max(0, v.cursor-page)
+		case "g", "home":
+			v.cursor = 0
+		case "G", "end":
+			v.cursor = len(v.rows())
+		case "n", "]", "tab":
+			v.moveFile(1)
+		case "p", "[", "shift+tab":
+			v.moveFile(-1)
+		case "s":
+			v.split = !v.split
+			v.anchor = -1
+		case "w":
+			v.hideWhitespace = !v.hideWhitespace
+			v.cursor = 0
+			v.anchor = -1
+		case "v":
+			// Start or cancel a range selection
+			if v.anchor >= 0 {
+				v.anchor = -1
+			} else {
+				v.anchor = v.cursor
+			}
+		case "c":
+			return v, v.startComment()
+		case "x":
+			v.deleteComments()
+		case "R":
+			return v, openOverlay(NewReviewDialog(v.repo, v.number, v.pending))
+		case "b":
+			return v, openInBrowser("pr", fmt.Sprintf("%d", v.number), v.repo)
+		}
+
+	case tea.MouseMsg:
+		if !v.focused || v.composer != nil {
+			return v, nil
+		}
+
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.cursor = max(0, v.cursor-3)
+		case tea.MouseWheelDown:
+			v.cursor += 3
+		}
+
+	default:
+		// Cursor blink and other textarea messages
+		if v.composer != nil {
+			composer, cmd := v.composer.Update(msg)
+			v.composer = &composer
+			return v, cmd
+		}
+	}
+
+	return v, nil
+}
+
+// updateComposer handles keys while a comment is being written
+func (v *DiffView) updateComposer(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.composer = nil
+		v.notice = "Comment discarded"
+		return v, nil
+	case "ctrl+s":
+		body := strings.TrimSpace(v.composer.Value())
+		if body == "" {
+			v.notice = "Comment is empty"
+			return v, nil
+		}
+		v.draft.Body = body
+		v.pending.Comments = append(v.pending.Comments, v.draft)
+		v.composer = nil
+		v.anchor = -1
+		v.notice = fmt.Sprintf("Comment added to pending review (%d)", len(v.pending.Comments))
+		return v, nil
+	}
+
+	composer, cmd := v.composer.Update(msg)
+	v.composer = &composer
+	return v, cmd
+}
+
+// startComment opens the composer for the line or range under the cursor
+func (v *DiffView) startComment() tea.Cmd {
+	file, ok := v.currentFile()
+	if !ok {
+		return nil
+	}
+	rows := v.rows()
+	if v.cursor >= len(rows) || rows[v.cursor].line == nil {
+		v.notice = "Move to a diff line to comment"
+		return nil
+	}
+
+	end := rows[v.cursor]
+	v.draft = ReviewComment{Path: file.Path()}
+	v.draft.Side, v.draft.Line = commentPosition(*end.line)
+
+	if v.anchor >= 0 && v.anchor != v.cursor {
+		first, last := min(v.anchor, v.cursor), max(v.anchor, v.cursor)
+		if rows[first].line == nil || rows[last].line == nil || rows[first].hunk != rows[last].hunk {
+			v.notice = "A range must start and end on lines of the same hunk"
+			return nil
+		}
+		v.draft.Side, v.draft.Line = commentPosition(*rows[last].line)
+		v.draft.StartSide, v.draft.StartLine = commentPosition(*rows[first].line)
+	}
+
+	composer := textarea.New()
+	composer.Placeholder = "Leave a comment"
+	composer.ShowLineNumbers = false
+	composer.SetHeight(4)
+	composer.SetWidth(max(20, v.diffWidth()-4))
+	v.composer = &composer
+	return v.composer.Focus()
+}
+
+// deleteComments removes the pending comments on the line under the cursor
+func (v *DiffView) deleteComments() {
+	file, ok := v.currentFile()
+	rows := v.rows()
+	if !ok || v.cursor >= len(rows) || rows[v.cursor].line == nil {
+		return
+	}
+
+	side, line := commentPosition(*rows[v.cursor].line)
+	kept := v.pending.Comments[:0]
+	for _, comment := range v.pending.Comments {
+		if comment.Path != file.Path() || comment.Side != side || comment.Line != line {
+			kept = append(kept, comment)
+		}
+	}
+	if removed := len(v.pending.Comments) - len(kept); removed > 0 {
+		v.notice = fmt.Sprintf("Removed %d pending comment(s)", removed)
+	}
+	v.pending.Comments = kept
+}
+
+// commentPosition returns the side and line number a review comment uses
+func commentPosition(line DiffLine) (string, int) {
+	if line.Kind == DiffRemoved {
+		return "LEFT", line.OldLine
+	}
+	return "RIGHT", line.NewLine
+}
+
+// setFiles replaces the diff and selects its first file
+func (v *DiffView) setFiles(files []DiffFile) {
+	v.files = files
+	v.tree.TreeItems = BuildDiffTree(files)
+	for i := range v.tree.TreeItems {
+		item := &v.tree.TreeItems[i]
+		if item.Type == TreeItemDiffFile {
+			file := files[item.Data.(int)]
+			item.Name = fmt.Sprintf("%s%s +%d -%d", diffStatusMarker(file.Status), item.Name, file.Additions, file.Deletions)
+		}
+	}
+	v.tree.SelectedIndex = -1
+	v.moveFile(1)
+}
+
+// moveFile selects the next (delta 1) or previous (delta -1) file in the tree
+func (v *DiffView) moveFile(delta int) {
+	for i := v.tree.SelectedIndex + delta; i >= 0 && i < len(v.tree.TreeItems); i += delta {
+		if v.tree.TreeItems[i].Type == TreeItemDiffFile {
+			v.tree.SelectedIndex = i
+			v.scroll = 0
+			v.cursor = 0
+			v.anchor = -1
+			return
+		}
+	}
+}
+
+// currentFile returns the selected file
+func (v *DiffView) currentFile() (DiffFile, bool) {
+	item := v.tree.GetSelectedItem()
+	if item == nil || item.Type != TreeItemDiffFile {
+		return DiffFile{}, false
+	}
+	return v.files[item.Data.(int)], true
+}
+
+// treeWidth is the width of the file tree (0 when too narrow to show it)
+func (v *DiffView) treeWidth() int {
+	if v.width < 60 {
+		return 0
+	}
+	return min(40, max(20, v.width/4))
+}
+
+// diffWidth is the width of the diff pane
+func (v *DiffView) diffWidth() int {
+	if tw := v.treeWidth(); tw > 0 {
+		return v.width - tw - 1
+	}
+	return v.width
+}
+
+// View renders the diff view
+func (v *DiffView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	if v.loading {
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			infoStyle.Render(fmt.Sprintf("Loading diff for #%d...", v.number)))
+	}
+
+	if v.err != nil {
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			errorStyle.Render(fmt.Sprintf("Error: %v\n\nPress Esc to go back", v.err)))
+	}
+
+	if len(v.files) == 0 {
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			dimmedStyle.Render("No changes\n\nPress Esc to go back"))
+	}
+
+	// Header: PR and totals
+	additions, deletions := 0, 0
+	for _, file := range v.files {
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	title := detailTitleStyle.Render(fmt.Sprintf(" PR #%d Diff ", v.number)) +
+		dimmedStyle.Render(truncateString(v.title, width/2))
+	totals := fmt.Sprintf("%d files %s %s ", len(v.files),
+		diffAddStyle.Render(fmt.Sprintf("+%d", additions)),
+		diffDelStyle.Render(fmt.Sprintf("-%d", deletions)))
+	if n := len(v.pending.Comments); n > 0 {
+		totals = statusPendingStyle.Render(fmt.Sprintf("%d pending ", n)) + totals
+	}
+	header := title + strings.Repeat(" ", max(1, width-lipgloss.Width(title)-lipgloss.Width(totals))) + totals
+
+	hints := " j/k: Move • v: Range • c: Comment • x: Delete comment • R: Submit review • n/p: File • s: Split • w: Whitespace • Esc: Back"
+	switch {
+	case v.composer != nil:
+		hints = " Ctrl+S: Add to review • Esc: Discard"
+	case v.anchor >= 0:
+		hints = " j/k: Extend range • c: Comment on range • Esc: Cancel range"
+	}
+	if v.notice != "" {
+		hints = " " + v.notice + " •" + hints
+	}
+	hintsLine := helpStyle.MaxWidth(width).Render(hints)
+
+	bodyHeight := max(1, height-3)
+	diffPane := v.renderDiffPane(v.diffWidth(), bodyHeight)
+
+	body := diffPane
+	if tw := v.treeWidth(); tw > 0 {
+		divider := dividerStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", bodyHeight), "\n"))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, renderTreeView(v.tree, tw, bodyHeight), divider, diffPane)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body, hintsLine)
+}
+
+// renderDiffPane renders the selected file's header and visible diff rows
+func (v *DiffView) renderDiffPane(width, height int) string {
+	file, ok := v.currentFile()
+	if !ok {
+		return lipgloss.NewStyle().Width(width).Height(height).Render("")
+	}
+
+	// File header with display modes
+	path := file.Path()
+	if file.Status == "renamed" {
+		path = file.OldPath + " → " + file.NewPath
+	}
+	modes := "unified"
+	if v.split {
+		modes = "split"
+	}
+	if v.hideWhitespace {
+		modes += ", whitespace hidden"
+	}
+	header := highlightStyle.Render(truncateString(path, width-len(modes)-4)) + " " + dimmedStyle.Render("["+modes+"]")
+
+	// The composer takes the bottom of the pane
+	composer := ""
+	if v.composer != nil {
+		v.composer.SetWidth(max(20, width-4))
+		target := fmt.Sprintf("Comment on %s line %d", v.draft.Path, v.draft.Line)
+		if v.draft.StartLine > 0 {
+			target = fmt.Sprintf("Comment on %s lines %d-%d", v.draft.Path, v.draft.StartLine, v.draft.Line)
+		}
+		composer = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colorPrimary).
+			Render(dialogTitleStyle.Render(truncateString(target, width-4)) + "\n" + v.composer.View())
+		height -= lipgloss.Height(composer)
+	}
+
+	rows := v.rows()
+	if v.cursor >= len(rows) {
+		v.cursor = max(0, len(rows)-1)
+	}
+
+	// Keep the cursor in view
+	visible := max(1, height-2)
+	if v.cursor < v.scroll {
+		v.scroll = v.cursor
+	}
+	if v.cursor >= v.scroll+visible {
+		v.scroll = v.cursor - visible + 1
+	}
+	v.scroll = max(0, min(v.scroll, len(rows)-visible))
+
+	lines := []string{header, ""}
+	for i := v.scroll; i < min(len(rows), v.scroll+visible); i++ {
+		lines = append(lines, v.gutter(i)+rows[i].text)
+	}
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+	if composer != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, composer)
+	}
+	return content
+}
+
+// gutter marks the cursor row and the rows of a range selection
+func (v *DiffView) gutter(row int) string {
+	switch {
+	case row == v.cursor:
+		return listCursorStyle.Render("▶ ")
+	case v.anchor >= 0 && row >= min(v.anchor, v.cursor) && row <= max(v.anchor, v.cursor):
+		return listCursorStyle.Render("┃ ")
+	default:
+		return "  "
+	}
+}
+
+// rows renders the diff pane's rows at its current width
+func (v *DiffView) rows() []diffRow {
+	return v.diffRows(v.diffWidth() - 2) // Minus the cursor gutter
+}
+
+// diffRows renders every row of the selected file's diff, with pending
+// comments shown below the lines they are on
+func (v *DiffView) diffRows(width int) []diffRow {
+	file, ok := v.currentFile()
+	if !ok {
+		return nil
+	}
+	hunks := file.Hunks
+	if v.hideWhitespace {
+		hunks = file.withoutWhitespaceChanges().Hunks
+	}
+
+	switch {
+	case file.Binary:
+		return []diffRow{{text: dimmedStyle.Render("Binary file not shown"), hunk: -1}}
+	case len(file.Hunks) == 0:
+		return []diffRow{{text: dimmedStyle.Render("No content changes"), hunk: -1}}
+	case len(hunks) == 0:
+		return []diffRow{{text: dimmedStyle.Render("Only whitespace changes (w to show)"), hunk: -1}}
+	}
+
+	lang := syntaxFor(file.Path())
+	var rows []diffRow
+	for i, hunk := range hunks {
+		if i > 0 {
+			rows = append(rows, diffRow{hunk: -1})
+		}
+		header := strings.TrimSpace(fmt.Sprintf("@@ -%d +%d @@ %s", hunk.OldStart, hunk.NewStart, hunk.Header))
+		rows = append(rows, diffRow{text: diffHunkStyle.Render(fitWidth(header, width)), hunk: -1})
+
+		var hunkRows []diffRow
+		if v.split {
+			hunkRows = renderSplitHunk(hunk, lang, width)
+		} else {
+			for j := range hunk.Lines {
+				hunkRows = append(hunkRows, diffRow{text: renderUnifiedLine(hunk.Lines[j], lang, width), line: &hunk.Lines[j]})
+			}
+		}
+
+		for _, row := range hunkRows {
+			row.hunk = i
+			rows = append(rows, row)
+			rows = append(rows, v.commentRows(file.Path(), row.line, width)...)
+		}
+	}
+	return rows
+}
+
+// commentRows renders the pending comments on a diff line
+func (v *DiffView) commentRows(path string, line *DiffLine, width int) []diffRow {
+	if line == nil || v.pending == nil {
+		return nil
+	}
+
+	var rows []diffRow
+	side, number := commentPosition(*line)
+	for _, comment := range v.pending.Comments {
+		if comment.Path != path || comment.Side != side || comment.Line != number {
+			continue
+		}
+		for i, text := range wrapText(comment.Body, width-8) {
+			prefix := "      "
+			if i == 0 {
+				prefix = "    💬 "
+			}
+			rows = append(rows, diffRow{text: mdQuoteStyle.Render(prefix + text), hunk: -1})
+		}
+	}
+	return rows
+}
+
+// renderUnifiedLine renders a diff line with old and new line numbers
+func renderUnifiedLine(line DiffLine, lang *syntaxLanguage, width int) string {
+	numbers := fmt.Sprintf("%4s %4s ", lineNumber(line.OldLine), lineNumber(line.NewLine))
+	return diffLineNoStyle.Render(numbers) + renderDiffContent(line, lang, width-len(numbers))
+}
+
+// renderSplitHunk renders a hunk side by side: old on the left, new on the right
+// Runs of removals and additions are paired row by row.
+func renderSplitHunk(hunk DiffHunk, lang *syntaxLanguage, width int) []diffRow {
+	half := (width - 1) / 2
+	side := func(line *DiffLine, number int) string {
+		if line == nil {
+			return strings.Repeat(" ", half)
+		}
+		prefix := fmt.Sprintf("%4s ", lineNumber(number))
+		return diffLineNoStyle.Render(prefix) + renderDiffContent(*line, lang, half-len(prefix))
+	}
+	row := func(left, right *DiffLine) diffRow {
+		var oldNumber, newNumber int
+		target := right
+		if left != nil {
+			oldNumber = left.OldLine
+		}
+		if right != nil {
+			newNumber = right.NewLine
+		} else {
+			target = left
+		}
+		return diffRow{
+			text: side(left, oldNumber) + dividerStyle.Render("│") + side(right, newNumber),
+			line: target,
+		}
+	}
+
+	var rows []diffRow
+	for i := 0; i < len(hunk.Lines); {
+		if hunk.Lines[i].Kind == DiffContext {
+			rows = append(rows, row(&hunk.Lines[i], &hunk.Lines[i]))
+			i++
+			continue
+		}
+
+		var removed, added []*DiffLine
+		for ; i < len(hunk.Lines) && hunk.Lines[i].Kind == DiffRemoved; i++ {
+			removed = append(removed, &hunk.Lines[i])
+		}
+		for ; i < len(hunk.Lines) && hunk.Lines[i].Kind == DiffAdded; i++ {
+			added = append(added, &hunk.Lines[i])
+		}
+		for j := 0; j < max(len(removed), len(added)); j++ {
+			var left, right *DiffLine
+			if j < len(removed) {
+				left = removed[j]
+			}
+			if j < len(added) {
+				right = added[j]
+			}
+			rows = append(rows, row(left, right))
+		}
+	}
+	return rows
+}
+
+// renderDiffContent renders a line's +/- marker in its colour and its
+// content highlighted as lang, with the rest of the text in the marker's colour
+func renderDiffContent(line DiffLine, lang *syntaxLanguage, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	content := fitWidth(expandTabs(line.Content), width-1)
+	switch line.Kind {
+	case DiffAdded:
+		return diffAddStyle.Render("+") + highlightCode(lang, content, diffAddStyle)
+	case DiffRemoved:
+		return diffDelStyle.Render("-") + highlightCode(lang, content, diffDelStyle)
+	default:
+		return " " + highlightCode(lang, content, lipgloss.NewStyle())
+	}
+}
+
+// lineNumber formats a line number, blank for 0
+func lineNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// diffStatusMarker prefixes added, deleted and renamed files in the tree
+func diffStatusMarker(status string) string {
+	switch status {
+	case "added":
+		return "A "
+	case "deleted":
+		return "D "
+	case "renamed":
+		return "R "
+	default:
+		return ""
+	}
+}
+
+// fitWidth truncates or pads plain text to exactly width runes
+func fitWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// Focus sets the view as focused
+func (v *DiffView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *DiffView) Blur() {
+	v.focused = false
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dispatchField is the form state of one workflow_dispatch input
+type dispatchField struct {
+	input   WorkflowInput
+	text    textinput.Model // string and number inputs, and choices without options
+	checked bool            // boolean inputs
+	options []string        // choice and environment inputs
+	choice  int             // Index into options
+}
+
+// value returns the field's value as GitHub expects it
+func (f *dispatchField) value() string {
+	switch {
+	case f.input.Type == "boolean":
+		return strconv.FormatBool(f.checked)
+	case len(f.options) > 0:
+		return f.options[f.choice]
+	}
+	return strings.TrimSpace(f.text.Value())
+}
+
+// setValue fills the field in from a value, as given by value or a default
+func (f *dispatchField) setValue(value string) {
+	switch {
+	case f.input.Type == "boolean":
+		f.checked = value == "true"
+	case len(f.options) > 0:
+		for i, option := range f.options {
+			if option == value {
+				f.choice = i
+			}
+		}
+	default:
+		f.text.SetValue(value)
+	}
+}
+
+// DispatchDialog runs a workflow manually, with a form for its inputs
+// The inputs are read from the workflow file at the chosen ref, and read
+// again when the ref changes, keeping the values typed so far.
+type DispatchDialog struct {
+	repo     string
+	workflow Workflow
+	focused  bool
+	err      error
+
+	ref          textinput.Model
+	requested    string // Ref of the inputs being loaded
+	loadedRef    string // Ref the inputs were read at
+	loading      bool
+	dispatchable bool
+	fields       []dispatchField
+	field        int // 0 is the ref, then one per input
+
+	dispatching bool
+}
+
+// NewDispatchDialog creates the dialog to run workflow; its inputs arrive as dispatchFormLoadedMsg
+func NewDispatchDialog(repo string, workflow Workflow) *DispatchDialog {
+	ref := textinput.New()
+	ref.Placeholder = "Branch or tag"
+	ref.Prompt = ""
+	ref.Focus()
+
+	return &DispatchDialog{repo: repo, workflow: workflow, ref: ref, loading: true}
+}
+
+// openDispatchDialog opens the dispatch dialog for workflow at its default branch
+func openDispatchDialog(repo string, workflow Workflow) tea.Cmd {
+	return tea.Sequence(openOverlay(NewDispatchDialog(repo, workflow)), fetchDispatchForm(repo, workflow, ""))
+}
+
+// Update handles messages for the dispatch dialog
+func (d *DispatchDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dispatchFormLoadedMsg:
+		if msg.workflowID != d.workflow.ID || !d.loading || (d.requested != "" && msg.ref != d.requested) {
+			return d, nil
+		}
+		d.loading = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		d.err = nil
+		if d.ref.Value() == "" {
+			d.ref.SetValue(msg.ref)
+			d.ref.CursorEnd()
+		}
+		d.loadedRef = msg.ref
+		d.dispatchable = msg.dispatchable
+		d.setInputs(msg.inputs, msg.environments)
+		return d, nil
+
+	case workflowDispatchedMsg:
+		if msg.workflow.ID != d.workflow.ID || !d.dispatching {
+			return d, nil
+		}
+		d.dispatching = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		return d, closeOverlay()
+
+	case tea.KeyMsg:
+		if !d.focused || d.dispatching {
+			return d, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			return d, closeOverlay()
+		case "ctrl+s":
+			return d, d.submit()
+		case "tab", "down":
+			return d, d.moveField(1)
+		case "shift+tab", "up":
+			return d, d.moveField(-1)
+		}
+
+		if d.field == 0 {
+			if msg.String() == "enter" {
+				return d, d.reload()
+			}
+			var cmd tea.Cmd
+			d.ref, cmd = d.ref.Update(msg)
+			return d, cmd
+		}
+
+		f := &d.fields[d.field-1]
+		switch {
+		case f.input.Type == "boolean":
+			switch msg.String() {
+			case " ", "enter", "x":
+				f.checked = !f.checked
+			}
+			return d, nil
+		case len(f.options) > 0:
+			switch msg.String() {
+			case "left", "h":
+				f.choice = (f.choice - 1 + len(f.options)) % len(f.options)
+			case "right", "l", " ":
+				f.choice = (f.choice + 1) % len(f.options)
+			}
+			return d, nil
+		case msg.String() == "enter":
+			return d, d.moveField(1)
+		}
+	}
+
+	// Text input, cursor blink
+	var cmd tea.Cmd
+	if d.field == 0 {
+		d.ref, cmd = d.ref.Update(msg)
+	} else if d.field <= len(d.fields) {
+		f := &d.fields[d.field-1]
+		f.text, cmd = f.text.Update(msg)
+	}
+	return d, cmd
+}
+
+// setInputs builds the form for inputs, keeping the values of inputs
+// that were already there
+func (d *DispatchDialog) setInputs(inputs []WorkflowInput, environments []string) {
+	previous := make(map[string]string)
+	for i := range d.fields {
+		previous[d.fields[i].input.Name] = d.fields[i].value()
+	}
+
+	d.fields = make([]dispatchField, len(inputs))
+	for i, input := range inputs {
+		f := &d.fields[i]
+		f.input = input
+		switch input.Type {
+		case "choice":
+			f.options = input.Options
+		case "environment":
+			f.options = environments
+		}
+		f.text = textinput.New()
+		f.text.Prompt = ""
+		if input.Type == "number" {
+			f.text.Placeholder = "Number"
+		}
+
+		f.setValue(input.Default)
+		if value, ok := previous[input.Name]; ok {
+			f.setValue(value)
+		}
+	}
+
+	if d.field > len(d.fields) {
+		d.field = 0
+		d.moveField(0)
+	}
+}
+
+// moveField focuses the field delta away, wrapping around
+func (d *DispatchDialog) moveField(delta int) tea.Cmd {
+	count := len(d.fields) + 1
+	d.field = (d.field + delta + count) % count
+
+	d.ref.Blur()
+	for i := range d.fields {
+		d.fields[i].text.Blur()
+	}
+	if d.field == 0 {
+		return d.ref.Focus()
+	}
+	return d.fields[d.field-1].text.Focus()
+}
+
+// reload reads the inputs again at the ref typed in, if it changed
+func (d *DispatchDialog) reload() tea.Cmd {
+	ref := strings.TrimSpace(d.ref.Value())
+	if ref == "" || (ref == d.loadedRef && d.err == nil) {
+		return nil
+	}
+	d.err = nil
+	d.loading = true
+	d.requested = ref
+	return fetchDispatchForm(d.repo, d.workflow, ref)
+}
+
+// submit checks the form and dispatches the workflow
+func (d *DispatchDialog) submit() tea.Cmd {
+	if d.loading {
+		return nil
+	}
+	if strings.TrimSpace(d.ref.Value()) != d.loadedRef {
+		// The form may not match the workflow at the new ref
+		return d.reload()
+	}
+	if !d.dispatchable {
+		return nil
+	}
+
+	d.err = nil
+	inputs := make(map[string]string)
+	for i := range d.fields {
+		f := &d.fields[i]
+		value := f.value()
+		switch {
+		case value == "" && f.input.Required:
+			d.err = fmt.Errorf("%s is required", f.input.Name)
+		case value != "" && f.input.Type == "number":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				d.err = fmt.Errorf("%s must be a number", f.input.Name)
+			}
+		}
+		if d.err != nil {
+			d.field = i
+			d.moveField(1)
+			return nil
+		}
+		// Inputs left empty take their default
+		if value != "" {
+			inputs[f.input.Name] = value
+		}
+	}
+
+	d.dispatching = true
+	return dispatchWorkflowRun(d.repo, d.workflow, d.loadedRef, inputs)
+}
+
+// View renders the dialog centered in the content area
+func (d *DispatchDialog) View(width, height int) string {
+	boxWidth := min(72, width-4)
+	inner := boxWidth - 6
+
+	lines := []string{dialogTitleStyle.Width(inner).Render(truncateString("Run workflow: "+d.workflow.Name, inner))}
+	lines = append(lines, dimmedStyle.Render(truncateString(d.workflow.Path, inner)), "")
+
+	d.ref.Width = inner - 4
+	lines = append(lines, d.label(0, "Use workflow from"), "  "+d.ref.View(), "")
+
+	var help string
+	switch {
+	case d.loading:
+		lines = append(lines, infoStyle.Padding(0).Render("Reading workflow inputs..."))
+		help = "Esc: Cancel"
+	case d.loadedRef == "":
+		lines = append(lines, statusFailureStyle.Render(strings.Join(wrapText("Error: "+d.err.Error(), inner), "\n")))
+		help = "Enter: Retry • Esc: Close"
+	case !d.dispatchable:
+		lines = append(lines, wrapText(fmt.Sprintf("This workflow has no workflow_dispatch trigger at %s.", d.loadedRef), inner)...)
+		help = "Enter: Reload at ref • Esc: Close"
+	default:
+		// Keep the focused input in sight when the form is taller than the screen
+		var blocks [][]string
+		for i := range d.fields {
+			blocks = append(blocks, d.renderField(i, inner))
+		}
+		if len(blocks) == 0 {
+			blocks = append(blocks, []string{dimmedStyle.Render("This workflow has no inputs")})
+		}
+		room := height - len(lines) - 8
+		first := 0
+		for first < d.field-1 && blockHeight(blocks[first:max(d.field, 1)]) > room {
+			first++
+		}
+		if first > 0 {
+			lines = append(lines, dimmedStyle.Render(fmt.Sprintf("  ↑ %s above", pluralize(first, "input"))))
+		}
+		for i := first; i < len(blocks); i++ {
+			if i > first && blockHeight(blocks[first:i+1]) > room {
+				lines = append(lines, dimmedStyle.Render(fmt.Sprintf("  ↓ %s below", pluralize(len(blocks)-i, "input"))))
+				break
+			}
+			lines = append(lines, blocks[i]...)
+		}
+
+		switch {
+		case d.dispatching:
+			lines = append(lines, infoStyle.Padding(0).Render("Dispatching, waiting for the run to start..."))
+		case d.err != nil:
+			lines = append(lines, statusFailureStyle.Render(strings.Join(wrapText("Error: "+d.err.Error(), inner), "\n")))
+		}
+		help = "Tab: Field • Space/←/→: Change • Ctrl+S: Run • Esc: Cancel"
+	}
+
+	lines = append(lines, "", helpStyle.Render(strings.Join(wrapText(help, inner), "\n")))
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// label renders a field label, highlighted when the field is focused
+func (d *DispatchDialog) label(field int, text string) string {
+	if d.field == field {
+		return highlightStyle.Render("▶ " + text)
+	}
+	return dimmedStyle.Render("  " + text)
+}
+
+// renderField renders the label, description and control of input i
+func (d *DispatchDialog) renderField(i, width int) []string {
+	f := &d.fields[i]
+	name := f.input.Name
+	if f.input.Required {
+		name += " *"
+	}
+	lines := []string{d.label(i+1, name)}
+	if f.input.Description != "" {
+		for _, line := range wrapText(f.input.Description, width-4) {
+			lines = append(lines, dimmedStyle.Render("  "+line))
+		}
+	}
+
+	switch {
+	case f.input.Type == "boolean":
+		box := "[ ]"
+		if f.checked {
+			box = "[x]"
+		}
+		lines = append(lines, "  "+box)
+	case len(f.options) > 0:
+		lines = append(lines, "  ‹ "+highlightStyle.Render(f.options[f.choice])+" ›"+dimmedStyle.Render(fmt.Sprintf("  %d/%d", f.choice+1, len(f.options))))
+	default:
+		f.text.Width = width - 4
+		lines = append(lines, "  "+f.text.View())
+	}
+	return append(lines, "")
+}
+
+// blockHeight counts the lines of the field blocks
+func blockHeight(blocks [][]string) int {
+	n := 0
+	for _, block := range blocks {
+		n += len(block)
+	}
+	return n
+}
+
+// Focus sets the dialog as focused
+func (d *DispatchDialog) Focus() {
+	d.focused = true
+}
+
+// Blur sets the dialog as unfocused
+func (d *DispatchDialog) Blur() {
+	d.focused = false
+}
@@ -11,7 +11,10 @@ import (
 
 // GistView displays a list of gists
 type GistView struct {
+	all               []Gist // Everything loaded; data is what the filter lets through
 	data              []Gist
+	page              PageInfo // Pagination state of data
+	loadingMore       bool     // Next page request in flight
 	cursor            int
 	focused           bool
 	err               error
@@ -20,12 +23,14 @@ type GistView struct {
 	height            int
 	awaitingGistInput bool   // waiting for description/visibility input for new gist
 	newGistFilePath   string // temp file path for new gist being created
+	filter            *ListFilter
 }
 
 // NewGistView creates a new gist view
 func NewGistView() *GistView {
 	return &GistView{
 		data:    []Gist{},
+		filter:  NewListFilter("is", "file"),
 		cursor:  0,
 		focused: false,
 		loading: true,
@@ -37,13 +42,20 @@ func (v *GistView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case gistsLoadedMsg:
 		v.loading = false
+		v.loadingMore = false
 		if msg.err != nil {
-			v.err = msg.err
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
 		} else {
-			v.data = msg.gists
-			if len(v.data) > 0 && v.cursor >= len(v.data) {
-				v.cursor = len(v.data) - 1
+			if msg.appended {
+				v.all = append(v.all, msg.gists...)
+			} else {
+				v.all = msg.gists
 			}
+			v.page = msg.page
+			v.applyFilter()
 		}
 
 	case gistEditorFinishedMsg:
@@ -76,8 +88,18 @@ func (v *GistView) Update(msg tea.Msg) (View, tea.Cmd) {
 		if !v.focused {
 			return v, nil
 		}
+		if v.filter.Editing() {
+			cmd := v.filter.HandleKey(msg)
+			v.applyFilter()
+			return v, cmd
+		}
 
 		switch msg.String() {
+		case "/":
+			return v, v.filter.Start()
+		case "esc":
+			v.filter.Clear()
+			v.applyFilter()
 		case "up", "k":
 			if v.cursor > 0 {
 				v.cursor--
@@ -86,6 +108,7 @@ func (v *GistView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
 			}
+			return v, v.loadMore()
 		case "r":
 			v.loading = true
 			v.err = nil
@@ -149,6 +172,7 @@ func (v *GistView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
 			}
+			return v, v.loadMore()
 		}
 	}
 
@@ -172,7 +196,7 @@ func (v *GistView) View(width, height int) string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", v.err)))
 	}
 
-	if len(v.data) == 0 {
+	if len(v.all) == 0 && !v.filter.Editing() {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
 			dimmedStyle.Render("No gists found"))
@@ -198,9 +222,15 @@ func (v *GistView) renderList(width, height int) string {
 	var lines []string
 
 	// Header
-	title := listTitleStyle.Render(fmt.Sprintf(" Gists (%d)", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" Gists (%s)", formatListCount(len(v.data), v.page)) + v.filter.Title())
+	if v.loadingMore {
+		title += dimmedStyle.Render(" loading more...")
+	}
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
+	if len(v.data) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 3
@@ -250,7 +280,9 @@ func (v *GistView) renderList(width, height int) string {
 			formatTimeAgo(gist.UpdatedAt))
 
 		if len(line)+len(meta)+3 < width {
-			line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+			line = v.filter.Highlight(padRight(line, width-len(meta)-3), style) + dimmedStyle.Render(meta)
+		} else {
+			line = v.filter.Highlight(line, style)
 		}
 
 		lines = append(lines, style.Render(line))
@@ -331,6 +363,63 @@ func (v *GistView) renderDetail(width, height int) string {
 		Render(content)
 }
 
+// loadMore requests the next page when the cursor nears the end of the list
+func (v *GistView) loadMore() tea.Cmd {
+	if !needsNextPage(v.cursor, len(v.data), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return fetchGistsPage(v.page.EndCursor)
+}
+
+// applyFilter narrows the loaded gists to those matching the filter,
+// keeping the cursor on the same gist while it still matches
+func (v *GistView) applyFilter() {
+	current := ""
+	if v.cursor < len(v.data) {
+		current = v.data[v.cursor].ID
+	}
+	data := []Gist{}
+	cursor := -1
+	for _, gist := range v.all {
+		if !v.filter.Match(gistFilterItem(gist)) {
+			continue
+		}
+		if gist.ID == current {
+			cursor = len(data)
+		}
+		data = append(data, gist)
+	}
+	v.data = data
+	if cursor >= 0 {
+		v.cursor = cursor
+	} else {
+		v.cursor = max(0, min(v.cursor, len(v.data)-1))
+	}
+}
+
+// gistFilterItem is what the filter matches a gist on
+func gistFilterItem(gist Gist) filterItem {
+	visibility := "secret"
+	if gist.Public {
+		visibility = "public"
+	}
+	item := filterItem{
+		fields: map[string][]string{"is": {visibility}},
+		text:   []string{gist.Description},
+	}
+	for _, file := range gist.Files {
+		item.fields["file"] = append(item.fields["file"], file.Filename)
+		item.text = append(item.text, file.Filename)
+	}
+	return item
+}
+
+// Filtering reports whether the filter prompt is open
+func (v *GistView) Filtering() bool {
+	return v.filter.Editing()
+}
+
 // Focus sets the view as focused
 func (v *GistView) Focus() {
 	v.focused = true
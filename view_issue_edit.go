@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// issueField is a field of an issue edited with a picker
+type issueField int
+
+const (
+	issueFieldLabels issueField = iota
+	issueFieldAssignees
+	issueFieldMilestone
+)
+
+// IssueFieldDialog edits the labels, assignees or milestone of an issue
+// The choices are loaded when it opens; what is chosen is applied as a diff
+// against the issue, so changes made elsewhere in the meantime are kept.
+// Opened on a selection it only adds labels, to every selected issue or PR.
+type IssueFieldDialog struct {
+	repo    string
+	issue   Issue
+	field   issueField
+	focused bool
+
+	// Batch of issue or PR numbers to label, shown in view; nil for a single issue
+	batch []int
+	noun  string
+	view  ViewType
+
+	loading  bool
+	applying bool
+	picker   *Picker
+	err      error
+}
+
+// openIssueFieldDialog opens the picker for field of issue
+func openIssueFieldDialog(repo string, issue Issue, field issueField) tea.Cmd {
+	d := &IssueFieldDialog{repo: repo, issue: issue, field: field, loading: true}
+	return tea.Sequence(openOverlay(d), fetchIssueOptions(repo, field))
+}
+
+// openBatchLabelDialog opens the picker of labels to add to numbers, issues
+// or PRs named by noun, and reports the batch to view
+func openBatchLabelDialog(view ViewType, repo string, numbers []int, noun string) tea.Cmd {
+	d := &IssueFieldDialog{repo: repo, field: issueFieldLabels, loading: true, batch: numbers, noun: noun, view: view}
+	return tea.Sequence(openOverlay(d), fetchIssueOptions(repo, issueFieldLabels))
+}
+
+// Update handles messages for the dialog
+func (d *IssueFieldDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case issueOptionsLoadedMsg:
+		if msg.repo != d.repo || msg.field != d.field || !d.loading {
+			return d, nil
+		}
+		d.loading = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		d.picker = d.newPicker(msg)
+
+	case issueEditedMsg:
+		if msg.repo != d.repo || msg.issue.Number != d.issue.Number || !d.applying {
+			return d, nil
+		}
+		d.applying = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		return d, closeOverlay()
+
+	case tea.KeyMsg:
+		if !d.focused || d.applying {
+			return d, nil
+		}
+		if d.picker == nil {
+			// Loading or failed: any of the usual keys gets out
+			switch msg.String() {
+			case "esc", "q", "ctrl+c", "enter":
+				return d, closeOverlay()
+			}
+			return d, nil
+		}
+
+		switch d.picker.HandleKey(msg) {
+		case PickerCancelled:
+			return d, closeOverlay()
+		case PickerChosen:
+			if d.batch != nil {
+				labels := d.picker.Checked()
+				if len(labels) == 0 {
+					return d, closeOverlay()
+				}
+				return d, tea.Sequence(closeOverlay(),
+					runBatch(d.view, "Labelling", "Labelled", d.noun, issueBatch(d.repo, d.batch, addLabels(labels))))
+			}
+			edit, changed := d.diff()
+			if !changed {
+				return d, tea.Sequence(closeOverlay(), func() tea.Msg {
+					return statusMsg{message: "No changes"}
+				})
+			}
+			d.err = nil
+			d.applying = true
+			return d, editIssueFields(d.repo, d.issue.Number, edit)
+		}
+	}
+	return d, nil
+}
+
+// newPicker builds the picker from the loaded choices. The issue's current
+// values are always offered, even if they are not among the choices (e.g. a
+// former collaborator), so confirming without touching them keeps them.
+func (d *IssueFieldDialog) newPicker(msg issueOptionsLoadedMsg) *Picker {
+	title := truncateString(fmt.Sprintf("#%d %s", d.issue.Number, d.issue.Title), 40)
+	if d.batch != nil {
+		items := make([]PickerItem, len(msg.labels))
+		for i, label := range msg.labels {
+			items[i] = PickerItem{Label: label.Name, Color: label.Color}
+		}
+		return NewMultiPicker(fmt.Sprintf("Add labels · %d %s", len(d.batch), d.noun), items, nil)
+	}
+
+	switch d.field {
+	case issueFieldLabels:
+		var items []PickerItem
+		var current []string
+		known := make(map[string]bool)
+		for _, label := range msg.labels {
+			items = append(items, PickerItem{Label: label.Name, Color: label.Color})
+			known[label.Name] = true
+		}
+		for _, label := range d.issue.Labels {
+			current = append(current, label.Name)
+			if !known[label.Name] {
+				items = append(items, PickerItem{Label: label.Name, Color: label.Color})
+			}
+		}
+		return NewMultiPicker("Labels · "+title, items, current)
+
+	case issueFieldAssignees:
+		var items []PickerItem
+		var current []string
+		known := make(map[string]bool)
+		for _, login := range msg.assignees {
+			items = append(items, PickerItem{Label: login})
+			known[login] = true
+		}
+		for _, assignee := range d.issue.Assignees {
+			current = append(current, assignee.Login)
+			if !known[assignee.Login] {
+				items = append(items, PickerItem{Label: assignee.Login})
+			}
+		}
+		return NewMultiPicker("Assignees · "+title, items, current)
+
+	default:
+		items := []PickerItem{{Label: "No milestone", Value: "0"}}
+		for _, milestone := range msg.milestones {
+			item := PickerItem{Label: milestone.Title, Value: strconv.Itoa(milestone.Number)}
+			if d.issue.Milestone != nil && d.issue.Milestone.Number == milestone.Number {
+				item.Detail = "current"
+			}
+			items = append(items, item)
+		}
+		return NewPicker("Milestone · "+title, items)
+	}
+}
+
+// diff compares what is chosen in the picker with the issue
+func (d *IssueFieldDialog) diff() (IssueEdit, bool) {
+	var edit IssueEdit
+	switch d.field {
+	case issueFieldLabels:
+		var current []string
+		for _, label := range d.issue.Labels {
+			current = append(current, label.Name)
+		}
+		edit.AddLabels, edit.RemoveLabels = diffStrings(current, d.picker.Checked())
+		return edit, len(edit.AddLabels)+len(edit.RemoveLabels) > 0
+
+	case issueFieldAssignees:
+		var current []string
+		for _, assignee := range d.issue.Assignees {
+			current = append(current, assignee.Login)
+		}
+		edit.AddAssignees, edit.RemoveAssignees = diffStrings(current, d.picker.Checked())
+		return edit, len(edit.AddAssignees)+len(edit.RemoveAssignees) > 0
+
+	default:
+		item, ok := d.picker.Selected()
+		if !ok {
+			return edit, false
+		}
+		number, _ := strconv.Atoi(item.Value)
+		current := 0
+		if d.issue.Milestone != nil {
+			current = d.issue.Milestone.Number
+		}
+		edit.Milestone = &number
+		return edit, number != current
+	}
+}
+
+// diffStrings returns what is in to but not in from, and the other way round
+func diffStrings(from, to []string) (added, removed []string) {
+	had := make(map[string]bool)
+	for _, s := range from {
+		had[s] = true
+	}
+	has := make(map[string]bool)
+	for _, s := range to {
+		has[s] = true
+		if !had[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range from {
+		if !has[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+// View renders the picker, or the loading or error state, centered
+func (d *IssueFieldDialog) View(width, height int) string {
+	boxWidth := min(60, width-4)
+
+	if d.picker == nil {
+		inner := boxWidth - 6
+		title := fmt.Sprintf("Edit #%d", d.issue.Number)
+		if d.batch != nil {
+			title = fmt.Sprintf("Add labels to %d %s", len(d.batch), d.noun)
+		}
+		lines := []string{dialogTitleStyle.Width(inner).Render(truncateString(title, inner)), ""}
+		if d.err != nil {
+			lines = append(lines, errorStyle.Width(inner).Render("Error: "+d.err.Error()), "",
+				helpStyle.Render("Esc: Close"))
+		} else {
+			lines = append(lines, dimmedStyle.Render("Loading choices..."))
+		}
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			dialogBoxStyle.Width(boxWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+	}
+
+	content := d.picker.View(boxWidth, min(height-2, 24))
+	switch {
+	case d.applying:
+		content = lipgloss.JoinVertical(lipgloss.Left, content, dimmedStyle.Render(" Applying..."))
+	case d.err != nil:
+		content = lipgloss.JoinVertical(lipgloss.Left, content,
+			errorStyle.Width(boxWidth).Render(" Error: "+d.err.Error()))
+	}
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// Focus gives the dialog keyboard input
+func (d *IssueFieldDialog) Focus() {
+	d.focused = true
+}
+
+// Blur takes keyboard input away from the dialog
+func (d *IssueFieldDialog) Blur() {
+	d.focused = false
+}
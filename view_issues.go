@@ -10,83 +10,276 @@ import (
 
 // IssueView displays a list of issues
 type IssueView struct {
-	data     []Issue
-	cursor   int
-	focused  bool
-	err      error
-	loading  bool
-	width    int
-	height   int
+	all         []Issue // Everything loaded; data is what the filter lets through
+	data        []Issue
+	repo        string   // Repo being shown ("" for the working directory)
+	page        PageInfo // Pagination state of data
+	loadingMore bool     // Next page request in flight
+	cursor      int
+	focused     bool
+	err         error
+	loading     bool
+	width       int
+	height      int
+
+	// Body and timeline of the selected issue, fetched lazily and kept per issue number
+	details       map[int]IssueDetail
+	detailErrs    map[int]error
+	detailLoading map[int]bool
+	detailScroll  int
+
+	selection *Selection // Issues marked for batch actions
+	filter    *ListFilter
+	shown     *Issue // Opened from the Search tab; selected until the list is fetched afresh
+
+	// Tab the view is shown in; a custom tab lists its saved query (see tabs.go)
+	tab     ViewType
+	title   string
+	saved   *TabConfig
+	columns []string // Shown after the title of each row
 }
 
 // NewIssueView creates a new issue view
 func NewIssueView() *IssueView {
 	return &IssueView{
-		data:    []Issue{},
-		cursor:  0,
-		focused: false,
-		loading: true,
+		data:          []Issue{},
+		details:       make(map[int]IssueDetail),
+		detailErrs:    make(map[int]error),
+		detailLoading: make(map[int]bool),
+		selection:     NewSelection(),
+		filter:        NewListFilter("author", "label", "assignee", "milestone", "state", "is", "no"),
+		tab:           ViewIssues,
+		title:         "Issues",
+		columns:       []string{"author", "updated"},
+		cursor:        0,
+		focused:       false,
+		loading:       true,
 	}
 }
 
 // Update handles messages for the issue view
 func (v *IssueView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
+	case repoChangedMsg:
+		// The model fetches the new repo's data; start over until it arrives
+		v.repo = msg.repo
+		v.all = nil
+		v.data = nil
+		v.page = PageInfo{}
+		v.cursor = 0
+		v.err = nil
+		v.loading = true
+		v.loadingMore = false
+		v.details = make(map[int]IssueDetail)
+		v.detailErrs = make(map[int]error)
+		v.detailLoading = make(map[int]bool)
+		v.detailScroll = 0
+		v.selection.Clear()
+
 	case issuesLoadedMsg:
+		if staleRepo(msg.repo, v.repo) {
+			// Asked for before switching repos
+			return v, nil
+		}
 		v.loading = false
+		v.loadingMore = false
 		if msg.err != nil {
-			v.err = msg.err
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
 		} else {
-			v.data = msg.issues
-			if len(v.data) > 0 && v.cursor >= len(v.data) {
-				v.cursor = len(v.data) - 1
+			if msg.appended {
+				v.all = append(v.all, msg.issues...)
+			} else {
+				v.all = msg.issues
+				if msg.cachedAt.IsZero() {
+					// Fresh list: timelines may be stale, refetch them as issues are selected
+					v.details = make(map[int]IssueDetail)
+					v.detailErrs = make(map[int]error)
+				}
 			}
+			v.page = msg.page
+			v.applyFilter()
+			if v.shown != nil {
+				v.selectShown()
+			}
+		}
+		if !msg.appended && msg.cachedAt.IsZero() {
+			v.shown = nil
+		}
+		return v, v.loadDetail()
+
+	case showIssueMsg:
+		v.shown = &msg.issue
+		if !v.loading {
+			v.selectShown()
+		}
+		return v, v.loadDetail()
+
+	case issueCreatedMsg:
+		if msg.err == nil && msg.repo == v.repo && !v.loading {
+			v.all = append([]Issue{msg.issue}, v.all...)
+			v.applyFilter()
+			if len(v.data) == 0 || v.data[0].Number != msg.issue.Number {
+				// Don't hide what was just created
+				v.filter.Clear()
+				v.applyFilter()
+			}
+			v.cursor = 0
+			v.detailScroll = 0
+			return v, v.loadDetail()
+		}
+
+	case commentAddedMsg:
+		if msg.err == nil && msg.repo == v.repo {
+			// Show the new comment once the timeline is refetched
+			delete(v.details, msg.number)
+			return v, v.loadDetail()
+		}
+
+	case issueEditedMsg:
+		if msg.err == nil && msg.repo == v.repo {
+			for i := range v.all {
+				if v.all[i].Number == msg.issue.Number {
+					v.all[i] = msg.issue
+				}
+			}
+			v.applyFilter()
+			// The timeline gained events for the change
+			delete(v.details, msg.issue.Number)
+			return v, v.loadDetail()
+		}
+
+	case batchProgressMsg:
+		// Keep what failed marked for another try, and show what changed
+		reselectFailures(v.selection, msg)
+		status := batchStatus(msg)
+		return v, tea.Sequence(v.fetchPage(""), func() tea.Msg { return statusMsg{message: status} })
+
+	case issueDetailLoadedMsg:
+		if staleRepo(msg.repo, v.repo) {
+			// The same number in the repo before a switch; the new repo's
+			// detail may still be loading
+			return v, nil
+		}
+		delete(v.detailLoading, msg.number)
+		if msg.err != nil {
+			v.detailErrs[msg.number] = msg.err
+		} else {
+			delete(v.detailErrs, msg.number)
+			v.details[msg.number] = msg.detail
 		}
 
 	case tea.KeyMsg:
 		if !v.focused {
 			return v, nil
 		}
+		if v.filter.Editing() {
+			cmd := v.filter.HandleKey(msg)
+			v.applyFilter()
+			v.detailScroll = 0
+			return v, tea.Batch(cmd, v.loadDetail())
+		}
 
 		switch msg.String() {
+		case "/":
+			return v, v.filter.Start()
 		case "up", "k":
 			if v.cursor > 0 {
 				v.cursor--
+				v.detailScroll = 0
 			}
+			return v, v.loadDetail()
 		case "down", "j":
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				v.detailScroll = 0
 			}
-		case "r":
-			// Smart 'r' key: reopen if closed, otherwise refresh
-			if len(v.data) > 0 && v.cursor < len(v.data) {
-				issue := v.data[v.cursor]
-				if issue.State == "CLOSED" {
-					// Reopen closed issue
-					return v, reopenIssue(issue.Number)
-				}
+			return v, tea.Batch(v.loadMore(), v.loadDetail())
+		case "K":
+			// Scroll the detail pane
+			if v.detailScroll > 0 {
+				v.detailScroll--
 			}
-			// Otherwise refresh the view
+		case "J":
+			v.detailScroll++
+		case "r":
+			// Refresh the view; X reopens
 			v.loading = true
 			v.err = nil
-			return v, fetchIssues("")
+			return v, v.fetchPage("")
+		case "V":
+			// Start or end a visual range
+			if len(v.data) > 0 {
+				v.selection.ToggleVisual(v.cursor, v.issueKey)
+			}
+		case "ctrl+a":
+			v.selection.SelectAll(len(v.data), v.issueKey)
+		case "esc":
+			// Clear the selection first, then the filter
+			if v.selection.Active() {
+				v.selection.Clear()
+			} else if v.filter.Active() {
+				v.filter.Clear()
+				v.applyFilter()
+				return v, v.loadDetail()
+			}
 		case "x":
-			// Close open issue
+			// Close the marked issues, or the open issue under the cursor
+			if v.selection.Active() {
+				return v, v.runBatch("Closing", "Closed", "OPEN", ghClient.CloseIssue)
+			}
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				issue := v.data[v.cursor]
 				if issue.State == "OPEN" {
-					return v, closeIssue(issue.Number)
+					return v, closeIssue(v.repo, issue.Number)
+				}
+			}
+		case "X":
+			// Reopen the marked issues, or the closed issue under the cursor
+			if v.selection.Active() {
+				return v, v.runBatch("Reopening", "Reopened", "CLOSED", ghClient.ReopenIssue)
+			}
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				issue := v.data[v.cursor]
+				if issue.State == "CLOSED" {
+					return v, reopenIssue(v.repo, issue.Number)
 				}
 			}
 		case "b":
 			// Open issue in browser
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				issue := v.data[v.cursor]
-				return v, openInBrowser("issue", fmt.Sprintf("%d", issue.Number), "")
+				return v, openInBrowser("issue", fmt.Sprintf("%d", issue.Number), v.repo)
 			}
 		case "n":
-			// Create new issue
-			return v, createNewIssue()
+			// Compose a new issue
+			return v, openIssueComposer(v.repo)
+		case "N":
+			// Create new issue in the browser, e.g. for attachments
+			return v, createNewIssue(v.repo)
+		case "C":
+			// Comment on the selected issue
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				number := v.data[v.cursor].Number
+				return v, openCommentComposer(v.repo, number, fmt.Sprintf("issue #%d", number))
+			}
+		case "l", "a", "m":
+			// Edit labels, assignees or milestone; add labels to marked issues
+			if msg.String() == "l" && v.selection.Active() {
+				var numbers []int
+				for _, issue := range v.selectedIssues() {
+					numbers = append(numbers, issue.Number)
+				}
+				return v, openBatchLabelDialog(v.tab, v.repo, numbers, "issues")
+			}
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				field := map[string]issueField{
+					"l": issueFieldLabels, "a": issueFieldAssignees, "m": issueFieldMilestone,
+				}[msg.String()]
+				return v, openIssueFieldDialog(v.repo, v.data[v.cursor], field)
+			}
 		}
 
 	case tea.MouseMsg:
@@ -98,11 +291,15 @@ func (v *IssueView) Update(msg tea.Msg) (View, tea.Cmd) {
 		case tea.MouseWheelUp:
 			if v.cursor > 0 {
 				v.cursor--
+				v.detailScroll = 0
 			}
+			return v, v.loadDetail()
 		case tea.MouseWheelDown:
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				v.detailScroll = 0
 			}
+			return v, tea.Batch(v.loadMore(), v.loadDetail())
 		}
 	}
 
@@ -126,7 +323,7 @@ func (v *IssueView) View(width, height int) string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", v.err)))
 	}
 
-	if len(v.data) == 0 {
+	if len(v.all) == 0 && !v.filter.Editing() {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
 			dimmedStyle.Render("No issues found"))
@@ -152,9 +349,16 @@ func (v *IssueView) renderList(width, height int) string {
 	var lines []string
 
 	// Header
-	title := listTitleStyle.Render(fmt.Sprintf(" Issues (%d)", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" %s (%s)", v.title, formatListCount(len(v.data), v.page)) +
+		v.selection.Status(len(v.data), v.cursor, v.issueKey) + v.filter.Title())
+	if v.loadingMore {
+		title += dimmedStyle.Render(" loading more...")
+	}
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
+	if len(v.data) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 3
@@ -180,17 +384,19 @@ func (v *IssueView) renderList(width, height int) string {
 		}
 
 		// Format: "▶ #123 Title - author • 2h ago"
-		line := fmt.Sprintf("%s#%d %s",
+		mark := v.selection.Mark(v.issueKey(i), i, v.cursor)
+		line := fmt.Sprintf("%s%s#%d %s",
 			cursor,
+			mark,
 			issue.Number,
-			truncateString(issue.Title, width-20))
+			truncateString(issue.Title, width-20-lipgloss.Width(mark)))
 
-		meta := fmt.Sprintf("%s • %s",
-			issue.Author.Login,
-			formatTimeAgo(issue.UpdatedAt))
+		meta := issueColumns(issue, v.columns)
 
 		if len(line)+len(meta)+3 < width {
-			line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+			line = v.filter.Highlight(padRight(line, width-len(meta)-3), style) + dimmedStyle.Render(meta)
+		} else {
+			line = v.filter.Highlight(line, style)
 		}
 
 		lines = append(lines, style.Render(line))
@@ -231,8 +437,15 @@ func (v *IssueView) renderDetail(width, height int) string {
 	lines = append(lines, fmt.Sprintf("Updated:   %s", formatTimeAgo(issue.UpdatedAt)))
 
 	if len(issue.Labels) > 0 {
-		labels := formatLabels(issue.Labels)
-		lines = append(lines, fmt.Sprintf("Labels:    %s", truncateString(labels, width-15)))
+		if len(formatLabels(issue.Labels)) > width-15 {
+			lines = append(lines, fmt.Sprintf("Labels:    %s", truncateString(formatLabels(issue.Labels), width-15)))
+		} else {
+			labels := make([]string, len(issue.Labels))
+			for i, label := range issue.Labels {
+				labels[i] = formatLabel(label)
+			}
+			lines = append(lines, fmt.Sprintf("Labels:    %s", strings.Join(labels, ", ")))
+		}
 	}
 
 	if len(issue.Assignees) > 0 {
@@ -253,9 +466,29 @@ func (v *IssueView) renderDetail(width, height int) string {
 	clickableURL := makeHyperlink(issue.URL, displayURL)
 	lines = append(lines, dimmedStyle.Render(fmt.Sprintf("URL: %s", clickableURL)))
 
-	// Keyboard hints
-	lines = append(lines, "")
-	lines = append(lines, helpStyle.Render("↑/↓: Navigate • b: Browser • n: New • x: Close • r: Reopen/Refresh"))
+	// Lazily loaded body and timeline
+	if detail, ok := v.details[issue.Number]; ok {
+		lines = append(lines, v.renderDetailSections(issue, detail, width-4)...)
+	} else if err, ok := v.detailErrs[issue.Number]; ok {
+		lines = append(lines, "")
+		lines = append(lines, statusFailureStyle.Render(truncateString(fmt.Sprintf("Failed to load details: %v", err), width-4)))
+	} else {
+		lines = append(lines, "")
+		lines = append(lines, dimmedStyle.Render("Loading details..."))
+	}
+
+	// Scroll the body, keeping the keyboard hints in view
+	hints := helpStyle.Width(width - 4).Render("↑/↓: Navigate • J/K: Scroll • b: Browser • n: New • C: Comment • l/a/m: Labels/Assignees/Milestone • x/X: Close/Reopen • Space/V/Ctrl+A: Select • r: Refresh")
+	visible := height - 3 - lipgloss.Height(hints) // padding and hints
+	if visible < 1 {
+		visible = 1
+	}
+	maxScroll := max(0, len(lines)-visible)
+	if v.detailScroll > maxScroll {
+		v.detailScroll = maxScroll
+	}
+	lines = lines[v.detailScroll:min(len(lines), v.detailScroll+visible)]
+	lines = append(lines, "", hints)
 
 	content := strings.Join(lines, "\n")
 	return lipgloss.NewStyle().
@@ -265,6 +498,306 @@ func (v *IssueView) renderDetail(width, height int) string {
 		Render(content)
 }
 
+// renderDetailSections renders the body and comment timeline of an issue
+func (v *IssueView) renderDetailSections(issue Issue, detail IssueDetail, width int) []string {
+	var lines []string
+
+	// Body
+	lines = append(lines, "")
+	lines = append(lines, detailTitleStyle.Render("Description"))
+	if strings.TrimSpace(detail.Body) == "" {
+		lines = append(lines, dimmedStyle.Render("No description provided."))
+	} else {
+		lines = append(lines, renderMarkdown(detail.Body, width)...)
+	}
+
+	// Timeline
+	comments := 0
+	for _, event := range detail.Timeline {
+		if event.Type == "comment" {
+			comments++
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, detailTitleStyle.Render(fmt.Sprintf("Activity (%s)", pluralize(comments, "comment"))))
+	if detail.EarlierEvents > 0 {
+		lines = append(lines, dimmedStyle.Render(fmt.Sprintf("  %s earlier on GitHub (b)", pluralize(detail.EarlierEvents, "event"))))
+	}
+	if len(detail.Timeline) == 0 {
+		lines = append(lines, dimmedStyle.Render("No activity yet."))
+	}
+	// Cross-references from the issue's own repo don't need the repo name
+	repoURL, _, _ := strings.Cut(issue.URL, "/issues/")
+	for i, event := range detail.Timeline {
+		if event.Type != "comment" {
+			if i > 0 && detail.Timeline[i-1].Type == "comment" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, renderIssueEvent(event, repoURL, width))
+			continue
+		}
+		lines = append(lines, "")
+		lines = append(lines, highlightStyle.Render("● "+event.Actor.Login)+
+			dimmedStyle.Render(" commented "+formatTimeAgo(event.CreatedAt)))
+		if strings.TrimSpace(event.Body) == "" {
+			lines = append(lines, dimmedStyle.Render("  No description provided."))
+		}
+		for _, line := range renderMarkdown(event.Body, width-2) {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	return lines
+}
+
+// renderIssueEvent renders a timeline event other than a comment as one line
+func renderIssueEvent(event IssueEvent, repoURL string, width int) string {
+	var what string
+	switch event.Type {
+	case "labeled", "unlabeled":
+		verb := "added"
+		if event.Type == "unlabeled" {
+			verb = "removed"
+		}
+		if event.Label != nil {
+			what = fmt.Sprintf("%s %s", verb, formatLabel(*event.Label))
+		}
+	case "assigned", "unassigned":
+		switch {
+		case event.Type == "unassigned":
+			what = "unassigned " + event.Assignee
+		case event.Assignee == event.Actor.Login:
+			what = "self-assigned this"
+		default:
+			what = "assigned " + event.Assignee
+		}
+	case "cross-referenced":
+		if ref := event.Source; ref != nil {
+			kind := "issue"
+			if ref.IsPullRequest {
+				kind = "PR"
+			}
+			target := fmt.Sprintf("%s #%d", kind, ref.Number)
+			if ref.Repo != "" && !strings.HasPrefix(ref.URL, repoURL+"/") {
+				target = fmt.Sprintf("%s %s#%d", kind, ref.Repo, ref.Number)
+			}
+			what = "mentioned this in " + makeHyperlink(ref.URL, target)
+			if room := width - len(event.Actor.Login) - len(target) - 36; ref.Title != "" && room > 5 {
+				what += dimmedStyle.Render(" " + truncateString(ref.Title, room))
+			}
+		}
+	case "closed":
+		what = statusFailureStyle.Render("closed this")
+	case "reopened":
+		what = statusSuccessStyle.Render("reopened this")
+	case "renamed":
+		what = fmt.Sprintf("renamed this from %q", truncateString(event.From, max(10, width/3)))
+	case "milestoned":
+		what = "added this to " + event.Milestone
+	case "demilestoned":
+		what = "removed this from " + event.Milestone
+	}
+	if what == "" {
+		what = event.Type
+	}
+
+	return dimmedStyle.Render("  · "+event.Actor.Login+" ") + what + dimmedStyle.Render(" "+formatTimeAgo(event.CreatedAt))
+}
+
+// applyFilter narrows the loaded issues to those matching the filter,
+// keeping the cursor on the same issue while it still matches
+func (v *IssueView) applyFilter() {
+	current := -1
+	if v.cursor < len(v.data) {
+		current = v.data[v.cursor].Number
+	}
+	data := []Issue{}
+	cursor := -1
+	for _, issue := range v.all {
+		if !v.filter.Match(issueFilterItem(issue)) {
+			continue
+		}
+		if issue.Number == current {
+			cursor = len(data)
+		}
+		data = append(data, issue)
+	}
+	v.data = data
+	if cursor >= 0 {
+		v.cursor = cursor
+	} else {
+		v.cursor = max(0, min(v.cursor, len(v.data)-1))
+	}
+}
+
+// selectShown puts the cursor on the issue opened from the Search tab,
+// adding it to the list if it isn't loaded and clearing a filter that hides it
+func (v *IssueView) selectShown() {
+	index := func() int {
+		for i, issue := range v.data {
+			if issue.Number == v.shown.Number {
+				return i
+			}
+		}
+		return -1
+	}
+	found := false
+	for _, issue := range v.all {
+		if issue.Number == v.shown.Number {
+			found = true
+			break
+		}
+	}
+	if !found {
+		v.all = append([]Issue{*v.shown}, v.all...)
+	}
+	v.applyFilter()
+	if index() < 0 {
+		v.filter.Clear()
+		v.applyFilter()
+	}
+	v.cursor = max(0, index())
+	v.detailScroll = 0
+}
+
+// issueColumns renders the columns of an issue's row, e.g. "octocat • 2h ago"
+func issueColumns(issue Issue, columns []string) string {
+	var values []string
+	for _, column := range columns {
+		value := ""
+		switch column {
+		case "author":
+			value = issue.Author.Login
+		case "updated":
+			value = formatTimeAgo(issue.UpdatedAt)
+		case "created":
+			value = formatTimeAgo(issue.CreatedAt)
+		case "labels":
+			if len(issue.Labels) > 0 {
+				value = formatLabels(issue.Labels)
+			}
+		case "assignees":
+			logins := make([]string, len(issue.Assignees))
+			for i, assignee := range issue.Assignees {
+				logins[i] = "@" + assignee.Login
+			}
+			value = strings.Join(logins, ", ")
+		case "milestone":
+			if issue.Milestone != nil {
+				value = issue.Milestone.Title
+			}
+		case "state":
+			value = strings.ToLower(issue.State)
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " • ")
+}
+
+// issueFilterItem is what the filter matches an issue on
+func issueFilterItem(issue Issue) filterItem {
+	item := filterItem{
+		fields: map[string][]string{
+			"author": {issue.Author.Login},
+			"state":  {issue.State},
+			"is":     {issue.State, "issue"},
+		},
+		text: []string{issue.Title, fmt.Sprintf("#%d", issue.Number)},
+	}
+	for _, label := range issue.Labels {
+		item.fields["label"] = append(item.fields["label"], label.Name)
+	}
+	for _, assignee := range issue.Assignees {
+		item.fields["assignee"] = append(item.fields["assignee"], assignee.Login)
+	}
+	if issue.Milestone != nil {
+		item.fields["milestone"] = []string{issue.Milestone.Title}
+	}
+	// no:label matches issues without any
+	for _, key := range []string{"label", "assignee", "milestone"} {
+		if len(item.fields[key]) == 0 {
+			item.fields["no"] = append(item.fields["no"], key)
+		}
+	}
+	return item
+}
+
+// Filtering reports whether the filter prompt is open
+func (v *IssueView) Filtering() bool {
+	return v.filter.Editing()
+}
+
+// issueKey is the selection key of the issue at index i
+func (v *IssueView) issueKey(i int) string {
+	return fmt.Sprint(v.data[i].Number)
+}
+
+// ToggleSelection marks or unmarks the issue under the cursor
+func (v *IssueView) ToggleSelection() {
+	if v.cursor < len(v.data) {
+		v.selection.Toggle(v.issueKey(v.cursor))
+	}
+}
+
+// selectedIssues returns the marked issues in list order
+func (v *IssueView) selectedIssues() []Issue {
+	var issues []Issue
+	for _, i := range v.selection.Indexes(len(v.data), v.cursor, v.issueKey) {
+		issues = append(issues, v.data[i])
+	}
+	return issues
+}
+
+// runBatch runs action on the marked issues that are in state, skipping
+// the rest (e.g. closing issues that are already closed)
+func (v *IssueView) runBatch(verb, past, state string, action func(repo string, number int) error) tea.Cmd {
+	var numbers []int
+	for _, issue := range v.selectedIssues() {
+		if issue.State == state {
+			numbers = append(numbers, issue.Number)
+		}
+	}
+	if len(numbers) == 0 {
+		return func() tea.Msg {
+			return statusMsg{message: fmt.Sprintf("No %s issues selected", strings.ToLower(state))}
+		}
+	}
+	return runBatch(v.tab, verb, past, "issues", issueBatch(v.repo, numbers, action))
+}
+
+// loadDetail fetches the selected issue's detail unless it is cached or in flight
+func (v *IssueView) loadDetail() tea.Cmd {
+	if v.cursor < 0 || v.cursor >= len(v.data) {
+		return nil
+	}
+	number := v.data[v.cursor].Number
+	if _, ok := v.details[number]; ok || v.detailLoading[number] {
+		return nil
+	}
+	v.detailLoading[number] = true
+	return fetchIssueDetail(v.repo, number)
+}
+
+// fetchPage fetches the page of issues after cursor, of the tab's saved
+// query if it has one
+func (v *IssueView) fetchPage(cursor string) tea.Cmd {
+	if v.saved != nil {
+		return fetchTabPage(v.tab, *v.saved, v.repo, cursor)
+	}
+	return fetchIssuesPage(v.repo, cursor)
+}
+
+// loadMore requests the next page when the cursor nears the end of the list
+func (v *IssueView) loadMore() tea.Cmd {
+	if !needsNextPage(v.cursor, len(v.data), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return v.fetchPage(v.page.EndCursor)
+}
+
 // Focus sets the view as focused
 func (v *IssueView) Focus() {
 	v.focused = true
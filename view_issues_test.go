@@ -0,0 +1,133 @@
+package main
+
+import "testing"
+
+// loadedIssueView returns a focused issue view of the fixture repo with its
+// first page loaded
+func loadedIssueView(t *testing.T) *IssueView {
+	t.Helper()
+	v := NewIssueView()
+	v.Focus()
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	v.Update(runCmd(fetchIssues(fixtureRepo)))
+	if v.loading || v.err != nil {
+		t.Fatalf("loading = %t, err = %v", v.loading, v.err)
+	}
+	return v
+}
+
+func TestIssueViewLoadsPages(t *testing.T) {
+	c := newTestClient(t)
+	addIssues(c, pageSize)
+
+	v := loadedIssueView(t)
+	if len(v.data) != pageSize || !v.page.HasNextPage {
+		t.Fatalf("first page: %d issues, %+v", len(v.data), v.page)
+	}
+
+	// Nearing the end of the list asks for the next page, once
+	v.cursor = len(v.data) - 1
+	cmd := v.loadMore()
+	if cmd == nil || !v.loadingMore {
+		t.Fatal("no next page requested at the end of the list")
+	}
+	if v.loadMore() != nil {
+		t.Error("next page requested twice")
+	}
+
+	msg, ok := runCmd(cmd).(issuesLoadedMsg)
+	if !ok || !msg.appended {
+		t.Fatalf("next page: %#v", msg)
+	}
+	v.Update(msg)
+	if len(v.data) != len(c.Issues) || v.page.HasNextPage || v.loadingMore {
+		t.Errorf("after next page: %d of %d issues, %+v", len(v.data), len(c.Issues), v.page)
+	}
+	if v.cursor != pageSize-1 {
+		t.Errorf("cursor moved to %d", v.cursor)
+	}
+}
+
+func TestIssueViewDropsStaleRepo(t *testing.T) {
+	newTestClient(t)
+
+	v := NewIssueView()
+	v.Update(repoChangedMsg{repo: "octo-org/other"})
+	v.Update(runCmd(fetchIssues(fixtureRepo)))
+	if len(v.all) != 0 || !v.loading {
+		t.Errorf("kept %d issues loaded for %s", len(v.all), fixtureRepo)
+	}
+
+	v.Update(issueDetailLoadedMsg{repo: fixtureRepo, number: 57, detail: IssueDetail{Number: 57}})
+	if _, ok := v.details[57]; ok {
+		t.Errorf("kept the detail of #57 in %s", fixtureRepo)
+	}
+}
+
+func TestIssueViewFilter(t *testing.T) {
+	newTestClient(t)
+	v := loadedIssueView(t)
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{57, 55, 50}},
+		{"is:closed", []int{50}},
+		{"-is:closed", []int{57, 55}},
+		{"assignee:octocat", []int{57}},
+		{"enterprise", []int{55}},
+		{"#50", []int{50}},
+	}
+	for _, tt := range tests {
+		v.filter.Set(tt.query)
+		v.applyFilter()
+		var got []int
+		for _, issue := range v.data {
+			got = append(got, issue.Number)
+		}
+		if !equalInts(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestIssueViewCloseAndReopen(t *testing.T) {
+	c := newTestClient(t)
+	v := loadedIssueView(t)
+
+	// x closes the open issue under the cursor
+	_, cmd := v.Update(keyPress("x"))
+	if _, ok := runCmd(cmd).(statusMsg); !ok {
+		t.Fatal("closing #57 failed")
+	}
+	assertCalls(t, c, "CloseIssue octo-org/fixtures 57")
+	if c.Issues[0].State != "CLOSED" {
+		t.Errorf("#57 is %s", c.Issues[0].State)
+	}
+
+	// and X reopens a closed one
+	v.cursor = 2
+	_, cmd = v.Update(keyPress("X"))
+	runCmd(cmd)
+	assertCalls(t, c, "CloseIssue octo-org/fixtures 57", "ReopenIssue octo-org/fixtures 50")
+
+	// X does nothing to an open issue
+	v.cursor = 1
+	if _, cmd = v.Update(keyPress("X")); cmd != nil {
+		t.Error("X on open #55 did something")
+	}
+}
+
+// equalInts reports whether a and b hold the same numbers in the same order
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
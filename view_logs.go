@@ -0,0 +1,757 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LogView shows a workflow run's logs as an overlay, one section per job
+// step. Steps and ##[group] sections fold; those with errors start open,
+// and until the user moves the cursor follows the first failure.
+// The logs stream in while the view is open (see logs.go).
+type LogView struct {
+	repo     string
+	runID    int64
+	title    string
+	sections []*logSection
+	loading  bool          // Stream still running
+	stop     chan struct{} // Closed when the view closes, ending the stream
+	stopped  bool
+	focused  bool
+	err      error
+	width    int
+	height   int
+
+	cursor int // Row of the log pane
+	scroll int
+	moved  bool            // The user moved; don't jump on arrival any more
+	open   map[logPos]bool // Fold state set by the user; other folds use their default
+
+	// Job and step to show once they arrive ("" when done or none)
+	targetJob  string
+	targetStep string
+
+	// Search
+	search    textinput.Model
+	searching bool   // Typing a query
+	query     string // Highlighted query
+	origin    logPos // Where the query being typed is searched from
+
+	saving *textinput.Model // Save path prompt, nil when not saving
+	notice string           // Feedback shown in the hints line
+}
+
+// logSection is a section of the log with what its folds default to
+type logSection struct {
+	LogSection
+	errors      int          // ##[error] lines
+	groupErrors map[int]bool // Groups (by start line) with ##[error] lines
+}
+
+// logPos is a line of a section; line -1 is the section header
+// Folds are keyed by the header of the step or group they hide.
+type logPos struct {
+	section int
+	line    int
+}
+
+// NewLogView creates the log viewer for run, showing job and step (either
+// may be "") once their logs arrive
+func NewLogView(repo string, run WorkflowRun, job, step string) *LogView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.CharLimit = 200
+
+	return &LogView{
+		repo:       repo,
+		runID:      run.DatabaseId,
+		title:      fmt.Sprintf("%s #%d", run.Name, run.RunNumber),
+		loading:    true,
+		stop:       make(chan struct{}),
+		open:       make(map[logPos]bool),
+		targetJob:  job,
+		targetStep: step,
+		search:     search,
+	}
+}
+
+// openLogView opens the log viewer for run and starts streaming its logs
+func openLogView(repo string, run WorkflowRun, job, step string) tea.Cmd {
+	view := NewLogView(repo, run, job, step)
+	return tea.Sequence(openOverlay(view), streamRunLogs(repo, run.DatabaseId, view.stop))
+}
+
+// Update handles messages for the log view
+func (v *LogView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case logChunkMsg:
+		if msg.runID != v.runID || v.stopped {
+			return v, nil
+		}
+		if msg.err != nil {
+			// The stream ends after an error
+			v.loading = false
+			v.err = msg.err
+			return v, nil
+		}
+		v.appendChunk(msg.section, msg.more)
+		return v, waitForLogChunk(v.runID, msg.stream)
+
+	case logStreamDoneMsg:
+		if msg.runID != v.runID {
+			return v, nil
+		}
+		v.loading = false
+		v.targetJob, v.targetStep = "", ""
+
+	case logSavedMsg:
+		if msg.err != nil {
+			v.notice = fmt.Sprintf("Save failed: %v", msg.err)
+		} else {
+			v.notice = "Saved to " + msg.path
+		}
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		if v.saving != nil {
+			return v.updateSave(msg)
+		}
+		if v.searching {
+			return v.updateSearch(msg)
+		}
+		return v.updateKeys(msg)
+
+	case tea.MouseMsg:
+		if !v.focused || v.searching || v.saving != nil {
+			return v, nil
+		}
+
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.moveCursor(-3)
+		case tea.MouseWheelDown:
+			v.moveCursor(3)
+		}
+	}
+
+	return v, nil
+}
+
+// updateKeys handles keys while browsing the log
+func (v *LogView) updateKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	v.notice = ""
+	page := max(1, v.height-6)
+	switch msg.String() {
+	case "esc":
+		if v.query != "" {
+			v.query = ""
+			return v, nil
+		}
+		return v, v.close()
+	case "q":
+		return v, v.close()
+	case "down", "j":
+		v.moveCursor(1)
+	case "up", "k":
+		v.moveCursor(-1)
+	case "ctrl+d":
+		v.moveCursor(page / 2)
+	case "ctrl+u":
+		v.moveCursor(-page / 2)
+	case "pgdown":
+		v.moveCursor(page)
+	case "pgup":
+		v.moveCursor(-page)
+	case "g", "home":
+		v.moved = true
+		v.cursor = 0
+	case "G", "end":
+		v.moved = true
+		v.cursor = len(v.rows())
+	case "enter", " ":
+		v.toggleFold()
+	case "]":
+		v.moveSection(1)
+	case "[":
+		v.moveSection(-1)
+	case "f":
+		// First failure of the run
+		if pos, ok := v.find(logPos{0, -1}, true, false, isLogError); ok {
+			v.jumpTo(pos)
+		} else {
+			v.notice = "No errors"
+		}
+	case "e", "E":
+		v.findNext(msg.String() == "e", isLogError, "No errors")
+	case "/":
+		v.searching = true
+		v.origin = v.currentPos()
+		v.search.SetValue("")
+		v.search.Focus()
+		return v, textinput.Blink
+	case "n", "N":
+		if v.query == "" {
+			v.notice = "No search (press /)"
+			return v, nil
+		}
+		v.findNext(msg.String() == "n", v.matchesQuery, "No matches for "+v.query)
+	case "s":
+		path := textinput.New()
+		path.Prompt = "Save to: "
+		path.SetValue(fmt.Sprintf("run-%d.log", v.runID))
+		path.CursorEnd()
+		path.Focus()
+		v.saving = &path
+		return v, textinput.Blink
+	case "b":
+		return v, openInBrowser("run", fmt.Sprintf("%d", v.runID), v.repo)
+	}
+
+	return v, nil
+}
+
+// updateSearch handles keys while a query is typed; each change searches
+// again from where the search started
+func (v *LogView) updateSearch(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.searching = false
+		v.query = ""
+		v.search.Blur()
+		return v, nil
+	case "enter":
+		v.searching = false
+		v.search.Blur()
+		if v.query != "" {
+			if _, ok := v.find(v.origin, true, false, v.matchesQuery); !ok {
+				v.notice = "No matches for " + v.query
+			}
+		}
+		return v, nil
+	}
+
+	search, cmd := v.search.Update(msg)
+	v.search = search
+	v.query = search.Value()
+	if v.query != "" {
+		if pos, ok := v.find(v.origin, true, false, v.matchesQuery); ok {
+			v.jumpTo(pos)
+		}
+	}
+	return v, cmd
+}
+
+// updateSave handles keys in the save path prompt
+func (v *LogView) updateSave(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.saving = nil
+		return v, nil
+	case "enter":
+		path := strings.TrimSpace(v.saving.Value())
+		v.saving = nil
+		if path == "" {
+			return v, nil
+		}
+		v.notice = "Saving..."
+		return v, saveLog(path, v.plainText())
+	}
+
+	saving, cmd := v.saving.Update(msg)
+	v.saving = &saving
+	return v, cmd
+}
+
+// close ends the stream and closes the view
+func (v *LogView) close() tea.Cmd {
+	if !v.stopped {
+		v.stopped = true
+		close(v.stop)
+	}
+	return closeOverlay()
+}
+
+// appendChunk adds a chunk of lines to the log
+func (v *LogView) appendChunk(chunk LogSection, more bool) {
+	var section *logSection
+	if more && len(v.sections) > 0 {
+		section = v.sections[len(v.sections)-1]
+		section.Lines = append(section.Lines, chunk.Lines...)
+	} else {
+		section = &logSection{LogSection: chunk, groupErrors: make(map[int]bool)}
+		v.sections = append(v.sections, section)
+	}
+
+	firstError := section.errors == 0
+	for _, line := range chunk.Lines {
+		if line.Kind != LogError {
+			continue
+		}
+		section.errors++
+		if line.Group >= 0 {
+			section.groupErrors[line.Group] = true
+		}
+	}
+
+	switch {
+	case v.moved:
+	case v.targetJob != "" && !more && sameLogName(chunk.Job, v.targetJob) &&
+		(v.targetStep == "" || sameLogName(chunk.Step, v.targetStep)):
+		// The job or step the view was opened on
+		v.targetJob, v.targetStep = "", ""
+		v.moved = true
+		index := len(v.sections) - 1
+		v.open[logPos{index, -1}] = true
+		v.cursor = v.rowOf(logPos{index, -1})
+		v.scroll = v.cursor
+	case v.targetJob == "" && firstError && section.errors > 0:
+		// Until the user moves, follow the first failure
+		if pos, ok := v.find(logPos{0, -1}, true, false, isLogError); ok {
+			v.jumpTo(pos)
+			v.moved = false
+		}
+	}
+}
+
+// sameLogName compares job and step names loosely; archive file names
+// drop characters that can't be in paths
+func sameLogName(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, s)
+	}
+	return normalize(a) == normalize(b)
+}
+
+// isOpen tells whether the step or group starting at pos is unfolded
+func (v *LogView) isOpen(pos logPos) bool {
+	if open, ok := v.open[pos]; ok {
+		return open
+	}
+	section := v.sections[pos.section]
+	if pos.line < 0 {
+		// Whole-job sections have no steps to pick from
+		return section.errors > 0 || section.Step == ""
+	}
+	return section.groupErrors[pos.line]
+}
+
+// rows lists the visible lines, in order
+func (v *LogView) rows() []logPos {
+	var rows []logPos
+	for i, section := range v.sections {
+		rows = append(rows, logPos{i, -1})
+		if !v.isOpen(logPos{i, -1}) {
+			continue
+		}
+		for j, line := range section.Lines {
+			if line.Group >= 0 && !v.isOpen(logPos{i, line.Group}) {
+				continue
+			}
+			rows = append(rows, logPos{i, j})
+		}
+	}
+	return rows
+}
+
+// rowOf returns the row showing pos, or the closest one before it
+func (v *LogView) rowOf(pos logPos) int {
+	rows := v.rows()
+	return sort.Search(len(rows), func(i int) bool {
+		r := rows[i]
+		return r.section > pos.section || (r.section == pos.section && r.line >= pos.line)
+	})
+}
+
+// currentPos returns the line under the cursor
+func (v *LogView) currentPos() logPos {
+	rows := v.rows()
+	if len(rows) == 0 {
+		return logPos{0, -1}
+	}
+	return rows[max(0, min(v.cursor, len(rows)-1))]
+}
+
+// moveCursor moves the cursor by delta rows
+func (v *LogView) moveCursor(delta int) {
+	v.moved = true
+	v.cursor = max(0, min(v.cursor+delta, len(v.rows())-1))
+}
+
+// moveSection moves the cursor to the next or previous section header
+func (v *LogView) moveSection(delta int) {
+	if len(v.sections) == 0 {
+		return
+	}
+	pos := v.currentPos()
+	section := pos.section + delta
+	if delta < 0 && pos.line >= 0 {
+		section = pos.section // Back to the start of this one first
+	}
+	section = max(0, min(section, len(v.sections)-1))
+	v.moved = true
+	v.cursor = v.rowOf(logPos{section, -1})
+}
+
+// toggleFold folds or unfolds the step or group under the cursor; on a
+// line inside a group, that group folds
+func (v *LogView) toggleFold() {
+	if len(v.sections) == 0 {
+		return
+	}
+	pos := v.currentPos()
+	fold := pos
+	if pos.line >= 0 {
+		line := v.sections[pos.section].Lines[pos.line]
+		switch {
+		case line.Kind == LogGroup:
+		case line.Group >= 0:
+			fold = logPos{pos.section, line.Group}
+		default:
+			fold = logPos{pos.section, -1}
+		}
+	}
+	v.open[fold] = !v.isOpen(fold)
+	v.moved = true
+	v.cursor = v.rowOf(fold)
+}
+
+// jumpTo unfolds what hides pos and moves the cursor to it
+func (v *LogView) jumpTo(pos logPos) {
+	v.open[logPos{pos.section, -1}] = true
+	if pos.line >= 0 {
+		if group := v.sections[pos.section].Lines[pos.line].Group; group >= 0 {
+			v.open[logPos{pos.section, group}] = true
+		}
+	}
+	v.moved = true
+	v.cursor = v.rowOf(pos)
+	// Show some context above the line
+	v.scroll = max(0, v.cursor-3)
+}
+
+// findNext jumps to the next (or previous) line after the cursor that matches
+func (v *LogView) findNext(forward bool, match func(LogLine) bool, none string) {
+	from := v.currentPos()
+	pos, ok := v.find(from, forward, true, match)
+	if !ok {
+		v.notice = none
+		return
+	}
+	if (forward && !logPosBefore(from, pos)) || (!forward && !logPosBefore(pos, from)) {
+		v.notice = "Search wrapped"
+	}
+	v.jumpTo(pos)
+}
+
+// find returns the first line from from on that matches, wrapping around
+// Folded lines are searched too. skipFrom skips the line at from.
+func (v *LogView) find(from logPos, forward, skipFrom bool, match func(LogLine) bool) (logPos, bool) {
+	// Lines are numbered across sections
+	offsets := make([]int, len(v.sections))
+	total := 0
+	for i, section := range v.sections {
+		offsets[i] = total
+		total += len(section.Lines)
+	}
+	if total == 0 || from.section >= len(v.sections) {
+		return logPos{}, false
+	}
+
+	start := offsets[from.section] + max(0, from.line)
+	step := 1
+	if !forward {
+		step = -1
+		// A header is before its first line
+		if from.line < 0 {
+			skipFrom = true
+		}
+	} else if from.line < 0 {
+		skipFrom = false
+	}
+	if skipFrom {
+		start += step
+	}
+
+	for k := 0; k < total; k++ {
+		index := ((start+k*step)%total + total) % total
+		section := sort.Search(len(offsets), func(i int) bool { return offsets[i] > index }) - 1
+		line := index - offsets[section]
+		if match(v.sections[section].Lines[line]) {
+			return logPos{section, line}, true
+		}
+	}
+	return logPos{}, false
+}
+
+// logPosBefore tells whether a comes before b
+func logPosBefore(a, b logPos) bool {
+	return a.section < b.section || (a.section == b.section && a.line < b.line)
+}
+
+// isLogError tells whether line is an ##[error] line
+func isLogError(line LogLine) bool {
+	return line.Kind == LogError
+}
+
+// matchesQuery tells whether line contains the query; the search ignores
+// case unless the query has capitals
+func (v *LogView) matchesQuery(line LogLine) bool {
+	return len(queryMatches(line.Text, v.query)) > 0
+}
+
+// queryMatches returns the byte ranges of query in text
+func queryMatches(text, query string) [][2]int {
+	if query == "" {
+		return nil
+	}
+	haystack := text
+	if lower := strings.ToLower(text); strings.ToLower(query) == query && len(lower) == len(text) {
+		haystack = lower
+	}
+
+	var matches [][2]int
+	for offset := 0; ; {
+		i := strings.Index(haystack[offset:], query)
+		if i < 0 {
+			return matches
+		}
+		start := offset + i
+		matches = append(matches, [2]int{start, start + len(query)})
+		offset = start + len(query)
+	}
+}
+
+// plainText renders the whole log for saving, with section headers and
+// the ##[...] markers restored
+func (v *LogView) plainText() string {
+	markers := make(map[LogLineKind]string)
+	for _, m := range logMarkers {
+		markers[m.kind] = m.marker
+	}
+
+	var b strings.Builder
+	for _, section := range v.sections {
+		fmt.Fprintf(&b, "==> %s <==\n", logSectionName(section.LogSection))
+		for i, line := range section.Lines {
+			b.WriteString(markers[line.Kind])
+			b.WriteString(line.Text)
+			b.WriteString("\n")
+			// Close groups where the next line leaves them
+			if line.Kind == LogGroup || line.Group >= 0 {
+				if i+1 == len(section.Lines) || section.Lines[i+1].Group < 0 {
+					b.WriteString("##[endgroup]\n")
+				}
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// saveLog writes text to path, expanding a leading ~
+func saveLog(path, text string) tea.Cmd {
+	return func() tea.Msg {
+		if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+			path = filepath.Join(home, path[2:])
+		}
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			return logSavedMsg{path: path, err: err}
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return logSavedMsg{path: path}
+	}
+}
+
+// logSectionName names a section "job › step"
+func logSectionName(section LogSection) string {
+	if section.Step == "" {
+		return section.Job
+	}
+	return section.Job + " › " + section.Step
+}
+
+// View renders the log view
+func (v *LogView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	if v.err != nil && len(v.sections) == 0 {
+		return lipgloss.Place(width, height,
+			lipgloss.Center, lipgloss.Center,
+			errorStyle.Render(fmt.Sprintf("Error: %v\n\nPress Esc to go back", v.err)))
+	}
+
+	if len(v.sections) == 0 {
+		message := dimmedStyle.Render("No logs\n\nPress Esc to go back")
+		if v.loading {
+			message = infoStyle.Render(fmt.Sprintf("Downloading logs for %s...", v.title))
+		}
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, message)
+	}
+
+	// Header: run, size and stream state
+	lines, errors := 0, 0
+	for _, section := range v.sections {
+		lines += len(section.Lines)
+		errors += section.errors
+	}
+	title := detailTitleStyle.Render(" Logs ") + dimmedStyle.Render(truncateString(v.title, width/2))
+	totals := dimmedStyle.Render(pluralize(len(v.sections), "step") + ", " + pluralize(lines, "line") + " ")
+	if errors > 0 {
+		totals = statusFailureStyle.Render(pluralize(errors, "error")+", ") + totals
+	}
+	switch {
+	case v.loading:
+		totals = statusPendingStyle.Render("● loading ") + totals
+	case v.err != nil:
+		totals = statusFailureStyle.Render("incomplete ") + totals
+	}
+	header := title + strings.Repeat(" ", max(1, width-lipgloss.Width(title)-lipgloss.Width(totals))) + totals
+
+	// Hints, or the prompt being typed
+	hints := " j/k: Move • Enter: Fold • [/]: Step • f: First failure • e/E: Next/prev error • /: Search • s: Save • Esc: Back"
+	if v.query != "" {
+		hints = " n/N: Next/prev match • Esc: Clear search • j/k: Move • Enter: Fold • f: First failure • s: Save"
+	}
+	if v.notice != "" {
+		hints = " " + v.notice + " •" + hints
+	}
+	footer := helpStyle.MaxWidth(width).Render(hints)
+	switch {
+	case v.saving != nil:
+		v.saving.Width = max(10, width-12)
+		footer = v.saving.View()
+	case v.searching:
+		v.search.Width = max(10, width-4)
+		footer = v.search.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", v.renderRows(width, max(1, height-3)), footer)
+}
+
+// renderRows renders the visible rows, keeping the cursor in view
+func (v *LogView) renderRows(width, height int) string {
+	rows := v.rows()
+	if v.cursor >= len(rows) {
+		v.cursor = max(0, len(rows)-1)
+	}
+	if v.cursor < v.scroll {
+		v.scroll = v.cursor
+	}
+	if v.cursor >= v.scroll+height {
+		v.scroll = v.cursor - height + 1
+	}
+	v.scroll = max(0, min(v.scroll, len(rows)-height))
+
+	var lines []string
+	for i := v.scroll; i < min(len(rows), v.scroll+height); i++ {
+		gutter := "  "
+		if i == v.cursor {
+			gutter = listCursorStyle.Render("▶ ")
+		}
+		lines = append(lines, gutter+v.renderRow(rows[i], width-2))
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderRow renders a section header or log line in width columns
+func (v *LogView) renderRow(pos logPos, width int) string {
+	section := v.sections[pos.section]
+	if pos.line < 0 {
+		fold := "▸ "
+		if v.isOpen(pos) {
+			fold = "▾ "
+		}
+		count := " " + pluralize(len(section.Lines), "line")
+		if section.errors > 0 {
+			count = " " + pluralize(section.errors, "error")
+		}
+		name := truncateString(logSectionName(section.LogSection), max(0, width-len(count)-2))
+		header := fold + highlightStyle.Render(name)
+		if section.errors > 0 {
+			return header + statusFailureStyle.Render(count)
+		}
+		return header + dimmedStyle.Render(count)
+	}
+
+	line := section.Lines[pos.line]
+	indent := "  "
+	if line.Group >= 0 {
+		indent = "    "
+	}
+	width -= len(indent)
+
+	switch line.Kind {
+	case LogGroup:
+		fold := "▸ "
+		if v.isOpen(pos) {
+			fold = "▾ "
+		}
+		return indent + logGroupStyle.Render(fold) + v.renderText(line.Text, width-2, logGroupStyle)
+	case LogError:
+		return indent + v.renderText("Error: "+line.Text, width, statusFailureStyle)
+	case LogWarning:
+		return indent + v.renderText("Warning: "+line.Text, width, statusPendingStyle)
+	case LogNotice:
+		return indent + v.renderText("Notice: "+line.Text, width, mdCodeStyle)
+	case LogCommand:
+		return indent + v.renderText(line.Text, width, mdCodeStyle)
+	case LogDebug:
+		return indent + v.renderText(line.Text, width, dimmedStyle)
+	default:
+		return indent + v.renderText(line.Text, width, lipgloss.NewStyle())
+	}
+}
+
+// renderText truncates text to width and styles it, highlighting matches
+// of the search query
+func (v *LogView) renderText(text string, width int, style lipgloss.Style) string {
+	text = strings.ReplaceAll(text, "\t", "    ")
+	if width <= 0 {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > width {
+		text = string(runes[:width-1]) + "…"
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range queryMatches(text, v.query) {
+		b.WriteString(style.Render(text[last:m[0]]))
+		b.WriteString(logMatchStyle.Render(text[m[0]:m[1]]))
+		last = m[1]
+	}
+	b.WriteString(style.Render(text[last:]))
+	return b.String()
+}
+
+// Focus sets the view as focused
+func (v *LogView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *LogView) Blur() {
+	v.focused = false
+}
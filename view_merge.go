@@ -0,0 +1,419 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mergeMethodLabels are the dialog labels of the merge methods
+var mergeMethodLabels = map[string]string{
+	"merge":  "Create a merge commit",
+	"squash": "Squash and merge",
+	"rebase": "Rebase and merge",
+}
+
+// mergeMethodLabel returns the dialog label of a merge method
+func mergeMethodLabel(method string) string {
+	if label, ok := mergeMethodLabels[method]; ok {
+		return label
+	}
+	return method
+}
+
+// Fields of the merge dialog, in tab order
+const (
+	mergeFieldMethod = iota
+	mergeFieldTitle
+	mergeFieldBody
+	mergeFieldDelete
+	mergeFieldCount
+)
+
+// MergeDialog merges a PR with a chosen method and commit message
+type MergeDialog struct {
+	repo    string
+	number  int
+	info    MergeInfo
+	loading bool
+	focused bool
+	err     error
+
+	method       int // Index into info.AllowedMethods
+	title        textinput.Model
+	body         textarea.Model
+	deleteBranch bool
+	field        int
+
+	// Prefilled commit message of the current method, replaced when the
+	// method changes unless the user has edited it
+	defaultTitle string
+	defaultBody  string
+
+	confirming bool // Warnings shown; the next Ctrl+S merges anyway
+	merging    bool
+}
+
+// NewMergeDialog creates the merge dialog for PR number; its state arrives as mergeInfoLoadedMsg
+func NewMergeDialog(repo string, number int) *MergeDialog {
+	title := textinput.New()
+	title.Placeholder = "Commit title"
+	title.Prompt = ""
+
+	body := textarea.New()
+	body.Placeholder = "Commit message"
+	body.ShowLineNumbers = false
+	body.SetHeight(4)
+
+	return &MergeDialog{
+		repo:    repo,
+		number:  number,
+		loading: true,
+		title:   title,
+		body:    body,
+	}
+}
+
+// openMergeDialog opens the merge dialog for PR number and loads its mergeability
+func openMergeDialog(repo string, number int) tea.Cmd {
+	return tea.Sequence(openOverlay(NewMergeDialog(repo, number)), fetchMergeInfo(repo, number))
+}
+
+// Update handles messages for the merge dialog
+func (d *MergeDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case mergeInfoLoadedMsg:
+		if msg.number != d.number || staleRepo(msg.repo, d.repo) {
+			return d, nil
+		}
+		d.loading = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		d.info = msg.info
+		d.prefill()
+		return d, nil
+
+	case prMergedMsg:
+		if msg.number != d.number || staleRepo(msg.repo, d.repo) || !d.merging {
+			return d, nil
+		}
+		d.merging = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		return d, closeOverlay()
+
+	case tea.KeyMsg:
+		if !d.focused || d.merging {
+			return d, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			if d.confirming {
+				d.confirming = false
+				return d, nil
+			}
+			return d, closeOverlay()
+		}
+		if d.loading || d.blockReason() != "" {
+			return d, nil
+		}
+
+		if msg.String() == "ctrl+s" {
+			return d, d.submit()
+		}
+		d.confirming = false
+
+		switch msg.String() {
+		case "tab":
+			return d, d.moveField(1)
+		case "shift+tab":
+			return d, d.moveField(-1)
+		}
+
+		switch d.field {
+		case mergeFieldMethod:
+			switch msg.String() {
+			case "left", "h":
+				d.setMethod(d.method - 1)
+			case "right", "l":
+				d.setMethod(d.method + 1)
+			}
+			return d, nil
+		case mergeFieldDelete:
+			switch msg.String() {
+			case " ", "enter", "x":
+				d.deleteBranch = !d.deleteBranch
+			}
+			return d, nil
+		}
+	}
+
+	// Text input, cursor blink
+	var cmd tea.Cmd
+	switch d.field {
+	case mergeFieldTitle:
+		d.title, cmd = d.title.Update(msg)
+	case mergeFieldBody:
+		d.body, cmd = d.body.Update(msg)
+	}
+	return d, cmd
+}
+
+// methodName returns the selected merge method
+func (d *MergeDialog) methodName() string {
+	if d.method < len(d.info.AllowedMethods) {
+		return d.info.AllowedMethods[d.method]
+	}
+	return ""
+}
+
+// setMethod selects a merge method, wrapping around, and updates the message
+func (d *MergeDialog) setMethod(index int) {
+	n := len(d.info.AllowedMethods)
+	if n == 0 {
+		return
+	}
+	d.method = (index%n + n) % n
+	d.prefill()
+}
+
+// prefill fills in the default commit message of the selected method,
+// keeping whatever the user has typed over the previous default
+func (d *MergeDialog) prefill() {
+	var title, body string
+	switch d.methodName() {
+	case "merge":
+		title = fmt.Sprintf("Merge pull request #%d from %s", d.number, d.info.HeadRefName)
+		body = d.info.Title
+	case "squash":
+		title = fmt.Sprintf("%s (#%d)", d.info.Title, d.number)
+		body = d.info.Body
+	}
+
+	if d.title.Value() == d.defaultTitle {
+		d.title.SetValue(title)
+	}
+	if d.body.Value() == d.defaultBody {
+		d.body.SetValue(body)
+	}
+	d.defaultTitle, d.defaultBody = title, body
+}
+
+// moveField focuses the next (delta 1) or previous (delta -1) usable field
+func (d *MergeDialog) moveField(delta int) tea.Cmd {
+	for i := 0; i < mergeFieldCount; i++ {
+		d.field = (d.field + delta + mergeFieldCount) % mergeFieldCount
+		if d.fieldEnabled(d.field) {
+			break
+		}
+	}
+
+	d.title.Blur()
+	d.body.Blur()
+	switch d.field {
+	case mergeFieldTitle:
+		return d.title.Focus()
+	case mergeFieldBody:
+		return d.body.Focus()
+	}
+	return nil
+}
+
+// fieldEnabled reports whether a field applies to the selected method and PR
+func (d *MergeDialog) fieldEnabled(field int) bool {
+	switch field {
+	case mergeFieldTitle, mergeFieldBody:
+		// Rebasing keeps the PR's commits as they are
+		return d.methodName() != "rebase"
+	case mergeFieldDelete:
+		return d.info.CanDeleteBranch
+	}
+	return true
+}
+
+// blockReason explains why the PR can't be merged, "" when it can
+func (d *MergeDialog) blockReason() string {
+	info := d.info
+	switch {
+	case info.State == "MERGED":
+		return fmt.Sprintf("#%d is already merged.", d.number)
+	case info.State == "CLOSED":
+		return fmt.Sprintf("#%d is closed. Reopen it to merge.", d.number)
+	case info.IsDraft || info.MergeStateStatus == "DRAFT":
+		return fmt.Sprintf("#%d is a draft. Mark it ready for review first.", d.number)
+	case len(info.AllowedMethods) == 0:
+		return "The repository doesn't allow any merge method."
+	case info.Mergeable == "CONFLICTING" || info.MergeStateStatus == "DIRTY":
+		return fmt.Sprintf("%s has conflicts with %s. Resolve them before merging.", info.HeadRefName, info.BaseRefName)
+	case info.MergeStateStatus == "BLOCKED" && info.ReviewDecision != "APPROVED":
+		return "Merging is blocked by branch protection: an approving review is required."
+	case info.MergeStateStatus == "BLOCKED":
+		return "Merging is blocked by branch protection: required checks or rules are not satisfied."
+	case info.MergeStateStatus == "BEHIND":
+		return fmt.Sprintf("%s is behind %s. Update it before merging.", d.info.HeadRefName, d.info.BaseRefName)
+	}
+	return ""
+}
+
+// warnings lists what should make the user think twice before merging
+func (d *MergeDialog) warnings() []string {
+	var warnings []string
+	if d.info.Mergeable == "UNKNOWN" {
+		warnings = append(warnings, "GitHub is still checking whether this branch can be merged")
+	}
+	switch d.info.ReviewDecision {
+	case "APPROVED":
+	case "CHANGES_REQUESTED":
+		warnings = append(warnings, "Changes have been requested")
+	case "REVIEW_REQUIRED":
+		warnings = append(warnings, "A review is required but the PR isn't approved")
+	default:
+		warnings = append(warnings, "The PR hasn't been approved")
+	}
+	if d.info.MergeStateStatus == "UNSTABLE" {
+		warnings = append(warnings, "Some checks are failing or haven't finished")
+	}
+	return warnings
+}
+
+// submit merges the PR, asking once for confirmation when there are warnings
+func (d *MergeDialog) submit() tea.Cmd {
+	if len(d.warnings()) > 0 && !d.confirming {
+		d.confirming = true
+		return nil
+	}
+	d.confirming = false
+
+	req := MergeRequest{Method: d.methodName(), SHA: d.info.HeadRefOid}
+	if d.fieldEnabled(mergeFieldTitle) {
+		req.Title = strings.TrimSpace(d.title.Value())
+		req.Body = strings.TrimSpace(d.body.Value())
+	}
+	branch := ""
+	if d.deleteBranch && d.info.CanDeleteBranch {
+		branch = d.info.HeadRefName
+	}
+
+	d.err = nil
+	d.merging = true
+	return mergePR(d.repo, d.number, req, branch)
+}
+
+// View renders the dialog centered in the content area
+func (d *MergeDialog) View(width, height int) string {
+	boxWidth := min(76, width-4)
+	inner := boxWidth - 6
+
+	lines := []string{dialogTitleStyle.Width(inner).Render(fmt.Sprintf("Merge #%d", d.number)), ""}
+
+	switch {
+	case d.loading:
+		lines = append(lines, infoStyle.Padding(0).Render("Checking whether the PR can be merged..."))
+		lines = append(lines, "", helpStyle.Render("Esc: Cancel"))
+	case d.err != nil && d.info.Number == 0:
+		lines = append(lines, statusFailureStyle.Render(strings.Join(wrapText("Error: "+d.err.Error(), inner), "\n")))
+		lines = append(lines, "", helpStyle.Render("Esc: Close"))
+	case d.blockReason() != "":
+		lines = append(lines, highlightStyle.Render(truncateString(d.info.Title, inner)), "")
+		lines = append(lines, statusFailureStyle.Render("Can't merge"))
+		lines = append(lines, wrapText(d.blockReason(), inner)...)
+		lines = append(lines, "", helpStyle.Render("Esc: Close"))
+	default:
+		lines = append(lines, d.renderForm(inner)...)
+	}
+
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderForm renders the method choice, commit message and options
+func (d *MergeDialog) renderForm(width int) []string {
+	label := func(field int, text string) string {
+		if d.field == field {
+			return highlightStyle.Render("▶ " + text)
+		}
+		return dimmedStyle.Render("  " + text)
+	}
+
+	lines := []string{
+		highlightStyle.Render(truncateString(d.info.Title, width)),
+		dimmedStyle.Render(truncateString(d.info.HeadRefName+" → "+d.info.BaseRefName, width)),
+		"",
+	}
+
+	// Method
+	lines = append(lines, label(mergeFieldMethod, "Method (←/→)"))
+	var buttons []string
+	for i, method := range d.info.AllowedMethods {
+		if i == d.method {
+			buttons = append(buttons, buttonActiveStyle.Render(mergeMethodLabel(method)))
+		} else {
+			buttons = append(buttons, buttonStyle.Render(mergeMethodLabel(method)))
+		}
+	}
+	lines = append(lines, "  "+lipgloss.JoinHorizontal(lipgloss.Top, buttons...), "")
+
+	// Commit message
+	if d.fieldEnabled(mergeFieldTitle) {
+		d.title.Width = width - 4
+		d.body.SetWidth(width - 2)
+		lines = append(lines, label(mergeFieldTitle, "Commit title"))
+		lines = append(lines, "  "+d.title.View())
+		lines = append(lines, label(mergeFieldBody, "Commit message"))
+		lines = append(lines, d.body.View(), "")
+	} else {
+		lines = append(lines, dimmedStyle.Render("  The PR's commits are rebased onto the base branch as they are"), "")
+	}
+
+	// Branch deletion
+	if d.info.CanDeleteBranch {
+		box := "[ ]"
+		if d.deleteBranch {
+			box = "[x]"
+		}
+		lines = append(lines, label(mergeFieldDelete, box+" Delete branch "+d.info.HeadRefName+" after merging"), "")
+	}
+
+	// Warnings and status
+	for _, warning := range d.warnings() {
+		lines = append(lines, warningStyle.Padding(0).Render(truncateString("⚠ "+warning, width)))
+	}
+	switch {
+	case d.merging:
+		lines = append(lines, infoStyle.Padding(0).Render("Merging..."))
+	case d.err != nil:
+		lines = append(lines, statusFailureStyle.Render(strings.Join(wrapText("Error: "+d.err.Error(), width), "\n")))
+	case d.confirming:
+		lines = append(lines, warningStyle.Padding(0).Render("Press Ctrl+S again to merge anyway"))
+	}
+
+	lines = append(lines, helpStyle.Render("Tab: Next field • Space: Toggle • Ctrl+S: Merge • Esc: Cancel"))
+	return lines
+}
+
+// Focus sets the dialog as focused
+func (d *MergeDialog) Focus() {
+	d.focused = true
+	switch d.field {
+	case mergeFieldTitle:
+		d.title.Focus()
+	case mergeFieldBody:
+		d.body.Focus()
+	}
+}
+
+// Blur sets the dialog as unfocused
+func (d *MergeDialog) Blur() {
+	d.focused = false
+	d.title.Blur()
+	d.body.Blur()
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestMergeDialogBlockReason(t *testing.T) {
+	ready := MergeInfo{
+		State:            "OPEN",
+		Mergeable:        "MERGEABLE",
+		MergeStateStatus: "CLEAN",
+		ReviewDecision:   "APPROVED",
+		AllowedMethods:   []string{"merge"},
+	}
+	tests := []struct {
+		name    string
+		change  func(*MergeInfo)
+		blocked bool
+	}{
+		{"ready", func(*MergeInfo) {}, false},
+		{"merged", func(i *MergeInfo) { i.State = "MERGED" }, true},
+		{"draft", func(i *MergeInfo) { i.IsDraft = true }, true},
+		{"no methods", func(i *MergeInfo) { i.AllowedMethods = nil }, true},
+		{"conflicting", func(i *MergeInfo) { i.Mergeable = "CONFLICTING" }, true},
+		{"dirty", func(i *MergeInfo) { i.MergeStateStatus = "DIRTY" }, true},
+		{"behind", func(i *MergeInfo) { i.MergeStateStatus = "BEHIND" }, true},
+		{"still checking", func(i *MergeInfo) { i.Mergeable = "UNKNOWN" }, false},
+		{"failing checks", func(i *MergeInfo) { i.MergeStateStatus = "UNSTABLE" }, false},
+	}
+	for _, tt := range tests {
+		d := NewMergeDialog(fixtureRepo, 42)
+		d.info = ready
+		tt.change(&d.info)
+		if reason := d.blockReason(); (reason != "") != tt.blocked {
+			t.Errorf("%s: block reason %q", tt.name, reason)
+		}
+	}
+}
@@ -0,0 +1,480 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notificationReasons are the reasons a thread is in the inbox with their
+// headings, in the order their groups are shown; other reasons come last
+var notificationReasons = []struct{ reason, title string }{
+	{"review_requested", "Review requested"},
+	{"mention", "Mentioned"},
+	{"team_mention", "Team mentioned"},
+	{"assign", "Assigned"},
+	{"ci_activity", "CI activity"},
+	{"approval_requested", "Approval requested"},
+	{"security_alert", "Security alert"},
+	{"author", "Your activity"},
+	{"comment", "Commented"},
+	{"state_change", "State changed"},
+	{"invitation", "Invitation"},
+	{"manual", "Subscribed"},
+	{"subscribed", "Watching"},
+}
+
+// notificationActionLabels describe the thread actions for the status bar,
+// to be done and done
+var notificationActionLabels = map[string][2]string{
+	"read":        {"mark read", "Marked read"},
+	"done":        {"mark done", "Marked done"},
+	"unsubscribe": {"unsubscribe from", "Unsubscribed from"},
+}
+
+// notificationRow is a line of the inbox: a repo heading, a reason heading
+// or a thread that can be selected
+type notificationRow struct {
+	repo   string // Repo heading
+	reason string // Reason heading, under its repo
+	count  int    // Threads under a reason heading
+	thread *Notification
+}
+
+// NotificationsView is the notifications inbox of the authenticated user,
+// grouped by repo and by why each thread is there. Threads can be marked
+// read or done or unsubscribed from, and Enter opens the PR, issue or
+// workflow runs a thread is about in their tab.
+type NotificationsView struct {
+	all           []Notification // Newest first
+	participating bool           // Leave out threads the user only watches
+	page          PageInfo
+	loading       bool
+	loadingMore   bool
+	err           error
+	unsubscribed  map[string]bool // Thread IDs
+	focused       bool
+	width         int
+	height        int
+
+	rows   []notificationRow
+	cursor int // Index into rows, always on a thread when there are any
+}
+
+// NewNotificationsView creates a new notifications view
+func NewNotificationsView() *NotificationsView {
+	return &NotificationsView{
+		loading:      true,
+		unsubscribed: make(map[string]bool),
+	}
+}
+
+// Update handles messages for the notifications view
+func (v *NotificationsView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case notificationsLoadedMsg:
+		if msg.participating != v.participating {
+			// Loaded before the filter changed
+			return v, nil
+		}
+		v.loading = false
+		v.loadingMore = false
+		if msg.err != nil {
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
+			return v, nil
+		}
+		v.err = nil
+		if msg.appended {
+			v.all = append(v.all, msg.notifications...)
+		} else {
+			v.all = msg.notifications
+		}
+		v.page = msg.page
+		v.buildRows()
+
+	case notificationDoneMsg:
+		if msg.err == nil {
+			return v, nil
+		}
+		// Undo what the action did to the list
+		switch msg.action {
+		case "read":
+			v.forThread(msg.thread.ID, func(thread *Notification) { thread.Unread = msg.thread.Unread })
+		case "done":
+			v.all = append(v.all, msg.thread)
+			sort.SliceStable(v.all, func(i, j int) bool { return v.all[i].UpdatedAt.After(v.all[j].UpdatedAt) })
+		case "unsubscribe":
+			delete(v.unsubscribed, msg.thread.ID)
+		}
+		v.buildRows()
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			v.move(-1)
+		case "down", "j":
+			v.move(1)
+			return v, v.loadMore()
+		case "[":
+			v.moveGroup(-1)
+		case "]":
+			v.moveGroup(1)
+			return v, v.loadMore()
+		case "p":
+			v.participating = !v.participating
+			return v, v.Refresh()
+		case "enter":
+			if thread, ok := v.selectedThread(); ok {
+				return v, tea.Batch(openNotification(thread), v.markRead(thread))
+			}
+		case "m":
+			if thread, ok := v.selectedThread(); ok {
+				return v, v.markRead(thread)
+			}
+		case "d":
+			if thread, ok := v.selectedThread(); ok {
+				return v, v.markDone(thread)
+			}
+		case "u":
+			if thread, ok := v.selectedThread(); ok && !v.unsubscribed[thread.ID] {
+				v.unsubscribed[thread.ID] = true
+				return v, notificationAction(thread, "unsubscribe")
+			}
+		case "b":
+			if thread, ok := v.selectedThread(); ok {
+				switch thread.Type {
+				case "PullRequest":
+					return v, openInBrowser("pr", fmt.Sprint(thread.Number), thread.Repo)
+				case "Issue":
+					return v, openInBrowser("issue", fmt.Sprint(thread.Number), thread.Repo)
+				default:
+					return v, openInBrowser("repo", thread.Repo, "")
+				}
+			}
+		}
+
+	case tea.MouseMsg:
+		if !v.focused {
+			return v, nil
+		}
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.move(-1)
+		case tea.MouseWheelDown:
+			v.move(1)
+			return v, v.loadMore()
+		}
+	}
+
+	return v, nil
+}
+
+// Refresh fetches the first page of the inbox again
+func (v *NotificationsView) Refresh() tea.Cmd {
+	v.loading = true
+	v.loadingMore = false
+	return fetchNotifications(v.participating, "")
+}
+
+// loadMore requests the next page when the cursor nears the last thread
+func (v *NotificationsView) loadMore() tea.Cmd {
+	threads := 0
+	for i, row := range v.rows {
+		if row.thread != nil && i <= v.cursor {
+			threads++
+		}
+	}
+	if !needsNextPage(threads-1, len(v.all), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return fetchNotifications(v.participating, v.page.EndCursor)
+}
+
+// markRead marks an unread thread read right away, and on GitHub
+func (v *NotificationsView) markRead(thread Notification) tea.Cmd {
+	if !thread.Unread {
+		return nil
+	}
+	v.forThread(thread.ID, func(thread *Notification) { thread.Unread = false })
+	return notificationAction(thread, "read")
+}
+
+// markDone takes a thread out of the inbox right away, and on GitHub; the
+// cursor moves on to the next thread
+func (v *NotificationsView) markDone(thread Notification) tea.Cmd {
+	for i := range v.all {
+		if v.all[i].ID == thread.ID {
+			v.all = append(v.all[:i:i], v.all[i+1:]...)
+			break
+		}
+	}
+	v.buildRows()
+	return notificationAction(thread, "done")
+}
+
+// forThread applies change to the loaded thread with id
+func (v *NotificationsView) forThread(id string, change func(thread *Notification)) {
+	for i := range v.all {
+		if v.all[i].ID == id {
+			change(&v.all[i])
+		}
+	}
+}
+
+// buildRows groups the threads by repo, the repo with the latest activity
+// first, and within a repo by reason, keeping the cursor on the same thread
+// if it is still there, or else at the same place in the list
+func (v *NotificationsView) buildRows() {
+	selected := ""
+	if thread, ok := v.selectedThread(); ok {
+		selected = thread.ID
+	}
+
+	var repos []string
+	byRepo := make(map[string]map[string][]*Notification)
+	for i := range v.all {
+		thread := &v.all[i]
+		if byRepo[thread.Repo] == nil {
+			repos = append(repos, thread.Repo)
+			byRepo[thread.Repo] = make(map[string][]*Notification)
+		}
+		byRepo[thread.Repo][thread.Reason] = append(byRepo[thread.Repo][thread.Reason], thread)
+	}
+
+	previous := v.cursor
+	v.rows = nil
+	v.cursor = -1
+	for _, repo := range repos {
+		v.rows = append(v.rows, notificationRow{repo: repo})
+		for _, reason := range notificationReasonOrder(byRepo[repo]) {
+			threads := byRepo[repo][reason]
+			v.rows = append(v.rows, notificationRow{reason: reason, count: len(threads)})
+			for _, thread := range threads {
+				if thread.ID == selected {
+					v.cursor = len(v.rows)
+				}
+				v.rows = append(v.rows, notificationRow{thread: thread})
+			}
+		}
+	}
+
+	if v.cursor < 0 {
+		v.cursor = min(previous, len(v.rows)-1)
+		if _, ok := v.selectedThread(); !ok {
+			v.move(1)
+		}
+		if _, ok := v.selectedThread(); !ok {
+			v.move(-1)
+		}
+	}
+	v.cursor = max(0, v.cursor)
+}
+
+// notificationReasonOrder lists the reasons of a repo's threads in the
+// order of notificationReasons, then any others by name
+func notificationReasonOrder(byReason map[string][]*Notification) []string {
+	var reasons, others []string
+	for _, known := range notificationReasons {
+		if _, ok := byReason[known.reason]; ok {
+			reasons = append(reasons, known.reason)
+		}
+	}
+	for reason := range byReason {
+		if !containsString(reasons, reason) {
+			others = append(others, reason)
+		}
+	}
+	sort.Strings(others)
+	return append(reasons, others...)
+}
+
+// notificationReasonTitle is the heading of the threads with reason
+func notificationReasonTitle(reason string) string {
+	for _, known := range notificationReasons {
+		if known.reason == reason {
+			return known.title
+		}
+	}
+	return strings.ReplaceAll(reason, "_", " ")
+}
+
+// selectedThread returns the thread under the cursor
+func (v *NotificationsView) selectedThread() (Notification, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.rows) || v.rows[v.cursor].thread == nil {
+		return Notification{}, false
+	}
+	return *v.rows[v.cursor].thread, true
+}
+
+// move moves the cursor to the next thread in direction (1 or -1)
+func (v *NotificationsView) move(direction int) {
+	for i := v.cursor + direction; i >= 0 && i < len(v.rows); i += direction {
+		if v.rows[i].thread != nil {
+			v.cursor = i
+			return
+		}
+	}
+}
+
+// moveGroup moves the cursor to the first thread of the next or previous
+// reason group
+func (v *NotificationsView) moveGroup(direction int) {
+	heading := v.cursor
+	for heading > 0 && v.rows[heading].thread != nil {
+		heading--
+	}
+	for i := heading + direction; i >= 0 && i < len(v.rows); i += direction {
+		if v.rows[i].reason != "" {
+			v.cursor = i + 1
+			return
+		}
+	}
+}
+
+// checkSuiteTitle is the title GitHub gives CI activity threads, e.g.
+// "CI workflow run failed for main branch"
+var checkSuiteTitle = regexp.MustCompile(`^(.+) workflow run \w+ for (\S+) branch$`)
+
+// openNotification opens the PR or issue of a thread in its tab, or for
+// CI activity the Actions tab filtered to the workflow's runs on the branch
+func openNotification(thread Notification) tea.Cmd {
+	switch thread.Type {
+	case "PullRequest":
+		msg := showPullRequestMsg{repo: thread.Repo, pr: PullRequest{Number: thread.Number, Title: thread.Title}}
+		return func() tea.Msg { return msg }
+	case "Issue":
+		msg := showIssueMsg{repo: thread.Repo, issue: Issue{Number: thread.Number, Title: thread.Title}}
+		return func() tea.Msg { return msg }
+	case "CheckSuite", "WorkflowRun":
+		msg := showFilteredMsg{view: ViewActions, repo: thread.Repo}
+		if match := checkSuiteTitle.FindStringSubmatch(thread.Title); match != nil {
+			msg.query = fmt.Sprintf("workflow:%q branch:%s", match[1], match[2])
+		}
+		return func() tea.Msg { return msg }
+	}
+	return sendStatus(fmt.Sprintf("%s threads can't be opened here; b opens %s in the browser", thread.Type, thread.Repo))
+}
+
+// View renders the notifications view
+func (v *NotificationsView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	unread := 0
+	for _, thread := range v.all {
+		if thread.Unread {
+			unread++
+		}
+	}
+	scope := "All"
+	if v.participating {
+		scope = "Participating"
+	}
+	title := listTitleStyle.Render(" Notifications") + dimmedStyle.Render(fmt.Sprintf(" • %s • %d unread", scope, unread))
+	lines := []string{
+		title,
+		helpStyle.Render(" Enter: Open • m: Mark read • d: Done • u: Unsubscribe • p: Participating/all • [/]: Previous/next group • b: Browser"),
+		"",
+	}
+
+	switch {
+	case v.loading && len(v.all) == 0:
+		lines = append(lines, infoStyle.Render(" Loading notifications..."))
+	case v.err != nil:
+		lines = append(lines, errorStyle.Render(strings.Join(wrapText("Error: "+v.err.Error(), width-4), "\n")))
+	case len(v.rows) == 0:
+		lines = append(lines, dimmedStyle.Render(" Inbox zero: nothing to catch up on"))
+	default:
+		lines = append(lines, v.renderRows(width, height-len(lines))...)
+	}
+
+	content := strings.Join(lines, "\n")
+	return lipgloss.NewStyle().Width(width).Height(height).Render(content)
+}
+
+// renderRows renders the rows that fit in height, keeping the cursor in view
+func (v *NotificationsView) renderRows(width, height int) []string {
+	var lines []string
+	cursorLine := 0
+	for i, row := range v.rows {
+		if i == v.cursor {
+			cursorLine = len(lines)
+		}
+		switch {
+		case row.repo != "":
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, detailTitleStyle.Render(" "+row.repo))
+		case row.reason != "":
+			lines = append(lines, highlightStyle.Render("  "+notificationReasonTitle(row.reason))+dimmedStyle.Render(fmt.Sprintf(" (%d)", row.count)))
+		default:
+			lines = append(lines, v.renderThread(i, *row.thread, width))
+		}
+	}
+	if v.loadingMore {
+		lines = append(lines, dimmedStyle.Render("   Loading more..."))
+	}
+
+	start := max(0, min(cursorLine-height/2, len(lines)-height))
+	return lines[start:min(len(lines), start+max(0, height))]
+}
+
+// renderThread renders the row of a thread; read threads are dimmed
+func (v *NotificationsView) renderThread(i int, thread Notification, width int) string {
+	cursor := "  "
+	style := listItemStyle
+	if i == v.cursor {
+		cursor = "▶ "
+		style = listSelectedStyle
+	} else if !thread.Unread {
+		style = dimmedStyle
+	}
+
+	dot := "  "
+	if thread.Unread {
+		dot = "● "
+	}
+	subject := thread.Type
+	switch thread.Type {
+	case "PullRequest":
+		subject = fmt.Sprintf("PR #%d", thread.Number)
+	case "Issue":
+		subject = fmt.Sprintf("Issue #%d", thread.Number)
+	case "CheckSuite", "WorkflowRun":
+		subject = "Run"
+	}
+	line := fmt.Sprintf("  %s%s%s %s", cursor, dot, subject, thread.Title)
+
+	meta := formatTimeAgo(thread.UpdatedAt)
+	if v.unsubscribed[thread.ID] {
+		meta = "🔕 unsubscribed • " + meta
+	}
+
+	room := width - lipgloss.Width(meta) - 3
+	if room < 20 {
+		return style.Render(fitWidth(line, max(1, width-1)))
+	}
+	return style.Render(fitWidth(line, room) + dimmedStyle.Render(meta))
+}
+
+// Focus sets the view as focused
+func (v *NotificationsView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *NotificationsView) Blur() {
+	v.focused = false
+}
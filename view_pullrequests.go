@@ -10,68 +10,253 @@ import (
 
 // PullRequestView displays a list of pull requests
 type PullRequestView struct {
-	data     []PullRequest
-	cursor   int
-	focused  bool
-	err      error
-	loading  bool
-	width    int
-	height   int
+	all         []PullRequest // Everything loaded; data is what the filter lets through
+	data        []PullRequest
+	repo        string   // Repo being shown ("" for the working directory)
+	page        PageInfo // Pagination state of data
+	loadingMore bool     // Next page request in flight
+	cursor      int
+	focused     bool
+	err         error
+	loading     bool
+	width       int
+	height      int
+
+	// Detail of the selected PR, fetched lazily and kept per PR number
+	details       map[int]PullRequestDetail
+	detailErrs    map[int]error
+	detailLoading map[int]bool
+	detailScroll  int
+
+	// Review comments queued until the review is submitted, by reviewKey;
+	// they survive switching to another repo and back
+	pendingReviews map[string]*PendingReview
+
+	selection *Selection // PRs marked for batch actions
+	filter    *ListFilter
+	shown     *PullRequest // Opened from the Search tab; selected until the list is fetched afresh
+
+	// Tab the view is shown in; a custom tab lists its saved query (see tabs.go)
+	tab     ViewType
+	title   string
+	saved   *TabConfig
+	columns []string // Shown after the title of each row
 }
 
 // NewPullRequestView creates a new pull request view
 func NewPullRequestView() *PullRequestView {
 	return &PullRequestView{
-		data:    []PullRequest{},
-		cursor:  0,
-		focused: true,
-		loading: true,
+		data:           []PullRequest{},
+		details:        make(map[int]PullRequestDetail),
+		detailErrs:     make(map[int]error),
+		detailLoading:  make(map[int]bool),
+		pendingReviews: make(map[string]*PendingReview),
+		selection:      NewSelection(),
+		filter:         NewListFilter("author", "state", "is", "base", "head", "review"),
+		tab:            ViewPullRequests,
+		title:          "Pull Requests",
+		columns:        []string{"author", "updated"},
+		cursor:         0,
+		focused:        true,
+		loading:        true,
 	}
 }
 
 // Update handles messages for the pull request view
 func (v *PullRequestView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
+	case repoChangedMsg:
+		// The model fetches the new repo's data; start over until it arrives
+		v.repo = msg.repo
+		v.all = nil
+		v.data = nil
+		v.page = PageInfo{}
+		v.cursor = 0
+		v.err = nil
+		v.loading = true
+		v.loadingMore = false
+		v.details = make(map[int]PullRequestDetail)
+		v.detailErrs = make(map[int]error)
+		v.detailLoading = make(map[int]bool)
+		v.detailScroll = 0
+		v.selection.Clear()
+
 	case prLoadedMsg:
+		if staleRepo(msg.repo, v.repo) {
+			// Asked for before switching repos
+			return v, nil
+		}
 		v.loading = false
+		v.loadingMore = false
 		if msg.err != nil {
-			v.err = msg.err
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
 		} else {
-			v.data = msg.prs
-			if len(v.data) > 0 && v.cursor >= len(v.data) {
-				v.cursor = len(v.data) - 1
+			if msg.appended {
+				v.all = append(v.all, msg.prs...)
+			} else {
+				v.all = msg.prs
+				if msg.cachedAt.IsZero() {
+					// Fresh list: details may be stale, refetch them as PRs are selected
+					v.details = make(map[int]PullRequestDetail)
+					v.detailErrs = make(map[int]error)
+				}
 			}
+			v.page = msg.page
+			v.applyFilter()
+			if v.shown != nil {
+				v.selectShown()
+			}
+		}
+		if !msg.appended && msg.cachedAt.IsZero() {
+			v.shown = nil
+		}
+		return v, v.loadDetail()
+
+	case showPullRequestMsg:
+		v.shown = &msg.pr
+		if !v.loading {
+			v.selectShown()
+		}
+		return v, v.loadDetail()
+
+	case reviewSubmittedMsg:
+		if msg.err == nil && !staleRepo(msg.repo, v.repo) {
+			// Show the new review once the detail is refetched
+			delete(v.details, msg.number)
+			return v, v.loadDetail()
+		}
+
+	case prMergedMsg:
+		if msg.err == nil && !staleRepo(msg.repo, v.repo) {
+			for i := range v.all {
+				if v.all[i].Number == msg.number {
+					v.all[i].State = "MERGED"
+				}
+			}
+			v.applyFilter()
+			delete(v.details, msg.number)
+			return v, v.loadDetail()
+		}
+
+	case batchProgressMsg:
+		// Keep what failed marked for another try
+		reselectFailures(v.selection, msg)
+
+	case prDetailLoadedMsg:
+		if staleRepo(msg.repo, v.repo) {
+			// The same number in the repo before a switch; the new repo's
+			// detail may still be loading
+			return v, nil
+		}
+		delete(v.detailLoading, msg.number)
+		if msg.err != nil {
+			v.detailErrs[msg.number] = msg.err
+		} else {
+			delete(v.detailErrs, msg.number)
+			v.details[msg.number] = msg.detail
 		}
 
 	case tea.KeyMsg:
 		if !v.focused {
 			return v, nil
 		}
+		if v.filter.Editing() {
+			cmd := v.filter.HandleKey(msg)
+			v.applyFilter()
+			v.detailScroll = 0
+			return v, tea.Batch(cmd, v.loadDetail())
+		}
 
 		switch msg.String() {
+		case "/":
+			return v, v.filter.Start()
 		case "up", "k":
 			if v.cursor > 0 {
 				v.cursor--
+				v.detailScroll = 0
 			}
+			return v, v.loadDetail()
 		case "down", "j":
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				v.detailScroll = 0
+			}
+			return v, tea.Batch(v.loadMore(), v.loadDetail())
+		case "K":
+			// Scroll the detail pane
+			if v.detailScroll > 0 {
+				v.detailScroll--
 			}
+		case "J":
+			v.detailScroll++
 		case "r":
 			v.loading = true
 			v.err = nil
-			return v, fetchPullRequests("")
+			return v, v.fetchPage("")
 		case "b":
 			// Open PR in browser
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				pr := v.data[v.cursor]
-				return v, openInBrowser("pr", fmt.Sprintf("%d", pr.Number), "")
+				return v, openInBrowser("pr", fmt.Sprintf("%d", pr.Number), v.repo)
 			}
 		case "d":
-			// View PR diff
+			// Open the diff viewer
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				pr := v.data[v.cursor]
-				return v, viewPRDiff(fmt.Sprintf("%d", pr.Number))
+				return v, openDiffView(v.repo, pr, v.pendingReview(pr.Number))
+			}
+		case "c":
+			// Check out into a worktree beside the local clone
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				return v, openCheckoutDialog(v.repo, v.data[v.cursor])
+			}
+		case "m":
+			// Merge, after choosing a method and checking the PR can be merged
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				return v, openMergeDialog(v.repo, v.data[v.cursor].Number)
+			}
+		case "C":
+			// Comment on the PR's conversation
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				number := v.data[v.cursor].Number
+				return v, openCommentComposer(v.repo, number, fmt.Sprintf("PR #%d", number))
+			}
+		case "V":
+			// Start or end a visual range
+			if len(v.data) > 0 {
+				v.selection.ToggleVisual(v.cursor, v.prKey)
+			}
+		case "ctrl+a":
+			v.selection.SelectAll(len(v.data), v.prKey)
+		case "esc":
+			// Clear the selection first, then the filter
+			if v.selection.Active() {
+				v.selection.Clear()
+			} else if v.filter.Active() {
+				v.filter.Clear()
+				v.applyFilter()
+				return v, v.loadDetail()
+			}
+		case "l":
+			// Add labels to the marked PRs, or the PR under the cursor
+			var numbers []int
+			for _, i := range v.selection.Indexes(len(v.data), v.cursor, v.prKey) {
+				numbers = append(numbers, v.data[i].Number)
+			}
+			if len(numbers) == 0 && len(v.data) > 0 && v.cursor < len(v.data) {
+				numbers = []int{v.data[v.cursor].Number}
+			}
+			if len(numbers) > 0 {
+				return v, openBatchLabelDialog(v.tab, v.repo, numbers, "pull requests")
+			}
+		case "R":
+			// Submit a review (with any comments queued from the diff view)
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				number := v.data[v.cursor].Number
+				return v, openOverlay(NewReviewDialog(v.repo, number, v.pendingReview(number)))
 			}
 		}
 
@@ -84,11 +269,15 @@ func (v *PullRequestView) Update(msg tea.Msg) (View, tea.Cmd) {
 		case tea.MouseWheelUp:
 			if v.cursor > 0 {
 				v.cursor--
+				v.detailScroll = 0
 			}
+			return v, v.loadDetail()
 		case tea.MouseWheelDown:
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
+				v.detailScroll = 0
 			}
+			return v, tea.Batch(v.loadMore(), v.loadDetail())
 		}
 	}
 
@@ -112,7 +301,7 @@ func (v *PullRequestView) View(width, height int) string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", v.err)))
 	}
 
-	if len(v.data) == 0 {
+	if len(v.all) == 0 && !v.filter.Editing() {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
 			dimmedStyle.Render("No pull requests found"))
@@ -138,9 +327,16 @@ func (v *PullRequestView) renderList(width, height int) string {
 	var lines []string
 
 	// Header
-	title := listTitleStyle.Render(fmt.Sprintf(" Pull Requests (%d)", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" %s (%s)", v.title, formatListCount(len(v.data), v.page)) +
+		v.selection.Status(len(v.data), v.cursor, v.prKey) + v.filter.Title())
+	if v.loadingMore {
+		title += dimmedStyle.Render(" loading more...")
+	}
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
+	if len(v.data) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 3 // Account for title and padding
@@ -166,17 +362,19 @@ func (v *PullRequestView) renderList(width, height int) string {
 		}
 
 		// Format: "▶ #123 Title - author • 2h ago"
-		line := fmt.Sprintf("%s#%d %s",
+		mark := v.selection.Mark(v.prKey(i), i, v.cursor)
+		line := fmt.Sprintf("%s%s#%d %s",
 			cursor,
+			mark,
 			pr.Number,
-			truncateString(pr.Title, width-20))
+			truncateString(pr.Title, width-20-lipgloss.Width(mark)))
 
-		meta := fmt.Sprintf("%s • %s",
-			pr.Author.Login,
-			formatTimeAgo(pr.UpdatedAt))
+		meta := prColumns(pr, v.columns)
 
 		if len(line)+len(meta)+3 < width {
-			line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+			line = v.filter.Highlight(padRight(line, width-len(meta)-3), style) + dimmedStyle.Render(meta)
+		} else {
+			line = v.filter.Highlight(line, style)
 		}
 
 		lines = append(lines, style.Render(line))
@@ -225,15 +423,40 @@ func (v *PullRequestView) renderDetail(width, height int) string {
 		lines = append(lines, fmt.Sprintf("Mergeable: %s", pr.Mergeable))
 	}
 
+	if pending := v.pendingReviews[reviewKey(v.repo, pr.Number)]; pending != nil && len(pending.Comments) > 0 {
+		lines = append(lines, fmt.Sprintf("Pending:   %s", statusPendingStyle.Render(
+			fmt.Sprintf("%d review comments (R to submit)", len(pending.Comments)))))
+	}
+
+	// Lazily loaded detail
+	if detail, ok := v.details[pr.Number]; ok {
+		lines = append(lines, v.renderDetailSections(detail, width-4)...)
+	} else if err, ok := v.detailErrs[pr.Number]; ok {
+		lines = append(lines, "")
+		lines = append(lines, statusFailureStyle.Render(truncateString(fmt.Sprintf("Failed to load details: %v", err), width-4)))
+	} else {
+		lines = append(lines, "")
+		lines = append(lines, dimmedStyle.Render("Loading details..."))
+	}
+
 	lines = append(lines, "")
 	// Truncate URL to prevent wrapping in narrow detail pane
 	displayURL := truncateString(pr.URL, width-10)
 	clickableURL := makeHyperlink(pr.URL, displayURL)
 	lines = append(lines, dimmedStyle.Render(fmt.Sprintf("URL: %s", clickableURL)))
 
-	// Keyboard hints
-	lines = append(lines, "")
-	lines = append(lines, helpStyle.Render("↑/↓: Navigate • b: Browser • d: Diff • r: Refresh • q: Quit"))
+	// Scroll the body, keeping the keyboard hints in view
+	hints := helpStyle.Width(width - 4).Render("↑/↓: Navigate • J/K: Scroll • b: Browser • d: Diff • c: Checkout • C: Comment • R: Review • m: Merge • l: Label • Space/V/Ctrl+A: Select • r: Refresh")
+	visible := height - 3 - lipgloss.Height(hints) // padding and hints
+	if visible < 1 {
+		visible = 1
+	}
+	maxScroll := max(0, len(lines)-visible)
+	if v.detailScroll > maxScroll {
+		v.detailScroll = maxScroll
+	}
+	lines = lines[v.detailScroll:min(len(lines), v.detailScroll+visible)]
+	lines = append(lines, "", hints)
 
 	content := strings.Join(lines, "\n")
 	return lipgloss.NewStyle().
@@ -243,6 +466,234 @@ func (v *PullRequestView) renderDetail(width, height int) string {
 		Render(content)
 }
 
+// renderDetailSections renders the lazily loaded parts of a PR's detail
+func (v *PullRequestView) renderDetailSections(detail PullRequestDetail, width int) []string {
+	var lines []string
+
+	lines = append(lines, fmt.Sprintf("Changes:   %s %s in %d files",
+		statusSuccessStyle.Render(fmt.Sprintf("+%d", detail.Additions)),
+		statusFailureStyle.Render(fmt.Sprintf("-%d", detail.Deletions)),
+		detail.ChangedFiles))
+
+	// Reviewers
+	if len(detail.Reviews) > 0 || len(detail.ReviewRequests) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, detailTitleStyle.Render("Reviewers"))
+		for _, review := range detail.Reviews {
+			lines = append(lines, fmt.Sprintf("  %-16s %s", truncateString(review.Author.Login, 16), formatReviewState(review.State)))
+		}
+		for _, reviewer := range detail.ReviewRequests {
+			lines = append(lines, fmt.Sprintf("  %-16s %s", truncateString(reviewer, 16), dimmedStyle.Render("requested")))
+		}
+	}
+
+	// Checks
+	if len(detail.Checks) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, detailTitleStyle.Render("Checks ")+formatCheckState(detail.ChecksState))
+		for _, check := range detail.Checks {
+			lines = append(lines, fmt.Sprintf("  %s %s %s",
+				formatCheckState(check.State),
+				truncateString(check.Name, width-20),
+				dimmedStyle.Render(strings.ToLower(check.State))))
+		}
+	}
+
+	// Body
+	lines = append(lines, "")
+	lines = append(lines, detailTitleStyle.Render("Description"))
+	if strings.TrimSpace(detail.Body) == "" {
+		lines = append(lines, dimmedStyle.Render("No description provided."))
+	} else {
+		lines = append(lines, renderMarkdown(detail.Body, width)...)
+	}
+
+	// Files changed
+	if len(detail.Files) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, detailTitleStyle.Render(fmt.Sprintf("Files changed (%d)", detail.ChangedFiles)))
+		for _, file := range detail.Files {
+			stats := fmt.Sprintf("+%d -%d", file.Additions, file.Deletions)
+			lines = append(lines, fmt.Sprintf("  %s %s",
+				padRight(truncateString(file.Path, width-len(stats)-4), width-len(stats)-3),
+				dimmedStyle.Render(stats)))
+		}
+		if len(detail.Files) < detail.ChangedFiles {
+			lines = append(lines, dimmedStyle.Render(fmt.Sprintf("  ... and %d more", detail.ChangedFiles-len(detail.Files))))
+		}
+	}
+
+	return lines
+}
+
+// applyFilter narrows the loaded PRs to those matching the filter,
+// keeping the cursor on the same PR while it still matches
+func (v *PullRequestView) applyFilter() {
+	current := -1
+	if v.cursor < len(v.data) {
+		current = v.data[v.cursor].Number
+	}
+	data := []PullRequest{}
+	cursor := -1
+	for _, pr := range v.all {
+		if !v.filter.Match(prFilterItem(pr)) {
+			continue
+		}
+		if pr.Number == current {
+			cursor = len(data)
+		}
+		data = append(data, pr)
+	}
+	v.data = data
+	if cursor >= 0 {
+		v.cursor = cursor
+	} else {
+		v.cursor = max(0, min(v.cursor, len(v.data)-1))
+	}
+}
+
+// selectShown puts the cursor on the PR opened from the Search tab, adding
+// it to the list if it isn't loaded and clearing a filter that hides it
+func (v *PullRequestView) selectShown() {
+	index := func() int {
+		for i, pr := range v.data {
+			if pr.Number == v.shown.Number {
+				return i
+			}
+		}
+		return -1
+	}
+	found := false
+	for _, pr := range v.all {
+		if pr.Number == v.shown.Number {
+			found = true
+			break
+		}
+	}
+	if !found {
+		v.all = append([]PullRequest{*v.shown}, v.all...)
+	}
+	v.applyFilter()
+	if index() < 0 {
+		v.filter.Clear()
+		v.applyFilter()
+	}
+	v.cursor = max(0, index())
+	v.detailScroll = 0
+}
+
+// prColumns renders the columns of a PR's row, e.g. "octocat • 2h ago"
+func prColumns(pr PullRequest, columns []string) string {
+	var values []string
+	for _, column := range columns {
+		value := ""
+		switch column {
+		case "author":
+			value = pr.Author.Login
+		case "updated":
+			value = formatTimeAgo(pr.UpdatedAt)
+		case "created":
+			value = formatTimeAgo(pr.CreatedAt)
+		case "branch":
+			value = pr.HeadRefName
+		case "base":
+			value = pr.BaseRefName
+		case "review":
+			value = strings.ToLower(strings.ReplaceAll(pr.ReviewDecision, "_", " "))
+		case "state":
+			value = strings.ToLower(pr.State)
+			if pr.IsDraft {
+				value = "draft"
+			}
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " • ")
+}
+
+// prFilterItem is what the filter matches a PR on
+func prFilterItem(pr PullRequest) filterItem {
+	is := []string{pr.State, "pr"}
+	if pr.IsDraft {
+		is = append(is, "draft")
+	}
+	return filterItem{
+		fields: map[string][]string{
+			"author": {pr.Author.Login},
+			"state":  {pr.State},
+			"is":     is,
+			"base":   {pr.BaseRefName},
+			"head":   {pr.HeadRefName},
+			"review": {pr.ReviewDecision},
+		},
+		text: []string{pr.Title, fmt.Sprintf("#%d", pr.Number), pr.HeadRefName},
+	}
+}
+
+// Filtering reports whether the filter prompt is open
+func (v *PullRequestView) Filtering() bool {
+	return v.filter.Editing()
+}
+
+// prKey is the selection key of the PR at index i
+func (v *PullRequestView) prKey(i int) string {
+	return fmt.Sprint(v.data[i].Number)
+}
+
+// ToggleSelection marks or unmarks the PR under the cursor
+func (v *PullRequestView) ToggleSelection() {
+	if v.cursor < len(v.data) {
+		v.selection.Toggle(v.prKey(v.cursor))
+	}
+}
+
+// loadDetail fetches the selected PR's detail unless it is cached or in flight
+func (v *PullRequestView) loadDetail() tea.Cmd {
+	if v.cursor < 0 || v.cursor >= len(v.data) {
+		return nil
+	}
+	number := v.data[v.cursor].Number
+	if _, ok := v.details[number]; ok || v.detailLoading[number] {
+		return nil
+	}
+	v.detailLoading[number] = true
+	return fetchPRDetail(v.repo, number)
+}
+
+// pendingReview returns the queued review comments of PR number
+func (v *PullRequestView) pendingReview(number int) *PendingReview {
+	key := reviewKey(v.repo, number)
+	if v.pendingReviews[key] == nil {
+		v.pendingReviews[key] = &PendingReview{}
+	}
+	return v.pendingReviews[key]
+}
+
+// reviewKey identifies PR number of repo among the pending reviews
+func reviewKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+// fetchPage fetches the page of PRs after cursor, of the tab's saved
+// query if it has one
+func (v *PullRequestView) fetchPage(cursor string) tea.Cmd {
+	if v.saved != nil {
+		return fetchTabPage(v.tab, *v.saved, v.repo, cursor)
+	}
+	return fetchPullRequestsPage(v.repo, cursor)
+}
+
+// loadMore requests the next page when the cursor nears the end of the list
+func (v *PullRequestView) loadMore() tea.Cmd {
+	if !needsNextPage(v.cursor, len(v.data), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return v.fetchPage(v.page.EndCursor)
+}
+
 // Focus sets the view as focused
 func (v *PullRequestView) Focus() {
 	v.focused = true
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPullRequestViewLoadsDetail(t *testing.T) {
+	newTestClient(t)
+
+	v := NewPullRequestView()
+	v.Focus()
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	_, cmd := v.Update(runCmd(fetchPullRequests(fixtureRepo)))
+	if len(v.data) != 3 || v.data[0].Number != 42 {
+		t.Fatalf("loaded %d PRs", len(v.data))
+	}
+
+	// The list asks for the detail of the PR under the cursor
+	msg, ok := runCmd(cmd).(prDetailLoadedMsg)
+	if !ok || msg.number != 42 || msg.repo != fixtureRepo {
+		t.Fatalf("detail: %#v", msg)
+	}
+	v.Update(msg)
+	if _, ok := v.details[42]; !ok || v.detailLoading[42] {
+		t.Error("detail of #42 not kept")
+	}
+}
+
+func TestPullRequestViewDropsStaleRepo(t *testing.T) {
+	newTestClient(t)
+
+	v := NewPullRequestView()
+	v.Update(repoChangedMsg{repo: "octo-org/other"})
+	v.Update(runCmd(fetchPullRequests(fixtureRepo)))
+	if len(v.all) != 0 || !v.loading {
+		t.Errorf("kept %d PRs loaded for %s", len(v.all), fixtureRepo)
+	}
+	v.Update(runCmd(fetchPRDetail(fixtureRepo, 42)))
+	if _, ok := v.details[42]; ok {
+		t.Errorf("kept the detail of #42 in %s", fixtureRepo)
+	}
+
+	// A view that never switched shows whatever the working directory is
+	v = NewPullRequestView()
+	v.Update(runCmd(fetchPullRequests("")))
+	if len(v.all) != 3 {
+		t.Errorf("loaded %d PRs of the working directory's repo", len(v.all))
+	}
+}
+
+func TestPullRequestViewKeepsListOnFailedRefresh(t *testing.T) {
+	c := newTestClient(t)
+
+	v := NewPullRequestView()
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	v.Update(runCmd(fetchPullRequests(fixtureRepo)))
+
+	c.Err = errors.New("rate limited")
+	v.Update(runCmd(fetchPullRequests(fixtureRepo)))
+	if len(v.data) != 3 || v.err != nil {
+		t.Errorf("after a failed refresh: %d PRs, err %v", len(v.data), v.err)
+	}
+}
+
+func TestPullRequestViewKeepsPendingReviewsAcrossRepos(t *testing.T) {
+	newTestClient(t)
+
+	v := NewPullRequestView()
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	v.pendingReview(42).Comments = append(v.pendingReview(42).Comments, ReviewComment{Path: "main.go", Line: 1, Body: "nit"})
+
+	// #42 of another repo has a review of its own
+	v.Update(repoChangedMsg{repo: "octo-org/other"})
+	if n := len(v.pendingReview(42).Comments); n != 0 {
+		t.Errorf("octo-org/other#42 has %d pending comments", n)
+	}
+
+	v.Update(repoChangedMsg{repo: fixtureRepo})
+	if n := len(v.pendingReview(42).Comments); n != 1 {
+		t.Errorf("%s#42 has %d pending comments after switching back", fixtureRepo, n)
+	}
+}
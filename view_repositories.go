@@ -10,15 +10,21 @@ import (
 
 // RepositoryView displays a list of repositories
 type RepositoryView struct {
-	data       []Repository
-	cursor     int
-	focused    bool
-	err        error
-	loading    bool
-	width      int
-	height     int
-	viewMode   ViewMode    // List or Table view
-	tableState *TableState // Table state for sorting
+	all         []Repository // Everything loaded; data is what the filter lets through
+	data        []Repository
+	page        PageInfo // Pagination state of data
+	loadingMore bool     // Next page request in flight
+	cursor      int
+	focused     bool
+	err         error
+	loading     bool
+	width       int
+	height      int
+	viewMode    ViewMode    // List or Table view
+	tableState  *TableState // Table state for sorting
+	selection   *Selection  // Repositories marked for batch actions
+	filter      *ListFilter
+	shown       *Repository // Opened from the Search tab; selected until the list is fetched afresh
 }
 
 // NewRepositoryView creates a new repository view
@@ -41,6 +47,8 @@ func NewRepositoryView() *RepositoryView {
 		loading:    true,
 		viewMode:   ViewModeList, // Default to list view
 		tableState: NewTableState(columns),
+		selection:  NewSelection(),
+		filter:     NewListFilter("owner", "language", "visibility", "is"),
 	}
 }
 
@@ -49,21 +57,51 @@ func (v *RepositoryView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case reposLoadedMsg:
 		v.loading = false
+		v.loadingMore = false
 		if msg.err != nil {
-			v.err = msg.err
+			// A failed refresh or next page keeps what's already loaded
+			if !msg.appended && len(v.all) == 0 {
+				v.err = msg.err
+			}
 		} else {
-			v.data = msg.repos
-			if len(v.data) > 0 && v.cursor >= len(v.data) {
-				v.cursor = len(v.data) - 1
+			if msg.appended {
+				v.all = append(v.all, msg.repos...)
+			} else {
+				v.all = msg.repos
 			}
+			v.page = msg.page
+			v.applyFilter()
+			if v.shown != nil {
+				v.selectShown()
+			}
+		}
+		if !msg.appended && msg.cachedAt.IsZero() {
+			v.shown = nil
 		}
 
+	case showRepoMsg:
+		v.shown = &msg.repo
+		if !v.loading {
+			v.selectShown()
+		}
+
+	case batchProgressMsg:
+		// Keep what failed marked for another try
+		reselectFailures(v.selection, msg)
+
 	case tea.KeyMsg:
 		if !v.focused {
 			return v, nil
 		}
+		if v.filter.Editing() {
+			cmd := v.filter.HandleKey(msg)
+			v.applyFilter()
+			return v, cmd
+		}
 
 		switch msg.String() {
+		case "/":
+			return v, v.filter.Start()
 		case "up", "k":
 			if v.cursor > 0 {
 				v.cursor--
@@ -72,24 +110,59 @@ func (v *RepositoryView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
 			}
+			return v, v.loadMore()
 		case "r":
 			v.loading = true
 			v.err = nil
 			return v, fetchRepositories("")
+		case "enter":
+			// Scope the PR, Issues and Actions tabs to this repo
+			if len(v.data) > 0 && v.cursor < len(v.data) {
+				return v, switchRepo(v.data[v.cursor].NameWithOwner)
+			}
 		case "b":
 			// Open repo in browser
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				repo := v.data[v.cursor]
 				return v, openInBrowser("repo", repo.NameWithOwner, "")
 			}
+		case "V":
+			// Start or end a visual range
+			if len(v.data) > 0 {
+				v.selection.ToggleVisual(v.cursor, v.repoKey)
+			}
+		case "ctrl+a":
+			v.selection.SelectAll(len(v.data), v.repoKey)
+		case "esc":
+			// Clear the selection first, then the filter
+			if v.selection.Active() {
+				v.selection.Clear()
+			} else if v.filter.Active() {
+				v.filter.Clear()
+				v.applyFilter()
+			}
 		case "s":
-			// Star/unstar repository
+			// Star the marked repositories, or star/unstar the one under the cursor
+			if v.selection.Active() {
+				return v, runBatch(ViewRepositories, "Starring", "Starred", "repositories",
+					repoBatch(v.selectedRepos(), ghClient.StarRepo))
+			}
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				repo := v.data[v.cursor]
 				return v, toggleRepoStar(repo.NameWithOwner)
 			}
+		case "S":
+			// Unstar the marked repositories
+			if v.selection.Active() {
+				return v, runBatch(ViewRepositories, "Unstarring", "Unstarred", "repositories",
+					repoBatch(v.selectedRepos(), ghClient.UnstarRepo))
+			}
 		case "c":
-			// Clone repository
+			// Clone the marked repositories, or the one under the cursor
+			if v.selection.Active() {
+				return v, runBatch(ViewRepositories, "Cloning", "Cloned", "repositories",
+					repoBatch(v.selectedRepos(), ghClient.CloneRepository))
+			}
 			if len(v.data) > 0 && v.cursor < len(v.data) {
 				repo := v.data[v.cursor]
 				return v, cloneRepository(repo.NameWithOwner)
@@ -148,6 +221,7 @@ func (v *RepositoryView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.cursor < len(v.data)-1 {
 				v.cursor++
 			}
+			return v, v.loadMore()
 		}
 	}
 
@@ -171,7 +245,7 @@ func (v *RepositoryView) View(width, height int) string {
 			errorStyle.Render(fmt.Sprintf("Error: %v", v.err)))
 	}
 
-	if len(v.data) == 0 {
+	if len(v.all) == 0 && !v.filter.Editing() {
 		return lipgloss.Place(width, height,
 			lipgloss.Center, lipgloss.Center,
 			dimmedStyle.Render("No repositories found"))
@@ -203,9 +277,16 @@ func (v *RepositoryView) renderList(width, height int) string {
 	var lines []string
 
 	// Header
-	title := listTitleStyle.Render(fmt.Sprintf(" Repositories (%d)", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" Repositories (%s)", formatListCount(len(v.data), v.page)) +
+		v.selection.Status(len(v.data), v.cursor, v.repoKey) + v.filter.Title())
+	if v.loadingMore {
+		title += dimmedStyle.Render(" loading more...")
+	}
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
+	if len(v.data) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 3
@@ -231,16 +312,20 @@ func (v *RepositoryView) renderList(width, height int) string {
 		}
 
 		// Format: "▶ name - ⭐123 • language"
-		line := fmt.Sprintf("%s%s",
+		mark := v.selection.Mark(v.repoKey(i), i, v.cursor)
+		line := fmt.Sprintf("%s%s%s",
 			cursor,
-			truncateString(repo.NameWithOwner, width-25))
+			mark,
+			truncateString(repo.NameWithOwner, width-25-lipgloss.Width(mark)))
 
 		meta := fmt.Sprintf("⭐ %s • %s",
 			formatNumber(repo.StargazerCount),
 			formatLanguage(repo.PrimaryLanguage))
 
 		if len(line)+len(meta)+3 < width {
-			line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+			line = v.filter.Highlight(padRight(line, width-len(meta)-3), style) + dimmedStyle.Render(meta)
+		} else {
+			line = v.filter.Highlight(line, style)
 		}
 
 		lines = append(lines, style.Render(line))
@@ -294,7 +379,7 @@ func (v *RepositoryView) renderDetail(width, height int) string {
 
 	// Keyboard hints
 	lines = append(lines, "")
-	lines = append(lines, helpStyle.Render("↑/↓: Navigate • b: Browser • s: Star • c: Clone • f: Fork • v: View • r: Refresh"))
+	lines = append(lines, helpStyle.Render("↑/↓: Navigate • Enter: Use repo • b: Browser • s/S: Star/Unstar • c: Clone • f: Fork • v: View • Space/V/Ctrl+A: Select • r: Refresh"))
 
 	content := strings.Join(lines, "\n")
 	return lipgloss.NewStyle().
@@ -304,6 +389,113 @@ func (v *RepositoryView) renderDetail(width, height int) string {
 		Render(content)
 }
 
+// loadMore requests the next page when the cursor nears the end of the list
+func (v *RepositoryView) loadMore() tea.Cmd {
+	if !needsNextPage(v.cursor, len(v.data), v.page, v.loadingMore) {
+		return nil
+	}
+	v.loadingMore = true
+	return fetchRepositoriesPage("", v.page.EndCursor)
+}
+
+// applyFilter narrows the loaded repositories to those matching the filter,
+// keeping the cursor on the same repository while it still matches
+func (v *RepositoryView) applyFilter() {
+	current := ""
+	if v.cursor < len(v.data) {
+		current = v.data[v.cursor].NameWithOwner
+	}
+	data := []Repository{}
+	cursor := -1
+	for _, repo := range v.all {
+		if !v.filter.Match(repoFilterItem(repo)) {
+			continue
+		}
+		if repo.NameWithOwner == current {
+			cursor = len(data)
+		}
+		data = append(data, repo)
+	}
+	v.data = data
+	if cursor >= 0 {
+		v.cursor = cursor
+	} else {
+		v.cursor = max(0, min(v.cursor, len(v.data)-1))
+	}
+}
+
+// selectShown puts the cursor on the repository opened from the Search tab,
+// adding it to the list if it isn't loaded and clearing a filter that hides it
+func (v *RepositoryView) selectShown() {
+	index := func() int {
+		for i, repo := range v.data {
+			if repo.NameWithOwner == v.shown.NameWithOwner {
+				return i
+			}
+		}
+		return -1
+	}
+	found := false
+	for _, repo := range v.all {
+		if repo.NameWithOwner == v.shown.NameWithOwner {
+			found = true
+			break
+		}
+	}
+	if !found {
+		v.all = append([]Repository{*v.shown}, v.all...)
+	}
+	v.applyFilter()
+	if index() < 0 {
+		v.filter.Clear()
+		v.applyFilter()
+	}
+	v.cursor = max(0, index())
+}
+
+// repoFilterItem is what the filter matches a repository on
+func repoFilterItem(repo Repository) filterItem {
+	owner, _, _ := strings.Cut(repo.NameWithOwner, "/")
+	item := filterItem{
+		fields: map[string][]string{
+			"owner":      {owner},
+			"visibility": {repo.Visibility},
+			"is":         {repo.Visibility},
+		},
+		text: []string{repo.NameWithOwner, repo.Description},
+	}
+	if repo.PrimaryLanguage != nil {
+		item.fields["language"] = []string{repo.PrimaryLanguage.Name}
+	}
+	return item
+}
+
+// Filtering reports whether the filter prompt is open
+func (v *RepositoryView) Filtering() bool {
+	return v.filter.Editing()
+}
+
+// repoKey is the selection key of the repository at index i
+func (v *RepositoryView) repoKey(i int) string {
+	return v.data[i].NameWithOwner
+}
+
+// ToggleSelection marks or unmarks the repository under the cursor
+func (v *RepositoryView) ToggleSelection() {
+	if v.cursor < len(v.data) {
+		v.selection.Toggle(v.repoKey(v.cursor))
+	}
+}
+
+// selectedRepos returns the names of the marked repositories in list order
+func (v *RepositoryView) selectedRepos() []string {
+	var repos []string
+	for _, i := range v.selection.Indexes(len(v.data), v.cursor, v.repoKey) {
+		repos = append(repos, v.data[i].NameWithOwner)
+	}
+	return repos
+}
+
 // Focus sets the view as focused
 func (v *RepositoryView) Focus() {
 	v.focused = true
@@ -319,11 +511,12 @@ func (v *RepositoryView) renderTable(width, height int) string {
 	var lines []string
 
 	// Title with view mode indicator
-	title := listTitleStyle.Render(fmt.Sprintf(" Repositories (%d) - Table View", len(v.data)))
+	title := listTitleStyle.Render(fmt.Sprintf(" Repositories (%s) - Table View", formatListCount(len(v.data), v.page)) +
+		v.selection.Status(len(v.data), v.cursor, v.repoKey) + v.filter.Title())
 	viewToggle := dimmedStyle.Render(" [v] Switch to List")
 	titleLine := title + "  " + viewToggle
 	lines = append(lines, titleLine)
-	lines = append(lines, "")
+	lines = append(lines, v.filter.Line(width))
 
 	// Sort data if needed
 	sortedData := make([]Repository, len(v.data))
@@ -334,6 +527,9 @@ func (v *RepositoryView) renderTable(width, height int) string {
 	header := v.tableState.RenderHeader(width)
 	lines = append(lines, header)
 	lines = append(lines, "")
+	if len(sortedData) == 0 {
+		lines = append(lines, dimmedStyle.Render("  No matches"))
+	}
 
 	// Calculate visible range
 	maxVisible := height - 5 // Account for title, header, and padding
@@ -352,8 +548,9 @@ func (v *RepositoryView) renderTable(width, height int) string {
 		repo := sortedData[i]
 
 		// Build row cells
+		mark := v.selection.Mark(repo.NameWithOwner, i, v.cursor)
 		cells := []string{
-			truncateString(repo.NameWithOwner, 30),
+			mark + truncateString(repo.NameWithOwner, 30-lipgloss.Width(mark)),
 			formatNumber(repo.StargazerCount),
 			formatNumber(repo.ForkCount),
 			formatLanguage(repo.PrimaryLanguage),
@@ -368,7 +565,7 @@ func (v *RepositoryView) renderTable(width, height int) string {
 
 	// Add keyboard hints
 	lines = append(lines, "")
-	hints := helpStyle.Render("↑/↓: Navigate • b: Browser • s: Star • c: Clone • f: Fork • v: Toggle View • r: Refresh")
+	hints := helpStyle.Render("↑/↓: Navigate • Enter: Use repo • b: Browser • s/S: Star/Unstar • c: Clone • f: Fork • v: Toggle View • Space/V/Ctrl+A: Select • r: Refresh")
 	lines = append(lines, hints)
 
 	content := strings.Join(lines, "\n")
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reviewEvents are the ways a review can be submitted, in dialog order
+var reviewEvents = []struct {
+	event string
+	label string
+}{
+	{"COMMENT", "Comment"},
+	{"APPROVE", "Approve"},
+	{"REQUEST_CHANGES", "Request changes"},
+}
+
+// reviewEventLabel returns the dialog label of a review event
+func reviewEventLabel(event string) string {
+	for _, choice := range reviewEvents {
+		if choice.event == event {
+			return choice.label
+		}
+	}
+	return event
+}
+
+// ReviewDialog submits a PR review with its pending comments
+type ReviewDialog struct {
+	repo       string
+	number     int
+	pending    *PendingReview
+	event      int // Index into reviewEvents
+	body       textarea.Model
+	focused    bool
+	submitting bool
+	err        error
+}
+
+// NewReviewDialog creates the review dialog for PR number
+func NewReviewDialog(repo string, number int, pending *PendingReview) *ReviewDialog {
+	body := textarea.New()
+	body.Placeholder = "Review summary (optional for approvals and line comments)"
+	body.ShowLineNumbers = false
+	body.SetHeight(5)
+
+	return &ReviewDialog{
+		repo:    repo,
+		number:  number,
+		pending: pending,
+		body:    body,
+	}
+}
+
+// Update handles messages for the review dialog
+func (d *ReviewDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reviewSubmittedMsg:
+		if msg.number != d.number || staleRepo(msg.repo, d.repo) || !d.submitting {
+			return d, nil
+		}
+		d.submitting = false
+		if msg.err != nil {
+			d.err = msg.err
+			return d, nil
+		}
+		d.pending.Comments = nil
+		return d, closeOverlay()
+
+	case tea.KeyMsg:
+		if !d.focused || d.submitting {
+			return d, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			return d, closeOverlay()
+		case "ctrl+s":
+			return d, d.submit()
+		case "tab":
+			d.event = (d.event + 1) % len(reviewEvents)
+			return d, nil
+		case "shift+tab":
+			d.event = (d.event + len(reviewEvents) - 1) % len(reviewEvents)
+			return d, nil
+		}
+	}
+
+	body, cmd := d.body.Update(msg)
+	d.body = body
+	return d, cmd
+}
+
+// submit validates the review and sends it
+func (d *ReviewDialog) submit() tea.Cmd {
+	review := ReviewSubmission{
+		Event:    reviewEvents[d.event].event,
+		Body:     strings.TrimSpace(d.body.Value()),
+		Comments: d.pending.Comments,
+	}
+
+	// GitHub rejects these without a body
+	switch {
+	case review.Event == "REQUEST_CHANGES" && review.Body == "":
+		d.err = fmt.Errorf("requesting changes needs a summary")
+		return nil
+	case review.Event == "COMMENT" && review.Body == "" && len(review.Comments) == 0:
+		d.err = fmt.Errorf("a comment review needs a summary or line comments")
+		return nil
+	}
+
+	d.err = nil
+	d.submitting = true
+	return submitPRReview(d.repo, d.number, review)
+}
+
+// View renders the dialog centered in the content area
+func (d *ReviewDialog) View(width, height int) string {
+	boxWidth := min(72, width-4)
+	d.body.SetWidth(boxWidth - 6)
+
+	var lines []string
+	lines = append(lines, dialogTitleStyle.Width(boxWidth-6).Render(fmt.Sprintf("Submit review for #%d", d.number)))
+	lines = append(lines, "")
+
+	// Queued line comments
+	if len(d.pending.Comments) == 0 {
+		lines = append(lines, dimmedStyle.Render("No line comments (add them from the diff view with c)"))
+	} else {
+		lines = append(lines, detailTitleStyle.Render(fmt.Sprintf("%d pending comments", len(d.pending.Comments))))
+		for i, comment := range d.pending.Comments {
+			if i == 5 {
+				lines = append(lines, dimmedStyle.Render(fmt.Sprintf("  ... and %d more", len(d.pending.Comments)-i)))
+				break
+			}
+			where := fmt.Sprintf("%s:%d", comment.Path, comment.Line)
+			if comment.StartLine > 0 {
+				where = fmt.Sprintf("%s:%d-%d", comment.Path, comment.StartLine, comment.Line)
+			}
+			firstLine := strings.SplitN(comment.Body, "\n", 2)[0]
+			lines = append(lines, "  "+highlightStyle.Render(where)+" "+
+				dimmedStyle.Render(truncateString(firstLine, max(0, boxWidth-lipgloss.Width(where)-10))))
+		}
+	}
+	lines = append(lines, "")
+
+	// Event choice
+	var buttons []string
+	for i, choice := range reviewEvents {
+		if i == d.event {
+			buttons = append(buttons, buttonActiveStyle.Render(choice.label))
+		} else {
+			buttons = append(buttons, buttonStyle.Render(choice.label))
+		}
+	}
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, buttons...))
+	lines = append(lines, "")
+	lines = append(lines, d.body.View())
+	lines = append(lines, "")
+
+	switch {
+	case d.submitting:
+		lines = append(lines, infoStyle.Padding(0).Render("Submitting review..."))
+	case d.err != nil:
+		lines = append(lines, statusFailureStyle.Render(truncateString("Error: "+d.err.Error(), boxWidth-6)))
+	}
+	lines = append(lines, helpStyle.Render("Tab: Change type • Ctrl+S: Submit • Esc: Cancel"))
+
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Focus sets the dialog as focused
+func (d *ReviewDialog) Focus() {
+	d.focused = true
+	d.body.Focus()
+}
+
+// Blur sets the dialog as unfocused
+func (d *ReviewDialog) Blur() {
+	d.focused = false
+	d.body.Blur()
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// view_runaction.go - Re-run and Cancel Dialog
+// Purpose: Confirm a re-run (all or failed jobs, optionally with debug
+// logging) or a cancellation of a workflow run, opened over the Actions tab
+// with R and c. The Actions views show the expected status while the request
+// is in flight and roll it back if GitHub rejects it (see view_actions.go).
+// When to extend: Add new run actions to rerunChoices and RunAction.Kind
+
+// rerunChoices are the ways a run can be re-run, in dialog order
+var rerunChoices = []struct {
+	kind  string
+	label string
+}{
+	{"rerun", "All jobs"},
+	{"rerun-failed", "Failed jobs"},
+}
+
+// runActionLabel describes a run action for status messages
+func runActionLabel(action RunAction) string {
+	label := "re-run"
+	switch action.Kind {
+	case "rerun-failed":
+		label = "re-run of failed jobs"
+	case "cancel":
+		return "cancellation"
+	}
+	if action.Debug {
+		label += " with debug logging"
+	}
+	return label
+}
+
+// runHasFailedJobs tells whether "re-run failed jobs" applies to run
+func runHasFailedJobs(run WorkflowRun) bool {
+	switch run.Conclusion {
+	case "failure", "cancelled", "timed_out", "startup_failure":
+		return true
+	}
+	return false
+}
+
+// RunActionDialog confirms re-running or cancelling a workflow run
+// The list shows the outcome as soon as the action is confirmed.
+type RunActionDialog struct {
+	repo    string
+	run     WorkflowRun
+	cancel  bool // Cancelling rather than re-running
+	choice  int  // Index into rerunChoices
+	debug   bool
+	focused bool
+}
+
+// NewRunActionDialog creates the dialog to re-run (or, with cancel, to cancel) run
+func NewRunActionDialog(repo string, run WorkflowRun, cancel bool) *RunActionDialog {
+	d := &RunActionDialog{repo: repo, run: run, cancel: cancel}
+	// Failed runs are usually re-run for their failures
+	if runHasFailedJobs(run) {
+		d.choice = 1
+	}
+	return d
+}
+
+// Update handles messages for the run action dialog
+func (d *RunActionDialog) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !d.focused {
+		return d, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "n", "q":
+		return d, closeOverlay()
+	case "enter", "y":
+		return d, d.confirm()
+	}
+	if d.cancel {
+		return d, nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "right", "l", "shift+tab", "left", "h":
+		if runHasFailedJobs(d.run) {
+			d.choice = (d.choice + 1) % len(rerunChoices)
+		}
+	case "d", " ":
+		d.debug = !d.debug
+	}
+	return d, nil
+}
+
+// confirm closes the dialog, then updates the list and sends the action
+func (d *RunActionDialog) confirm() tea.Cmd {
+	action := RunAction{Kind: "cancel"}
+	if !d.cancel {
+		action = RunAction{Kind: rerunChoices[d.choice].kind, Debug: d.debug}
+	}
+	runID := d.run.DatabaseId
+	started := func() tea.Msg { return runActionStartedMsg{runID: runID, action: action} }
+	return tea.Sequence(closeOverlay(), started, runWorkflowAction(d.repo, d.run, action))
+}
+
+// View renders the dialog centered in the content area
+func (d *RunActionDialog) View(width, height int) string {
+	boxWidth := min(64, width-4)
+	inner := boxWidth - 6
+
+	title := fmt.Sprintf("Re-run %s #%d", d.run.Name, d.run.RunNumber)
+	if d.cancel {
+		title = fmt.Sprintf("Cancel %s #%d", d.run.Name, d.run.RunNumber)
+	}
+	lines := []string{dialogTitleStyle.Width(inner).Render(truncateString(title, inner)), ""}
+	lines = append(lines, dimmedStyle.Render(truncateString(d.run.HeadBranch+" • "+formatStatus(d.run.Status, d.run.Conclusion), inner)), "")
+
+	if d.cancel {
+		lines = append(lines, strings.Join(wrapText("Queued and running jobs will be stopped. Jobs that already finished keep their results.", inner), "\n"))
+		lines = append(lines, "", helpStyle.Render("Enter/y: Cancel run • Esc/n: Keep running"))
+		box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+	}
+
+	// Jobs to re-run
+	var buttons []string
+	for i, choice := range rerunChoices {
+		switch {
+		case i == d.choice:
+			buttons = append(buttons, buttonActiveStyle.Render(choice.label))
+		case choice.kind == "rerun-failed" && !runHasFailedJobs(d.run):
+			buttons = append(buttons, buttonStyle.Foreground(colorDimmed).Render(choice.label))
+		default:
+			buttons = append(buttons, buttonStyle.Render(choice.label))
+		}
+	}
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, buttons...), "")
+
+	check := "[ ]"
+	if d.debug {
+		check = "[x]"
+	}
+	lines = append(lines, check+" Enable debug logging")
+	if rerunChoices[d.choice].kind == "rerun-failed" {
+		lines = append(lines, dimmedStyle.Render(truncateString("Jobs the failed ones depend on run again too", inner)))
+	}
+
+	lines = append(lines, "", helpStyle.Render("Tab: Jobs • d: Debug logging • Enter: Re-run • Esc: Cancel"))
+	box := dialogBoxStyle.Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Focus sets the dialog as focused
+func (d *RunActionDialog) Focus() {
+	d.focused = true
+}
+
+// Blur sets the dialog as unfocused
+func (d *RunActionDialog) Blur() {
+	d.focused = false
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchKind is one of the searches run for a query
+type searchKind int
+
+const (
+	searchKindIssues searchKind = iota // Issues and PRs
+	searchKindRepos
+	searchKindCode
+)
+
+// searchKinds are the searches run for every query
+var searchKinds = []searchKind{searchKindIssues, searchKindRepos, searchKindCode}
+
+// searchGroup is the state of one kind of search
+type searchGroup struct {
+	loading bool
+	total   int
+	err     error
+}
+
+// searchRow is a line of the results: a group heading, a result that can be
+// selected, or a line of a code fragment
+type searchRow struct {
+	heading  string
+	kind     searchKind
+	issue    *SearchIssue
+	repo     *Repository
+	code     *CodeResult
+	fragment string   // Fragment line, shown under its file
+	spans    [][2]int // Matches in fragment
+}
+
+// selectable reports whether the row is a result
+func (r searchRow) selectable() bool {
+	return r.issue != nil || r.repo != nil || r.code != nil
+}
+
+// SearchView searches issues, PRs, repositories and code across GitHub with
+// GitHub's search syntax. The three searches run side by side and their
+// results are grouped by type; Enter opens a result in its tab.
+type SearchView struct {
+	input   textinput.Model
+	editing bool
+	query   string // Query of the results shown
+	focused bool
+	width   int
+	height  int
+
+	groups map[searchKind]*searchGroup
+	issues []SearchIssue
+	repos  []Repository
+	code   []CodeResult
+
+	rows   []searchRow
+	cursor int // Index into rows, always on a result when there are any
+}
+
+// NewSearchView creates a new search view
+func NewSearchView() *SearchView {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "is:pr is:open review-requested:@me, language:go stars:>1000, repo:cli/cli ..."
+	input.CharLimit = 256
+	return &SearchView{input: input, groups: make(map[searchKind]*searchGroup)}
+}
+
+// Update handles messages for the search view
+func (v *SearchView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case searchResultsMsg:
+		group, ok := v.groups[msg.kind]
+		if !ok || msg.query != v.query {
+			// A search for an earlier query
+			return v, nil
+		}
+		group.loading = false
+		group.err = msg.err
+		group.total = msg.total
+		switch msg.kind {
+		case searchKindIssues:
+			v.issues = msg.issues
+		case searchKindRepos:
+			v.repos = msg.repos
+		case searchKindCode:
+			v.code = msg.code
+		}
+		v.buildRows()
+
+	case showFilteredMsg:
+		// Another tab searching for something, like a dashboard tile
+		v.editing = false
+		v.input.Blur()
+		v.input.SetValue(msg.query)
+		return v, v.search(msg.query)
+
+	case tea.KeyMsg:
+		if !v.focused {
+			return v, nil
+		}
+		if v.editing {
+			return v, v.updateInput(msg)
+		}
+
+		switch msg.String() {
+		case "/":
+			v.editing = true
+			v.input.CursorEnd()
+			return v, v.input.Focus()
+		case "up", "k":
+			v.move(-1)
+		case "down", "j":
+			v.move(1)
+		case "[":
+			v.moveGroup(-1)
+		case "]":
+			v.moveGroup(1)
+		case "enter":
+			if row, ok := v.selectedRow(); ok {
+				return v, openSearchResult(row)
+			}
+		case "b":
+			if row, ok := v.selectedRow(); ok {
+				switch {
+				case row.issue != nil && row.issue.PullRequest != nil:
+					return v, openInBrowser("pr", fmt.Sprint(row.issue.Number), row.issue.Repo)
+				case row.issue != nil:
+					return v, openInBrowser("issue", fmt.Sprint(row.issue.Number), row.issue.Repo)
+				case row.repo != nil:
+					return v, openInBrowser("repo", row.repo.NameWithOwner, "")
+				}
+			}
+		}
+
+	case tea.MouseMsg:
+		if !v.focused {
+			return v, nil
+		}
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			v.move(-1)
+		case tea.MouseWheelDown:
+			v.move(1)
+		}
+	}
+
+	return v, nil
+}
+
+// updateInput edits the query; Enter searches and Esc goes back to the results
+func (v *SearchView) updateInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		query := strings.TrimSpace(v.input.Value())
+		if query == "" {
+			return nil
+		}
+		v.editing = false
+		v.input.Blur()
+		return v.search(query)
+	case "esc":
+		v.editing = false
+		v.input.Blur()
+		v.input.SetValue(v.query)
+		return nil
+	}
+	input, cmd := v.input.Update(msg)
+	v.input = input
+	return cmd
+}
+
+// search starts every kind of search for query
+func (v *SearchView) search(query string) tea.Cmd {
+	v.query = query
+	v.issues, v.repos, v.code = nil, nil, nil
+	v.cursor = 0
+	cmds := make([]tea.Cmd, len(searchKinds))
+	for i, kind := range searchKinds {
+		v.groups[kind] = &searchGroup{loading: true}
+		cmds[i] = searchGitHub(query, kind)
+	}
+	v.buildRows()
+	return tea.Batch(cmds...)
+}
+
+// Refresh runs the current query again
+func (v *SearchView) Refresh() tea.Cmd {
+	if v.query == "" {
+		return nil
+	}
+	return v.search(v.query)
+}
+
+// buildRows lays out the results: pull requests, issues, repositories and
+// code, keeping the cursor on the same result if it is still there
+func (v *SearchView) buildRows() {
+	selected := ""
+	if row, ok := v.selectedRow(); ok {
+		selected = searchRowKey(row)
+	}
+
+	var prs, issues []searchRow
+	for i := range v.issues {
+		row := searchRow{kind: searchKindIssues, issue: &v.issues[i]}
+		if v.issues[i].PullRequest != nil {
+			prs = append(prs, row)
+		} else {
+			issues = append(issues, row)
+		}
+	}
+
+	v.rows = nil
+	group := v.groups[searchKindIssues]
+	if group != nil && (group.loading || group.err != nil || len(v.issues) == 0) {
+		// One heading for both until there are results to split
+		v.rows = append(v.rows, searchRow{heading: "Pull Requests & Issues", kind: searchKindIssues})
+	} else if group != nil {
+		if len(prs) > 0 {
+			v.rows = append(v.rows, searchRow{heading: fmt.Sprintf("Pull Requests (%d)", len(prs)), kind: searchKindIssues})
+			v.rows = append(v.rows, prs...)
+		}
+		if len(issues) > 0 {
+			v.rows = append(v.rows, searchRow{heading: fmt.Sprintf("Issues (%d)", len(issues)), kind: searchKindIssues})
+			v.rows = append(v.rows, issues...)
+		}
+	}
+
+	if group := v.groups[searchKindRepos]; group != nil {
+		heading := "Repositories"
+		if !group.loading && group.err == nil {
+			heading += fmt.Sprintf(" (%d)", len(v.repos))
+		}
+		v.rows = append(v.rows, searchRow{heading: heading, kind: searchKindRepos})
+		for i := range v.repos {
+			v.rows = append(v.rows, searchRow{kind: searchKindRepos, repo: &v.repos[i]})
+		}
+	}
+
+	if group := v.groups[searchKindCode]; group != nil {
+		heading := "Code"
+		if !group.loading && group.err == nil {
+			heading += fmt.Sprintf(" (%d)", len(v.code))
+		}
+		v.rows = append(v.rows, searchRow{heading: heading, kind: searchKindCode})
+		for i := range v.code {
+			result := &v.code[i]
+			v.rows = append(v.rows, searchRow{kind: searchKindCode, code: result})
+			for _, fragment := range result.Fragments {
+				for _, line := range fragmentLines(fragment) {
+					v.rows = append(v.rows, searchRow{kind: searchKindCode, fragment: line.text, spans: line.spans})
+				}
+			}
+		}
+	}
+
+	v.cursor = -1
+	for i, row := range v.rows {
+		if row.selectable() && (v.cursor < 0 || searchRowKey(row) == selected) {
+			v.cursor = i
+		}
+	}
+	v.cursor = max(0, v.cursor)
+}
+
+// fragmentLine is one line of a code fragment with its matches
+type fragmentLine struct {
+	text  string
+	spans [][2]int
+}
+
+// fragmentLines splits a fragment into its non-blank lines, at most three
+// around the first match
+func fragmentLines(fragment CodeFragment) []fragmentLine {
+	var lines []fragmentLine
+	first := -1
+	offset := 0
+	for _, text := range strings.Split(fragment.Text, "\n") {
+		line := fragmentLine{text: strings.ReplaceAll(text, "\t", "    ")}
+		for _, span := range fragment.Matches {
+			if span[0] >= offset && span[1] <= offset+len(text) && !strings.Contains(text, "\t") {
+				line.spans = append(line.spans, [2]int{span[0] - offset, span[1] - offset})
+			}
+		}
+		offset += len(text) + 1
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if first < 0 && len(line.spans) > 0 {
+			first = len(lines)
+		}
+		lines = append(lines, line)
+	}
+
+	start := max(0, first-1)
+	return lines[start:min(len(lines), start+3)]
+}
+
+// searchRowKey identifies the result of a row across searches
+func searchRowKey(row searchRow) string {
+	switch {
+	case row.issue != nil:
+		return fmt.Sprintf("%s#%d", row.issue.Repo, row.issue.Number)
+	case row.repo != nil:
+		return row.repo.NameWithOwner
+	case row.code != nil:
+		return row.code.Repo + ":" + row.code.Path
+	}
+	return ""
+}
+
+// selectedRow returns the result under the cursor
+func (v *SearchView) selectedRow() (searchRow, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.rows) || !v.rows[v.cursor].selectable() {
+		return searchRow{}, false
+	}
+	return v.rows[v.cursor], true
+}
+
+// move moves the cursor to the next result in direction (1 or -1)
+func (v *SearchView) move(direction int) {
+	for i := v.cursor + direction; i >= 0 && i < len(v.rows); i += direction {
+		if v.rows[i].selectable() {
+			v.cursor = i
+			return
+		}
+	}
+}
+
+// moveGroup moves the cursor to the first result of the next or previous
+// group that has results
+func (v *SearchView) moveGroup(direction int) {
+	// Find the heading of the cursor's group
+	heading := v.cursor
+	for heading > 0 && v.rows[heading].heading == "" {
+		heading--
+	}
+	for i := heading + direction; i >= 0 && i < len(v.rows); i += direction {
+		if v.rows[i].heading == "" {
+			continue
+		}
+		if i+1 < len(v.rows) && v.rows[i+1].selectable() {
+			v.cursor = i + 1
+			return
+		}
+	}
+}
+
+// openSearchResult opens a result in its tab, or a file found by code
+// search in the code viewer
+func openSearchResult(row searchRow) tea.Cmd {
+	switch {
+	case row.issue != nil && row.issue.PullRequest != nil:
+		msg := showPullRequestMsg{repo: row.issue.Repo, pr: *row.issue.PullRequest}
+		return func() tea.Msg { return msg }
+	case row.issue != nil:
+		msg := showIssueMsg{repo: row.issue.Repo, issue: row.issue.Issue}
+		return func() tea.Msg { return msg }
+	case row.repo != nil:
+		msg := showRepoMsg{repo: *row.repo}
+		return func() tea.Msg { return msg }
+	case row.code != nil:
+		return openCodeView(*row.code)
+	}
+	return nil
+}
+
+// Filtering reports whether the query is being edited, so the model sends
+// every key here
+func (v *SearchView) Filtering() bool {
+	return v.editing
+}
+
+// View renders the search view
+func (v *SearchView) View(width, height int) string {
+	v.width = width
+	v.height = height
+
+	var lines []string
+	title := listTitleStyle.Render(" Search GitHub")
+	if v.query != "" && !v.editing {
+		title += dimmedStyle.Render(" • " + truncateString(v.query, max(10, width-20)))
+	}
+	lines = append(lines, title)
+	if v.editing {
+		v.input.Width = max(10, width-6)
+		lines = append(lines, " "+v.input.View())
+	} else {
+		lines = append(lines, helpStyle.Render(" /: Search • ↑/↓: Navigate • [/]: Previous/next group • Enter: Open • b: Browser"))
+	}
+	lines = append(lines, "")
+
+	if v.query == "" {
+		examples := []string{
+			"Issues, pull requests, repositories and code across GitHub, with GitHub's search syntax:",
+			"",
+			"  is:pr is:open review-requested:@me",
+			"  is:issue author:@me label:bug",
+			"  language:go stars:>1000 tui",
+			"  repo:cli/cli NewCmdRoot",
+		}
+		content := strings.Join(append(lines, dimmedStyle.Render(strings.Join(examples, "\n"))), "\n")
+		return lipgloss.NewStyle().Width(width).Height(height).Render(content)
+	}
+
+	body := v.renderRows(width, height-len(lines))
+	content := strings.Join(append(lines, body...), "\n")
+	return lipgloss.NewStyle().Width(width).Height(height).Render(content)
+}
+
+// renderRows renders the results that fit in height, keeping the cursor in view
+func (v *SearchView) renderRows(width, height int) []string {
+	var lines []string
+	cursorLine := 0
+	for i, row := range v.rows {
+		if i == v.cursor {
+			cursorLine = len(lines)
+		}
+		if row.heading != "" && i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, v.renderRow(i, row, width)...)
+	}
+
+	start := max(0, min(cursorLine-height/2, len(lines)-height))
+	return lines[start:min(len(lines), start+max(0, height))]
+}
+
+// renderRow renders one row; a heading also shows its search's state
+func (v *SearchView) renderRow(i int, row searchRow, width int) []string {
+	if row.heading != "" {
+		group := v.groups[row.kind]
+		heading := detailTitleStyle.Render(" " + row.heading)
+		switch {
+		case group.loading:
+			return []string{heading + dimmedStyle.Render(" searching...")}
+		case group.err != nil:
+			return []string{heading, statusFailureStyle.Render("   " + truncateString(group.err.Error(), width-4))}
+		}
+		shown := len(v.issues)
+		switch row.kind {
+		case searchKindRepos:
+			shown = len(v.repos)
+		case searchKindCode:
+			shown = len(v.code)
+		}
+		if group.total > shown {
+			heading += dimmedStyle.Render(fmt.Sprintf(" first %d of %s", shown, formatNumber(group.total)))
+		}
+		if shown == 0 {
+			return []string{heading, dimmedStyle.Render("   No results")}
+		}
+		return []string{heading}
+	}
+
+	if row.fragment != "" {
+		text := fitWidth(row.fragment, max(1, width-8))
+		return []string{"      " + highlightSpans(strings.TrimRight(text, " "), row.spans, dimmedStyle)}
+	}
+
+	cursor := "  "
+	style := listItemStyle
+	if i == v.cursor {
+		cursor = "▶ "
+		style = listSelectedStyle
+	}
+
+	var line, meta string
+	switch {
+	case row.issue != nil:
+		issue := row.issue
+		state := formatIssueState(issue.State)
+		if issue.PullRequest != nil {
+			state = formatPRState(issue.State, issue.PullRequest.IsDraft)
+		}
+		line = fmt.Sprintf("%s%s %s#%d %s", cursor, state, issue.Repo, issue.Number, issue.Title)
+		meta = fmt.Sprintf("%s • %s", issue.Author.Login, formatTimeAgo(issue.UpdatedAt))
+	case row.repo != nil:
+		line = cursor + row.repo.NameWithOwner
+		if row.repo.Description != "" {
+			line += " - " + row.repo.Description
+		}
+		meta = fmt.Sprintf("⭐ %s • %s", formatNumber(row.repo.StargazerCount), formatLanguage(row.repo.PrimaryLanguage))
+	case row.code != nil:
+		line = fmt.Sprintf("%s%s %s", cursor, row.code.Repo, row.code.Path)
+		meta = fmt.Sprintf("%d matches", len(row.code.Fragments))
+		if len(row.code.Fragments) == 1 {
+			meta = "1 match"
+		}
+	}
+
+	line = truncateString(line, max(10, width-len(meta)-5))
+	if len(line)+len(meta)+3 < width {
+		line = padRight(line, width-len(meta)-3) + dimmedStyle.Render(meta)
+	}
+	return []string{style.Render(line)}
+}
+
+// Focus sets the view as focused
+func (v *SearchView) Focus() {
+	v.focused = true
+}
+
+// Blur sets the view as unfocused
+func (v *SearchView) Blur() {
+	v.focused = false
+}
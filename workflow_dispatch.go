@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workflow_dispatch.go - Workflow Dispatch Inputs
+// Purpose: Read the on.workflow_dispatch.inputs schema of a workflow file
+// The dispatch dialog (view_dispatch.go) builds its form from the result.
+// When to extend: Add support for new input types here and in the dialog
+
+// parseDispatchInputs returns the workflow_dispatch inputs of a workflow
+// file, in file order, and whether the workflow can be dispatched at all
+func parseDispatchInputs(data []byte) ([]WorkflowInput, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, fmt.Errorf("invalid workflow file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, false, nil
+	}
+
+	// "on" may be a single event, a list of events or a map of event configs
+	on := mappingValue(doc.Content[0], "on")
+	if on == nil {
+		return nil, false, nil
+	}
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return nil, on.Value == "workflow_dispatch", nil
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event.Value == "workflow_dispatch" {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+
+	dispatch := mappingValue(on, "workflow_dispatch")
+	if dispatch == nil {
+		return nil, false, nil
+	}
+	inputs := mappingValue(dispatch, "inputs")
+	if inputs == nil || inputs.Kind != yaml.MappingNode {
+		return nil, true, nil
+	}
+
+	var result []WorkflowInput
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		input := WorkflowInput{Name: inputs.Content[i].Value, Type: "string"}
+		spec := inputs.Content[i+1]
+		if node := mappingValue(spec, "description"); node != nil {
+			input.Description = strings.TrimSpace(node.Value)
+		}
+		if node := mappingValue(spec, "type"); node != nil && node.Value != "" {
+			input.Type = node.Value
+		}
+		if node := mappingValue(spec, "required"); node != nil {
+			input.Required = node.Value == "true"
+		}
+		if node := mappingValue(spec, "default"); node != nil {
+			input.Default = node.Value
+		}
+		if node := mappingValue(spec, "options"); node != nil {
+			for _, option := range node.Content {
+				input.Options = append(input.Options, option.Value)
+			}
+		}
+		result = append(result, input)
+	}
+	return result, true, nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, nil when absent
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// How long dispatchWorkflowRun waits for the new run to show up
+const (
+	dispatchPollAttempts = 10
+	dispatchPollInterval = time.Second
+)
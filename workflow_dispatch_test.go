@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name       string
+		workflow   string
+		want       []WorkflowInput
+		dispatched bool
+	}{
+		{
+			name:       "single event",
+			workflow:   "on: workflow_dispatch\n",
+			dispatched: true,
+		},
+		{
+			name:       "list of events",
+			workflow:   "on: [push, workflow_dispatch]\n",
+			dispatched: true,
+		},
+		{
+			name:     "not dispatchable",
+			workflow: "on:\n  push:\n    branches: [main]\n",
+		},
+		{
+			name:     "no triggers",
+			workflow: "name: CI\n",
+		},
+		{
+			name:       "no inputs",
+			workflow:   "on:\n  push:\n  workflow_dispatch:\n",
+			dispatched: true,
+		},
+		{
+			name: "inputs in file order",
+			workflow: `on:
+  workflow_dispatch:
+    inputs:
+      version:
+        description: "  Version to release  "
+        required: true
+      level:
+        type: choice
+        default: info
+        options: [debug, info, warning]
+      dry_run:
+        type: boolean
+        default: false
+`,
+			want: []WorkflowInput{
+				{Name: "version", Description: "Version to release", Type: "string", Required: true},
+				{Name: "level", Type: "choice", Default: "info", Options: []string{"debug", "info", "warning"}},
+				{Name: "dry_run", Type: "boolean", Default: "false"},
+			},
+			dispatched: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dispatched, err := parseDispatchInputs([]byte(tt.workflow))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dispatched != tt.dispatched {
+				t.Errorf("dispatchable = %t, want %t", dispatched, tt.dispatched)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := parseDispatchInputs([]byte("on: [push")); err == nil {
+		t.Error("no error for invalid YAML")
+	}
+}
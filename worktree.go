@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// worktree.go - PR Worktrees
+// Purpose: Check out PR heads into git worktrees beside the local clone, so
+// several PRs can be worked on without touching the main working copy.
+// Heads are fetched from refs/pull/N/head of the base repo, which also
+// covers PRs from forks without adding their remotes.
+// When to extend: Add worktree actions (e.g. pruning) here
+
+// worktreeDir is where PR worktrees are created, set up in main() from
+// Git.WorktreeDir; relative paths are relative to the local clone
+var worktreeDir = ".."
+
+// worktreeReadyMsg reports a PR worktree that was created or reused
+type worktreeReadyMsg struct {
+	number int
+	path   string
+	reused bool
+	note   string // Why a reused worktree wasn't updated to the PR head
+	err    error
+}
+
+// worktreeExecFinishedMsg reports that a shell or editor opened in a worktree exited
+type worktreeExecFinishedMsg struct {
+	path string
+	err  error
+}
+
+// checkoutPRWorktree fetches the head of PR number into its worktree
+func checkoutPRWorktree(repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := resolveRepo(repo)
+		if err != nil {
+			return worktreeReadyMsg{number: number, err: err}
+		}
+		path, reused, note, err := preparePRWorktree(repo, number)
+		return worktreeReadyMsg{number: number, path: path, reused: reused, note: note, err: err}
+	}
+}
+
+// preparePRWorktree creates the worktree of PR number on branch pr-N, or
+// fast-forwards it to the PR head when it or the branch already exists
+func preparePRWorktree(repo string, number int) (string, bool, string, error) {
+	root, err := runGit(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", false, "", fmt.Errorf("not in a git repository; run gh-tui from a clone of %s", repo)
+	}
+	remote, err := findRemote(root, repo)
+	if err != nil {
+		return "", false, "", err
+	}
+
+	// Into a ref of its own: FETCH_HEAD is shared by checkouts running at once
+	ref := fmt.Sprintf("refs/remotes/%s/pr/%d", remote, number)
+	if _, err := runGit(root, "fetch", remote, fmt.Sprintf("+refs/pull/%d/head:%s", number, ref)); err != nil {
+		return "", false, "", err
+	}
+	head, err := runGit(root, "rev-parse", ref)
+	if err != nil {
+		return "", false, "", err
+	}
+
+	branch := fmt.Sprintf("pr-%d", number)
+	existing, err := findWorktree(root, branch)
+	if err != nil {
+		return "", false, "", err
+	}
+	if existing != "" {
+		// Never discard local work; only move forward to the new head
+		if _, err := runGit(existing, "merge", "--ff-only", head); err != nil {
+			return existing, true, fmt.Sprintf("not updated to the PR head (%v)", err), nil
+		}
+		return existing, true, "", nil
+	}
+
+	path := prWorktreePath(root, repo, number)
+	if _, err := os.Stat(path); err == nil {
+		return "", false, "", fmt.Errorf("%s already exists and isn't the worktree of %s", path, branch)
+	}
+	if !branchExists(root, branch) {
+		if _, err := runGit(root, "worktree", "add", "-b", branch, path, head); err != nil {
+			return "", false, "", err
+		}
+		return path, false, "", nil
+	}
+
+	// The branch is left from an earlier checkout and may have commits of
+	// its own; like a reused worktree, it only moves forward to the new head
+	if _, err := runGit(root, "worktree", "add", path, branch); err != nil {
+		return "", false, "", err
+	}
+	if _, err := runGit(path, "merge", "--ff-only", head); err != nil {
+		return path, false, fmt.Sprintf("%s has commits that aren't in the PR; not updated to the PR head (%v)", branch, err), nil
+	}
+	return path, false, "", nil
+}
+
+// prWorktreePath returns where the worktree of PR number goes, e.g. ../gh-tui-pr-42
+func prWorktreePath(root, repo string, number int) string {
+	dir := worktreeDir
+	if home, err := os.UserHomeDir(); err == nil && (dir == "~" || strings.HasPrefix(dir, "~/")) {
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	_, name, _ := splitRepo(repo)
+	return filepath.Join(dir, fmt.Sprintf("%s-pr-%d", name, number))
+}
+
+// findRemote returns the name of the clone's remote that points at repo
+func findRemote(root, repo string) (string, error) {
+	output, err := runGit(root, "remote", "-v")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		url := strings.TrimSuffix(strings.TrimSuffix(fields[1], "/"), ".git")
+		url = strings.ToLower(url)
+		target := strings.ToLower(repo)
+		if strings.HasSuffix(url, "/"+target) || strings.HasSuffix(url, ":"+target) {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no remote of %s points at %s; run gh-tui from a clone of it", root, repo)
+}
+
+// findWorktree returns the path of the worktree that has branch checked out
+func findWorktree(root, branch string) (string, error) {
+	output, err := runGit(root, "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	path := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = strings.TrimPrefix(line, "worktree ")
+		case line == "branch refs/heads/"+branch:
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// branchExists reports whether the clone at root has a local branch
+func branchExists(root, branch string) bool {
+	_, err := runGit(root, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// runGit runs git in dir and returns its trimmed output
+// Failures carry git's own error message.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimPrefix(message, "fatal: "))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// openWorktreeShell suspends the TUI and starts $SHELL in path
+func openWorktreeShell(path string) tea.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	cmd := exec.Command(shell)
+	cmd.Dir = path
+	return execInWorktree(path, cmd)
+}
+
+// openWorktreeEditor suspends the TUI and opens $VISUAL or $EDITOR on path
+func openWorktreeEditor(path string) tea.Cmd {
+	cmd := editorCommand(".")
+	cmd.Dir = path
+	return execInWorktree(path, cmd)
+}
+
+// execInWorktree runs cmd in the foreground, resuming the TUI when it exits
+func execInWorktree(path string, cmd *exec.Cmd) tea.Cmd {
+	return tea.Sequence(
+		tea.ClearScreen,
+		tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return worktreeExecFinishedMsg{path: path, err: err}
+		}),
+	)
+}
+
+// editorCommand builds the command for the user's editor ($VISUAL, then
+// $EDITOR, then vi); the variables may include arguments, e.g. "code -w"
+func editorCommand(args ...string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+	}
+	return exec.Command(fields[0], append(fields[1:], args...)...)
+}